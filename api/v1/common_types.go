@@ -1,6 +1,8 @@
 package v1
 
 import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -66,6 +68,45 @@ type UploadStatus struct {
 	StoredMD5Checksum string `json:"storedMD5Checksum,omitempty"`
 }
 
+// DownloadStatus lists short lived URLs that the artifacts can be downloaded
+// from. It is populated when a download is requested with the
+// "substratus.ai/download-request" annotation.
+type DownloadStatus struct {
+	// RequestID is the value of the download request annotation that this
+	// status corresponds to.
+	RequestID string `json:"requestID,omitempty"`
+
+	// Expiration is the time at which the signed URLs expire. A new
+	// request ID can be set to get new URLs.
+	Expiration metav1.Time `json:"expiration,omitempty"`
+
+	// Files under "artifacts/" and their signed URLs.
+	Files []DownloadFile `json:"files,omitempty"`
+
+	// Message explains why no download URLs are available (i.e. because
+	// there are too many files).
+	Message string `json:"message,omitempty"`
+}
+
+type DownloadFile struct {
+	// Path relative to the artifacts directory.
+	Path string `json:"path"`
+
+	// Size of the file in bytes.
+	Size int64 `json:"size,omitempty"`
+
+	// SHA256 checksum (hex) of the file, taken from the artifacts manifest.
+	SHA256 string `json:"sha256,omitempty"`
+
+	// MD5Checksum (hex) of the file as reported by the bucket. Only set
+	// when the SHA256 checksum is not known.
+	MD5Checksum string `json:"md5Checksum,omitempty"`
+
+	// SignedURL is a short lived HTTPS URL that the file can be downloaded
+	// from with GET requests. Range requests are supported.
+	SignedURL string `json:"signedURL"`
+}
+
 type ObjectRef struct {
 	// Name of Kubernetes object.
 	Name string `json:"name"`
@@ -74,6 +115,85 @@ type ObjectRef struct {
 	// FUTURE: Possibly allow for cross-cluster references.
 }
 
+// DatasetRef references an object whose artifacts are used as a Dataset.
+type DatasetRef struct {
+	// Name of Kubernetes object.
+	Name string `json:"name"`
+
+	//+kubebuilder:validation:Enum=Dataset;BatchInference
+	// Kind of Kubernetes object. Defaults to "Dataset". Set to
+	// "BatchInference" to use the outputs of a BatchInference as a Dataset.
+	Kind string `json:"kind,omitempty"`
+}
+
+// DatasetMount references a Dataset that is mounted read-only under
+// /content/data.
+type DatasetMount struct {
+	//+kubebuilder:validation:Pattern="^[a-zA-Z0-9]([-a-zA-Z0-9_.]*[a-zA-Z0-9])?$"
+	// Name of the directory under /content/data that the Dataset is mounted
+	// at. A single Dataset without a name is mounted at /content/data. When
+	// multiple Datasets are mounted, defaults to the name of the Dataset.
+	Name string `json:"name,omitempty"`
+
+	// Dataset to mount.
+	Dataset DatasetRef `json:"dataset"`
+}
+
+// ImportSource describes where the built-in importer downloads artifacts
+// from. Exactly one source should be set. The importer runs instead of a
+// custom image and writes into the artifacts of the object.
+type ImportSource struct {
+	// HuggingFace repo to download.
+	HuggingFace *HuggingFaceSource `json:"huggingFace,omitempty"`
+
+	// URLs of files to download.
+	URLs []URLSource `json:"urls,omitempty"`
+
+	// Bucket to copy from.
+	Bucket *BucketSource `json:"bucket,omitempty"`
+}
+
+// HuggingFaceSource is a Hugging Face repo. A token can be provided in the
+// HF_TOKEN environment variable (see .spec.env).
+type HuggingFaceSource struct {
+	// Repo is the ID of the repo (i.e. "facebook/opt-125m").
+	Repo string `json:"repo"`
+
+	// Revision is a branch, tag or commit. Defaults to "main".
+	Revision string `json:"revision,omitempty"`
+
+	//+kubebuilder:validation:Enum=model;dataset
+	// RepoType is the type of the repo. Defaults to "dataset" for Datasets
+	// and "model" for Models.
+	RepoType string `json:"repoType,omitempty"`
+
+	// Files are glob patterns of the files to download (i.e. "*.json").
+	// Defaults to all files in the repo.
+	Files []string `json:"files,omitempty"`
+}
+
+// URLSource is a single file that is downloaded over HTTP(S).
+type URLSource struct {
+	//+kubebuilder:validation:Pattern="^https?://"
+	// URL of the file.
+	URL string `json:"url"`
+
+	// Path of the file relative to the artifacts directory. Defaults to the
+	// last element of the URL path.
+	Path string `json:"path,omitempty"`
+
+	//+kubebuilder:validation:Pattern="^[a-f0-9]{64}$"
+	// SHA256 checksum (hex) that the downloaded file is verified against.
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// BucketSource is a directory in a bucket.
+type BucketSource struct {
+	// URL of the directory (i.e. "gs://my-bucket/path/to/data"). It is
+	// mounted read-only at /content/source.
+	URL string `json:"url"`
+}
+
 type Resources struct {
 	//+kubebuilder:default:=2
 	// CPU resources.
@@ -95,8 +215,13 @@ type GPUType string
 
 const (
 	GPUTypeNvidiaA100 = GPUType("nvidia-a100")
+	GPUTypeNvidiaH100 = GPUType("nvidia-h100")
 	GPUTypeNvidiaT4   = GPUType("nvidia-t4")
 	GPUTypeNvidiaL4   = GPUType("nvidia-l4")
+	GPUTypeNvidiaA10G = GPUType("nvidia-a10g")
+	GPUTypeNvidiaV100 = GPUType("nvidia-v100")
+	GPUTypeAMDMI250   = GPUType("amd-mi250")
+	GPUTypeAMDMI300X  = GPUType("amd-mi300x")
 )
 
 type GPUResources struct {
@@ -106,6 +231,77 @@ type GPUResources struct {
 	Count int64 `json:"count,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=spot;on-demand
+type CapacityType string
+
+const (
+	CapacityTypeSpot     = CapacityType("spot")
+	CapacityTypeOnDemand = CapacityType("on-demand")
+)
+
+type Scheduling struct {
+	// CapacityType selects spot or on-demand nodes.
+	// Defaults to the capacity type configured for the cloud.
+	CapacityType CapacityType `json:"capacityType,omitempty"`
+
+	// NodeSelector is merged with the node selectors required by the
+	// requested resources (i.e. GPUs).
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations are added to the Pod.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity is set on the Pod.
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// PriorityClassName is set on the Pod.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// RunPolicy controls how the Job that runs a container is retried, timed out
+// and cleaned up.
+type RunPolicy struct {
+	//+kubebuilder:validation:Minimum=0
+	// Retries is the number of times a failed Pod is retried before the Job
+	// is considered failed.
+	Retries *int32 `json:"retries,omitempty"`
+
+	//+kubebuilder:validation:Minimum=1
+	// MaxDurationSeconds is how long the Job may run before it is terminated
+	// and considered failed.
+	MaxDurationSeconds *int64 `json:"maxDurationSeconds,omitempty"`
+
+	//+kubebuilder:validation:Minimum=0
+	// TTLSecondsAfterFinished is how long a finished Job (and its Pods) are
+	// kept around before being deleted.
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+
+	// PodFailurePolicy rules decide whether a failed Pod counts towards
+	// retries. For example: fail the Job on exit code 1, or ignore failures
+	// caused by spot preemption (the "DisruptionTarget" Pod condition).
+	PodFailurePolicy []batchv1.PodFailurePolicyRule `json:"podFailurePolicy,omitempty"`
+}
+
+// ArtifactsSpec references artifacts that already exist in a bucket.
+type ArtifactsSpec struct {
+	// URL of the existing artifacts (i.e. "gs://my-bucket/some/path"). It
+	// follows the same layout as .status.artifacts.url: files are expected
+	// under the "artifacts/" directory at this URL.
+	URL string `json:"url"`
+}
+
 type ArtifactsStatus struct {
 	URL string `json:"url,omitempty"`
+
+	// SizeBytes is the total size of the files under "artifacts/".
+	SizeBytes int64 `json:"sizeBytes,omitempty"`
+
+	// FileCount is the number of files under "artifacts/".
+	FileCount int64 `json:"fileCount,omitempty"`
+
+	// ManifestURL is the URL of a JSON manifest that lists the path, size and
+	// sha256 checksum of every artifact. It is stored alongside the
+	// artifacts and is only recorded for artifacts that were produced by
+	// Substratus.
+	ManifestURL string `json:"manifestURL,omitempty"`
 }
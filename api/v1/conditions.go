@@ -1,10 +1,15 @@
 package v1
 
 const (
-	ConditionUploaded = "Uploaded"
-	ConditionBuilt    = "Built"
-	ConditionComplete = "Complete"
-	ConditionServing  = "Serving"
+	ConditionUploaded  = "Uploaded"
+	ConditionBuilt     = "Built"
+	ConditionComplete  = "Complete"
+	ConditionServing   = "Serving"
+	ConditionRefreshed = "Refreshed"
+
+	ConditionInventoried = "Inventoried"
+
+	ConditionDeletionBlocked = "DeletionBlocked"
 )
 
 const (
@@ -16,10 +21,17 @@ const (
 
 	ReasonDatasetNotFound = "DatasetNotFound"
 	ReasonDatasetNotReady = "ReasonDatasetNotReady"
+	// ReasonDatasetKindUnsupported is reported when a Dataset reference has
+	// a kind that can not be used as a Dataset.
+	ReasonDatasetKindUnsupported = "DatasetKindUnsupported"
+	// ReasonDatasetMountInvalid is reported when Dataset mounts can never be
+	// satisfied (i.e. they form a cycle or share a directory).
+	ReasonDatasetMountInvalid = "DatasetMountInvalid"
 
 	ReasonJobNotComplete     = "JobNotComplete"
 	ReasonJobComplete        = "JobComplete"
 	ReasonJobFailed          = "JobFailed"
+	ReasonJobPreempted       = "JobPreempted"
 	ReasonDeploymentReady    = "DeploymentReady"
 	ReasonDeploymentNotReady = "DeploymentNotReady"
 	ReasonPodReady           = "PodReady"
@@ -27,6 +39,17 @@ const (
 
 	ReasonSuspended = "Suspended"
 
+	ReasonInvalidSchedule = "InvalidSchedule"
+
+	ReasonResultsInvalid = "ResultsInvalid"
+
+	ReasonArtifactsAdopted  = "ArtifactsAdopted"
+	ReasonArtifactsNotFound = "ArtifactsNotFound"
+	ReasonArtifactsInvalid  = "ArtifactsInvalid"
+	ReasonArtifactsListed   = "ArtifactsListed"
+
+	ReasonReferenced = "Referenced"
+
 	ReasonAwaitingUpload = "AwaitingUpload"
 	ReasonUploadFound    = "UploadFound"
 )
@@ -0,0 +1,232 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/substratusai/substratus/api/v1beta1"
+)
+
+// ResourcesAnnotation holds the v1beta1 resources of an object when they
+// can not be represented in v1 (i.e. fractional CPU or limits). It allows
+// objects to make a round trip through v1 without losing information.
+const ResourcesAnnotation = "substratus.ai/v1beta1-resources"
+
+const gigabyte = int64(1024 * 1024 * 1024)
+
+var (
+	_ conversion.Convertible = &Model{}
+	_ conversion.Convertible = &Dataset{}
+	_ conversion.Convertible = &Server{}
+	_ conversion.Convertible = &Notebook{}
+)
+
+func (src *Model) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.Model)
+	if err := convertViaJSON(src, dst); err != nil {
+		return err
+	}
+	var err error
+	dst.Spec.Resources, err = resourcesToHub(&dst.ObjectMeta, src.Spec.Resources)
+	return err
+}
+
+func (dst *Model) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.Model)
+	if err := convertViaJSON(src, dst); err != nil {
+		return err
+	}
+	var err error
+	dst.Spec.Resources, err = resourcesFromHub(&dst.ObjectMeta, src.Spec.Resources)
+	return err
+}
+
+func (src *Dataset) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.Dataset)
+	if err := convertViaJSON(src, dst); err != nil {
+		return err
+	}
+	var err error
+	dst.Spec.Resources, err = resourcesToHub(&dst.ObjectMeta, src.Spec.Resources)
+	return err
+}
+
+func (dst *Dataset) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.Dataset)
+	if err := convertViaJSON(src, dst); err != nil {
+		return err
+	}
+	var err error
+	dst.Spec.Resources, err = resourcesFromHub(&dst.ObjectMeta, src.Spec.Resources)
+	return err
+}
+
+func (src *Server) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.Server)
+	if err := convertViaJSON(src, dst); err != nil {
+		return err
+	}
+	var err error
+	dst.Spec.Resources, err = resourcesToHub(&dst.ObjectMeta, src.Spec.Resources)
+	return err
+}
+
+func (dst *Server) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.Server)
+	if err := convertViaJSON(src, dst); err != nil {
+		return err
+	}
+	var err error
+	dst.Spec.Resources, err = resourcesFromHub(&dst.ObjectMeta, src.Spec.Resources)
+	return err
+}
+
+func (src *Notebook) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.Notebook)
+	if err := convertViaJSON(src, dst); err != nil {
+		return err
+	}
+	var err error
+	dst.Spec.Resources, err = resourcesToHub(&dst.ObjectMeta, src.Spec.Resources)
+	return err
+}
+
+func (dst *Notebook) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.Notebook)
+	if err := convertViaJSON(src, dst); err != nil {
+		return err
+	}
+	var err error
+	dst.Spec.Resources, err = resourcesFromHub(&dst.ObjectMeta, src.Spec.Resources)
+	return err
+}
+
+// convertViaJSON copies all fields that share the same JSON representation
+// across versions (everything except .spec.resources, which is converted
+// separately). The TypeMeta of dst is left untouched.
+func convertViaJSON(src, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return fmt.Errorf("marshalling: %w", err)
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return fmt.Errorf("unmarshalling to map: %w", err)
+	}
+	delete(m, "apiVersion")
+	delete(m, "kind")
+	if spec, ok := m["spec"].(map[string]interface{}); ok {
+		delete(spec, "resources")
+	}
+	data, err = json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("marshalling map: %w", err)
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		return fmt.Errorf("unmarshalling: %w", err)
+	}
+	return nil
+}
+
+// resourcesToHub converts v1 resources to v1beta1. If the object was
+// previously converted from v1beta1 and the v1 resources were not modified
+// since, the original v1beta1 resources are restored from the annotation.
+func resourcesToHub(meta *metav1.ObjectMeta, src *Resources) (*v1beta1.Resources, error) {
+	var restored *v1beta1.Resources
+	if data, ok := meta.Annotations[ResourcesAnnotation]; ok {
+		delete(meta.Annotations, ResourcesAnnotation)
+		if len(meta.Annotations) == 0 {
+			meta.Annotations = nil
+		}
+		if err := json.Unmarshal([]byte(data), &restored); err != nil {
+			return nil, fmt.Errorf("unmarshalling %s annotation: %w", ResourcesAnnotation, err)
+		}
+	}
+
+	if restored != nil && equality.Semantic.DeepEqual(convertResourcesFromHub(restored), src) {
+		return restored, nil
+	}
+
+	return convertResourcesToHub(src), nil
+}
+
+// resourcesFromHub converts v1beta1 resources to v1. If the conversion is
+// lossy, the v1beta1 resources are saved in an annotation.
+func resourcesFromHub(meta *metav1.ObjectMeta, src *v1beta1.Resources) (*Resources, error) {
+	dst := convertResourcesFromHub(src)
+
+	if equality.Semantic.DeepEqual(convertResourcesToHub(dst), src) {
+		if meta.Annotations != nil {
+			delete(meta.Annotations, ResourcesAnnotation)
+		}
+		return dst, nil
+	}
+
+	data, err := json.Marshal(src)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling resources: %w", err)
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[ResourcesAnnotation] = string(data)
+
+	return dst, nil
+}
+
+func convertResourcesToHub(src *Resources) *v1beta1.Resources {
+	if src == nil {
+		return nil
+	}
+	dst := &v1beta1.Resources{}
+	if src.CPU != 0 {
+		dst.CPU = resource.NewQuantity(src.CPU, resource.DecimalSI)
+	}
+	if src.Disk != 0 {
+		dst.Disk = resource.NewQuantity(src.Disk*gigabyte, resource.BinarySI)
+	}
+	if src.Memory != 0 {
+		dst.Memory = resource.NewQuantity(src.Memory*gigabyte, resource.BinarySI)
+	}
+	if src.GPU != nil {
+		dst.GPU = &v1beta1.GPUResources{
+			Type:  v1beta1.GPUType(src.GPU.Type),
+			Count: src.GPU.Count,
+		}
+	}
+	return dst
+}
+
+// convertResourcesFromHub rounds quantities up to the units used in v1
+// (whole CPUs and Gigabytes). Limits are dropped.
+func convertResourcesFromHub(src *v1beta1.Resources) *Resources {
+	if src == nil {
+		return nil
+	}
+	dst := &Resources{}
+	if src.CPU != nil {
+		dst.CPU = divideRoundUp(src.CPU.MilliValue(), 1000)
+	}
+	if src.Disk != nil {
+		dst.Disk = divideRoundUp(src.Disk.Value(), gigabyte)
+	}
+	if src.Memory != nil {
+		dst.Memory = divideRoundUp(src.Memory.Value(), gigabyte)
+	}
+	if src.GPU != nil {
+		dst.GPU = &GPUResources{
+			Type:  GPUType(src.GPU.Type),
+			Count: src.GPU.Count,
+		}
+	}
+	return dst
+}
+
+func divideRoundUp(n, d int64) int64 {
+	return (n + d - 1) / d
+}
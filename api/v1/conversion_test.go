@@ -0,0 +1,88 @@
+package v1_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	apiv1 "github.com/substratusai/substratus/api/v1"
+	"github.com/substratusai/substratus/api/v1beta1"
+)
+
+func TestModelConversion(t *testing.T) {
+	v1Model := &apiv1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-model", Namespace: "my-ns"},
+		Spec: apiv1.ModelSpec{
+			Image:   ptr.To("my-image"),
+			Command: []string{"train.sh"},
+			Resources: &apiv1.Resources{
+				CPU:    4,
+				Memory: 16,
+				Disk:   100,
+				GPU:    &apiv1.GPUResources{Type: apiv1.GPUTypeNvidiaL4, Count: 1},
+			},
+			Dataset: &apiv1.DatasetRef{Name: "my-dataset"},
+		},
+		Status: apiv1.ModelStatus{Ready: true},
+	}
+
+	var hub v1beta1.Model
+	require.NoError(t, v1Model.ConvertTo(&hub))
+	require.Equal(t, "my-model", hub.Name)
+	require.Equal(t, "my-image", *hub.Spec.Image)
+	require.Equal(t, []string{"train.sh"}, hub.Spec.Command)
+	require.Equal(t, "my-dataset", hub.Spec.Dataset.Name)
+	require.True(t, hub.Status.Ready)
+	require.Equal(t, "4", hub.Spec.Resources.CPU.String())
+	require.Equal(t, "16Gi", hub.Spec.Resources.Memory.String())
+	require.Equal(t, "100Gi", hub.Spec.Resources.Disk.String())
+	require.Equal(t, v1beta1.GPUTypeNvidiaL4, hub.Spec.Resources.GPU.Type)
+
+	var roundTrip apiv1.Model
+	require.NoError(t, roundTrip.ConvertFrom(&hub))
+	require.Equal(t, v1Model.Spec, roundTrip.Spec)
+	require.Empty(t, roundTrip.Annotations, "lossless conversions should not be annotated")
+}
+
+func TestResourcesLossyConversion(t *testing.T) {
+	q := func(s string) *resource.Quantity {
+		v := resource.MustParse(s)
+		return &v
+	}
+	hub := &v1beta1.Dataset{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-dataset", Namespace: "my-ns"},
+		Spec: v1beta1.DatasetSpec{
+			Resources: &v1beta1.Resources{
+				CPU:    q("500m"),
+				Memory: q("1.5Gi"),
+				Limits: &v1beta1.ResourceLimits{Memory: q("2Gi")},
+			},
+		},
+	}
+
+	var v1Dataset apiv1.Dataset
+	require.NoError(t, v1Dataset.ConvertFrom(hub))
+	require.Equal(t, &apiv1.Resources{CPU: 1, Memory: 2}, v1Dataset.Spec.Resources, "quantities should be rounded up")
+	require.Contains(t, v1Dataset.Annotations, apiv1.ResourcesAnnotation)
+
+	t.Log("Unmodified v1 resources should be restored exactly")
+	var restored v1beta1.Dataset
+	require.NoError(t, v1Dataset.DeepCopy().ConvertTo(&restored))
+	require.Equal(t, "500m", restored.Spec.Resources.CPU.String())
+	require.Equal(t, "1536Mi", restored.Spec.Resources.Memory.String())
+	require.Equal(t, "2Gi", restored.Spec.Resources.Limits.Memory.String())
+	require.NotContains(t, restored.Annotations, apiv1.ResourcesAnnotation)
+
+	t.Log("Modified v1 resources should take precedence")
+	modified := v1Dataset.DeepCopy()
+	modified.Spec.Resources.CPU = 3
+	var updated v1beta1.Dataset
+	require.NoError(t, modified.ConvertTo(&updated))
+	require.Equal(t, "3", updated.Spec.Resources.CPU.String())
+	require.Equal(t, "2Gi", updated.Spec.Resources.Memory.String())
+	require.Nil(t, updated.Spec.Resources.Limits)
+	require.NotContains(t, updated.Annotations, apiv1.ResourcesAnnotation)
+}
@@ -20,9 +20,31 @@ type DatasetSpec struct {
 	// Build specifies how to build an image.
 	Build *Build `json:"build,omitempty"`
 
+	// Import data using the built-in importer instead of an image.
+	Import *ImportSource `json:"import,omitempty"`
+
+	// Artifacts adopts existing data instead of running a Job. The
+	// Dataset becomes ready once the artifacts are found.
+	Artifacts *ArtifactsSpec `json:"artifacts,omitempty"`
+
 	// Resources are the compute resources required by the container.
 	Resources *Resources `json:"resources,omitempty"`
 
+	// Scheduling controls where the workload is placed.
+	Scheduling *Scheduling `json:"scheduling,omitempty"`
+
+	// RunPolicy controls retries, timeouts and cleanup of the Job.
+	RunPolicy *RunPolicy `json:"runPolicy,omitempty"`
+
+	// Datasets are input Datasets that are mounted read-only under
+	// /content/data. The Dataset is loaded once all inputs are ready.
+	Datasets []DatasetMount `json:"datasets,omitempty"`
+
+	// Refresh controls when the Dataset is loaded again. A refresh can also
+	// be requested by setting the "substratus.ai/refresh" annotation to a
+	// new value (i.e. a timestamp).
+	Refresh *RefreshPolicy `json:"refresh,omitempty"`
+
 	// Params will be passed into the loading process as environment variables.
 	Params map[string]intstr.IntOrString `json:"params,omitempty"`
 }
@@ -86,6 +108,41 @@ type DatasetStatus struct {
 
 	// BuildUpload contains the status of the build context upload.
 	BuildUpload UploadStatus `json:"buildUpload,omitempty"`
+
+	// Download contains signed URLs of the artifacts (see the
+	// "substratus.ai/download-request" annotation).
+	Download *DownloadStatus `json:"download,omitempty"`
+
+	// Version of the artifacts that are used by consumers. It is
+	// incremented every time a refresh completes.
+	Version int32 `json:"version,omitempty"`
+
+	// LastRefreshTime is when the latest load or refresh was started.
+	LastRefreshTime *metav1.Time `json:"lastRefreshTime,omitempty"`
+
+	// RefreshRequest is the value of the refresh annotation that was last
+	// handled.
+	RefreshRequest string `json:"refreshRequest,omitempty"`
+
+	// Refresh is the status of a refresh that is in progress. The previous
+	// version of the artifacts is used until the refresh completes.
+	Refresh *DatasetRefreshStatus `json:"refresh,omitempty"`
+}
+
+// RefreshPolicy controls when a Dataset is reloaded.
+type RefreshPolicy struct {
+	// Schedule is a cron expression (i.e. "0 3 * * *") that determines when
+	// the Dataset is loaded again.
+	Schedule string `json:"schedule,omitempty"`
+}
+
+// DatasetRefreshStatus describes a refresh that is in progress.
+type DatasetRefreshStatus struct {
+	// Version of the artifacts that are being loaded.
+	Version int32 `json:"version"`
+
+	// URL of the artifacts that are being loaded.
+	URL string `json:"url"`
 }
 
 //+kubebuilder:resource:categories=ai,shortName=data
@@ -20,21 +20,68 @@ type ModelSpec struct {
 	// Build specifies how to build an image.
 	Build *Build `json:"build,omitempty"`
 
+	// Import model artifacts using the built-in importer instead of an image.
+	Import *ImportSource `json:"import,omitempty"`
+
+	// Artifacts adopts existing model artifacts instead of running a Job. The
+	// Model becomes ready once the artifacts are found.
+	Artifacts *ArtifactsSpec `json:"artifacts,omitempty"`
+
 	// Resources are the compute resources required by the container.
 	Resources *Resources `json:"resources,omitempty"`
 
+	// Scheduling controls where the workload is placed.
+	Scheduling *Scheduling `json:"scheduling,omitempty"`
+
+	// RunPolicy controls retries, timeouts and cleanup of the Job.
+	RunPolicy *RunPolicy `json:"runPolicy,omitempty"`
+
+	// Resume should be set to restart training from the last checkpoint
+	// when the modeller Pod is preempted.
+	Resume *ResumePolicy `json:"resume,omitempty"`
+
+	//+kubebuilder:validation:Minimum=1
+	// Workers is the number of Pods (typically one per node) that train the
+	// Model together. Defaults to 1. When greater than 1, rendezvous
+	// environment variables are injected for distributed training.
+	Workers *int32 `json:"workers,omitempty"`
+
 	// Model should be set in order to mount another model to be
 	// used for transfer learning.
 	Model *ObjectRef `json:"model,omitempty"`
 
 	// Dataset to mount for training.
-	Dataset *ObjectRef `json:"dataset,omitempty"`
+	Dataset *DatasetRef `json:"dataset,omitempty"`
+
+	// Datasets are additional named Datasets to mount for training (for
+	// example separate training and validation sets). Every Dataset is
+	// mounted read-only at /content/data/<name> (defaults to the name of the
+	// Dataset). When combined with `.dataset`, that Dataset is mounted at
+	// /content/data/<dataset-name>.
+	Datasets []DatasetMount `json:"datasets,omitempty"`
 
 	// Parameters are passing into the model training/loading container as environment variables.
 	// Environment variable name will be `"PARAM_" + uppercase(key)`.
 	Params map[string]intstr.IntOrString `json:"params,omitempty"`
 }
 
+// ResumePolicy controls how preempted training runs are resumed.
+type ResumePolicy struct {
+	//+kubebuilder:default:=5
+	//+kubebuilder:validation:Minimum=1
+	// MaxAttempts is the maximum number of times the modeller Job is started
+	// (including the first attempt) before the Model is considered failed.
+	MaxAttempts int32 `json:"maxAttempts,omitempty"`
+}
+
+// GetWorkers returns the number of distributed training workers.
+func (m *Model) GetWorkers() int32 {
+	if m.Spec.Workers == nil || *m.Spec.Workers < 1 {
+		return 1
+	}
+	return *m.Spec.Workers
+}
+
 func (m *Model) GetParams() map[string]intstr.IntOrString {
 	return m.Spec.Params
 }
@@ -96,6 +143,72 @@ type ModelStatus struct {
 
 	// BuildUpload contains the status of the build context upload.
 	BuildUpload UploadStatus `json:"buildUpload,omitempty"`
+
+	// Download contains signed URLs of the artifacts (see the
+	// "substratus.ai/download-request" annotation).
+	Download *DownloadStatus `json:"download,omitempty"`
+
+	// Attempts is the number of times the modeller Job has been started.
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// Preemptions is the history of attempts that were interrupted by
+	// node preemption.
+	Preemptions []Preemption `json:"preemptions,omitempty"`
+
+	// Progress is the latest training progress reported by the container.
+	Progress *TrainingProgress `json:"progress,omitempty"`
+
+	// Evaluations summarizes the latest completed Evaluations of the Model.
+	Evaluations []EvaluationSummary `json:"evaluations,omitempty"`
+}
+
+// EvaluationSummary is the result of an Evaluation of a Model.
+type EvaluationSummary struct {
+	// Name of the Evaluation.
+	Name string `json:"name"`
+
+	// Dataset that the Model was evaluated against.
+	Dataset string `json:"dataset"`
+
+	// Metrics reported by the Evaluation.
+	Metrics map[string]string `json:"metrics,omitempty"`
+
+	// CompletionTime is when the Evaluation completed.
+	CompletionTime metav1.Time `json:"completionTime"`
+}
+
+// TrainingProgress is reported by the training container in
+// /content/artifacts/status.json.
+type TrainingProgress struct {
+	// Step is the current training step.
+	Step int64 `json:"step,omitempty"`
+
+	// TotalSteps is the total number of training steps (if known).
+	TotalSteps int64 `json:"totalSteps,omitempty"`
+
+	// Epoch is the current (possibly fractional) epoch.
+	Epoch string `json:"epoch,omitempty"`
+
+	// Loss is the latest training loss.
+	Loss string `json:"loss,omitempty"`
+
+	// Metrics are the latest evaluation metrics.
+	Metrics map[string]string `json:"metrics,omitempty"`
+
+	// UpdateTime is when the progress was last observed to change.
+	UpdateTime metav1.Time `json:"updateTime,omitempty"`
+}
+
+// Preemption records an attempt that was interrupted.
+type Preemption struct {
+	// Attempt is the attempt number that was interrupted.
+	Attempt int32 `json:"attempt"`
+
+	// Time is when the interruption was observed.
+	Time metav1.Time `json:"time"`
+
+	// Message describes the cause of the interruption.
+	Message string `json:"message,omitempty"`
 }
 
 //+kubebuilder:resource:categories=ai
@@ -27,11 +27,14 @@ type NotebookSpec struct {
 	// Resources are the compute resources required by the container.
 	Resources *Resources `json:"resources,omitempty"`
 
+	// Scheduling controls where the workload is placed.
+	Scheduling *Scheduling `json:"scheduling,omitempty"`
+
 	// Model to load into the notebook container.
 	Model *ObjectRef `json:"model,omitempty"`
 
 	// Dataset to load into the notebook container.
-	Dataset *ObjectRef `json:"dataset,omitempty"`
+	Dataset *DatasetRef `json:"dataset,omitempty"`
 
 	// Params will be passed into the notebook container as environment variables.
 	Params map[string]intstr.IntOrString `json:"params,omitempty"`
@@ -23,6 +23,9 @@ type ServerSpec struct {
 	// Resources are the compute resources required by the container.
 	Resources *Resources `json:"resources,omitempty"`
 
+	// Scheduling controls where the workload is placed.
+	Scheduling *Scheduling `json:"scheduling,omitempty"`
+
 	// Model references the Model object to be served.
 	Model ObjectRef `json:"model,omitempty"`
 
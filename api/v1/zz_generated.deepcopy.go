@@ -10,11 +10,28 @@ Copyright 2023.
 package v1
 
 import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactsSpec) DeepCopyInto(out *ArtifactsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactsSpec.
+func (in *ArtifactsSpec) DeepCopy() *ArtifactsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArtifactsStatus) DeepCopyInto(out *ArtifactsStatus) {
 	*out = *in
@@ -30,6 +47,21 @@ func (in *ArtifactsStatus) DeepCopy() *ArtifactsStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketSource) DeepCopyInto(out *BucketSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketSource.
+func (in *BucketSource) DeepCopy() *BucketSource {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Build) DeepCopyInto(out *Build) {
 	*out = *in
@@ -144,6 +176,52 @@ func (in *DatasetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatasetMount) DeepCopyInto(out *DatasetMount) {
+	*out = *in
+	out.Dataset = in.Dataset
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatasetMount.
+func (in *DatasetMount) DeepCopy() *DatasetMount {
+	if in == nil {
+		return nil
+	}
+	out := new(DatasetMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatasetRef) DeepCopyInto(out *DatasetRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatasetRef.
+func (in *DatasetRef) DeepCopy() *DatasetRef {
+	if in == nil {
+		return nil
+	}
+	out := new(DatasetRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatasetRefreshStatus) DeepCopyInto(out *DatasetRefreshStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatasetRefreshStatus.
+func (in *DatasetRefreshStatus) DeepCopy() *DatasetRefreshStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatasetRefreshStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatasetSpec) DeepCopyInto(out *DatasetSpec) {
 	*out = *in
@@ -169,11 +247,41 @@ func (in *DatasetSpec) DeepCopyInto(out *DatasetSpec) {
 		*out = new(Build)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Import != nil {
+		in, out := &in.Import, &out.Import
+		*out = new(ImportSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Artifacts != nil {
+		in, out := &in.Artifacts, &out.Artifacts
+		*out = new(ArtifactsSpec)
+		**out = **in
+	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(Resources)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(Scheduling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RunPolicy != nil {
+		in, out := &in.RunPolicy, &out.RunPolicy
+		*out = new(RunPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Datasets != nil {
+		in, out := &in.Datasets, &out.Datasets
+		*out = make([]DatasetMount, len(*in))
+		copy(*out, *in)
+	}
+	if in.Refresh != nil {
+		in, out := &in.Refresh, &out.Refresh
+		*out = new(RefreshPolicy)
+		**out = **in
+	}
 	if in.Params != nil {
 		in, out := &in.Params, &out.Params
 		*out = make(map[string]intstr.IntOrString, len(*in))
@@ -205,6 +313,20 @@ func (in *DatasetStatus) DeepCopyInto(out *DatasetStatus) {
 	}
 	out.Artifacts = in.Artifacts
 	in.BuildUpload.DeepCopyInto(&out.BuildUpload)
+	if in.Download != nil {
+		in, out := &in.Download, &out.Download
+		*out = new(DownloadStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastRefreshTime != nil {
+		in, out := &in.LastRefreshTime, &out.LastRefreshTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Refresh != nil {
+		in, out := &in.Refresh, &out.Refresh
+		*out = new(DatasetRefreshStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatasetStatus.
@@ -217,6 +339,65 @@ func (in *DatasetStatus) DeepCopy() *DatasetStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DownloadFile) DeepCopyInto(out *DownloadFile) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DownloadFile.
+func (in *DownloadFile) DeepCopy() *DownloadFile {
+	if in == nil {
+		return nil
+	}
+	out := new(DownloadFile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DownloadStatus) DeepCopyInto(out *DownloadStatus) {
+	*out = *in
+	in.Expiration.DeepCopyInto(&out.Expiration)
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]DownloadFile, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DownloadStatus.
+func (in *DownloadStatus) DeepCopy() *DownloadStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DownloadStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvaluationSummary) DeepCopyInto(out *EvaluationSummary) {
+	*out = *in
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvaluationSummary.
+func (in *EvaluationSummary) DeepCopy() *EvaluationSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(EvaluationSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GPUResources) DeepCopyInto(out *GPUResources) {
 	*out = *in
@@ -232,6 +413,56 @@ func (in *GPUResources) DeepCopy() *GPUResources {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HuggingFaceSource) DeepCopyInto(out *HuggingFaceSource) {
+	*out = *in
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HuggingFaceSource.
+func (in *HuggingFaceSource) DeepCopy() *HuggingFaceSource {
+	if in == nil {
+		return nil
+	}
+	out := new(HuggingFaceSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImportSource) DeepCopyInto(out *ImportSource) {
+	*out = *in
+	if in.HuggingFace != nil {
+		in, out := &in.HuggingFace, &out.HuggingFace
+		*out = new(HuggingFaceSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.URLs != nil {
+		in, out := &in.URLs, &out.URLs
+		*out = make([]URLSource, len(*in))
+		copy(*out, *in)
+	}
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(BucketSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportSource.
+func (in *ImportSource) DeepCopy() *ImportSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ImportSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Model) DeepCopyInto(out *Model) {
 	*out = *in
@@ -316,11 +547,41 @@ func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
 		*out = new(Build)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Import != nil {
+		in, out := &in.Import, &out.Import
+		*out = new(ImportSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Artifacts != nil {
+		in, out := &in.Artifacts, &out.Artifacts
+		*out = new(ArtifactsSpec)
+		**out = **in
+	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(Resources)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(Scheduling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RunPolicy != nil {
+		in, out := &in.RunPolicy, &out.RunPolicy
+		*out = new(RunPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resume != nil {
+		in, out := &in.Resume, &out.Resume
+		*out = new(ResumePolicy)
+		**out = **in
+	}
+	if in.Workers != nil {
+		in, out := &in.Workers, &out.Workers
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Model != nil {
 		in, out := &in.Model, &out.Model
 		*out = new(ObjectRef)
@@ -328,9 +589,14 @@ func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
 	}
 	if in.Dataset != nil {
 		in, out := &in.Dataset, &out.Dataset
-		*out = new(ObjectRef)
+		*out = new(DatasetRef)
 		**out = **in
 	}
+	if in.Datasets != nil {
+		in, out := &in.Datasets, &out.Datasets
+		*out = make([]DatasetMount, len(*in))
+		copy(*out, *in)
+	}
 	if in.Params != nil {
 		in, out := &in.Params, &out.Params
 		*out = make(map[string]intstr.IntOrString, len(*in))
@@ -362,6 +628,30 @@ func (in *ModelStatus) DeepCopyInto(out *ModelStatus) {
 	}
 	out.Artifacts = in.Artifacts
 	in.BuildUpload.DeepCopyInto(&out.BuildUpload)
+	if in.Download != nil {
+		in, out := &in.Download, &out.Download
+		*out = new(DownloadStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Preemptions != nil {
+		in, out := &in.Preemptions, &out.Preemptions
+		*out = make([]Preemption, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(TrainingProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Evaluations != nil {
+		in, out := &in.Evaluations, &out.Evaluations
+		*out = make([]EvaluationSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelStatus.
@@ -468,6 +758,11 @@ func (in *NotebookSpec) DeepCopyInto(out *NotebookSpec) {
 		*out = new(Resources)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(Scheduling)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Model != nil {
 		in, out := &in.Model, &out.Model
 		*out = new(ObjectRef)
@@ -475,7 +770,7 @@ func (in *NotebookSpec) DeepCopyInto(out *NotebookSpec) {
 	}
 	if in.Dataset != nil {
 		in, out := &in.Dataset, &out.Dataset
-		*out = new(ObjectRef)
+		*out = new(DatasetRef)
 		**out = **in
 	}
 	if in.Params != nil {
@@ -536,6 +831,37 @@ func (in *ObjectRef) DeepCopy() *ObjectRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Preemption) DeepCopyInto(out *Preemption) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Preemption.
+func (in *Preemption) DeepCopy() *Preemption {
+	if in == nil {
+		return nil
+	}
+	out := new(Preemption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RefreshPolicy) DeepCopyInto(out *RefreshPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RefreshPolicy.
+func (in *RefreshPolicy) DeepCopy() *RefreshPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RefreshPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Resources) DeepCopyInto(out *Resources) {
 	*out = *in
@@ -556,6 +882,92 @@ func (in *Resources) DeepCopy() *Resources {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResumePolicy) DeepCopyInto(out *ResumePolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResumePolicy.
+func (in *ResumePolicy) DeepCopy() *ResumePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ResumePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunPolicy) DeepCopyInto(out *RunPolicy) {
+	*out = *in
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxDurationSeconds != nil {
+		in, out := &in.MaxDurationSeconds, &out.MaxDurationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PodFailurePolicy != nil {
+		in, out := &in.PodFailurePolicy, &out.PodFailurePolicy
+		*out = make([]batchv1.PodFailurePolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunPolicy.
+func (in *RunPolicy) DeepCopy() *RunPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RunPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Scheduling) DeepCopyInto(out *Scheduling) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Scheduling.
+func (in *Scheduling) DeepCopy() *Scheduling {
+	if in == nil {
+		return nil
+	}
+	out := new(Scheduling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Server) DeepCopyInto(out *Server) {
 	*out = *in
@@ -645,6 +1057,11 @@ func (in *ServerSpec) DeepCopyInto(out *ServerSpec) {
 		*out = new(Resources)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(Scheduling)
+		(*in).DeepCopyInto(*out)
+	}
 	out.Model = in.Model
 	if in.Params != nil {
 		in, out := &in.Params, &out.Params
@@ -688,6 +1105,44 @@ func (in *ServerStatus) DeepCopy() *ServerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrainingProgress) DeepCopyInto(out *TrainingProgress) {
+	*out = *in
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.UpdateTime.DeepCopyInto(&out.UpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrainingProgress.
+func (in *TrainingProgress) DeepCopy() *TrainingProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(TrainingProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *URLSource) DeepCopyInto(out *URLSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new URLSource.
+func (in *URLSource) DeepCopy() *URLSource {
+	if in == nil {
+		return nil
+	}
+	out := new(URLSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UploadStatus) DeepCopyInto(out *UploadStatus) {
 	*out = *in
@@ -0,0 +1,160 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+// BatchInferenceSpec defines the desired state of BatchInference.
+type BatchInferenceSpec struct {
+	// Command to run in the container.
+	Command []string `json:"command,omitempty"`
+
+	// Environment variables in the container
+	Env map[string]string `json:"env,omitempty"`
+
+	// Image that contains inference code and dependencies.
+	Image *string `json:"image,omitempty"`
+
+	// Build specifies how to build an image.
+	Build *Build `json:"build,omitempty"`
+
+	// Resources are the compute resources required by the container.
+	Resources *Resources `json:"resources,omitempty"`
+
+	// Scheduling controls where the workload is placed.
+	Scheduling *Scheduling `json:"scheduling,omitempty"`
+
+	// RunPolicy controls retries, timeouts and cleanup of the Job.
+	RunPolicy *RunPolicy `json:"runPolicy,omitempty"`
+
+	// Model to run. It is mounted read-only at /content/model.
+	Model ObjectRef `json:"model"`
+
+	// Dataset to run the Model over. It is mounted read-only at
+	// /content/data.
+	Dataset DatasetRef `json:"dataset"`
+
+	//+kubebuilder:validation:Minimum=1
+	// Shards is the number of Pods that the Dataset is split across. Every
+	// Pod is given its shard in the SHARD_INDEX and SHARD_COUNT environment
+	// variables. Defaults to 1.
+	Shards *int32 `json:"shards,omitempty"`
+
+	//+kubebuilder:validation:Minimum=1
+	// Parallelism is the maximum number of shards that run at the same time.
+	// Defaults to the number of shards.
+	Parallelism *int32 `json:"parallelism,omitempty"`
+
+	// Params will be passed into the inference process as environment variables.
+	Params map[string]intstr.IntOrString `json:"params,omitempty"`
+}
+
+// GetShards returns the number of shards.
+func (b *BatchInference) GetShards() int32 {
+	if b.Spec.Shards == nil || *b.Spec.Shards < 1 {
+		return 1
+	}
+	return *b.Spec.Shards
+}
+
+func (b *BatchInference) GetParams() map[string]intstr.IntOrString {
+	return b.Spec.Params
+}
+
+func (b *BatchInference) GetBuild() *Build {
+	return b.Spec.Build
+}
+
+func (b *BatchInference) SetBuild(bld *Build) {
+	b.Spec.Build = bld
+}
+
+func (b *BatchInference) SetImage(image string) {
+	b.Spec.Image = ptr.To(image)
+}
+
+func (b *BatchInference) GetImage() string {
+	if b.Spec.Image == nil {
+		return ""
+	}
+	return *b.Spec.Image
+}
+
+func (b *BatchInference) GetConditions() *[]metav1.Condition {
+	return &b.Status.Conditions
+}
+
+func (b *BatchInference) GetStatusReady() bool {
+	return b.Status.Ready
+}
+
+func (b *BatchInference) SetStatusReady(r bool) {
+	b.Status.Ready = r
+}
+
+func (b *BatchInference) GetStatusArtifacts() ArtifactsStatus {
+	return b.Status.Artifacts
+}
+
+func (b *BatchInference) SetStatusUpload(us UploadStatus) {
+	b.Status.BuildUpload = us
+}
+
+func (b *BatchInference) GetStatusUpload() UploadStatus {
+	return b.Status.BuildUpload
+}
+
+// BatchInferenceStatus defines the observed state of BatchInference.
+type BatchInferenceStatus struct {
+	// Ready indicates that the outputs of every shard are available. See
+	// Conditions for more details.
+	//+kubebuilder:default:=false
+	Ready bool `json:"ready"`
+
+	// Conditions is the list of conditions that describe the current state of the BatchInference.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Artifacts status.
+	Artifacts ArtifactsStatus `json:"artifacts,omitempty"`
+
+	// BuildUpload contains the status of the build context upload.
+	BuildUpload UploadStatus `json:"buildUpload,omitempty"`
+}
+
+//+kubebuilder:resource:categories=ai,shortName=bi
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Model",type="string",JSONPath=".spec.model.name"
+//+kubebuilder:printcolumn:name="Dataset",type="string",JSONPath=".spec.dataset.name"
+//+kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+
+// The BatchInference API is used to run a Model over a whole Dataset offline.
+//
+//   - BatchInferences run a (possibly sharded) Job with the Model and Dataset mounted read-only.
+//
+//   - Outputs are written to /content/artifacts and can be used as a Dataset by
+//     referencing the BatchInference with `kind: BatchInference`.
+type BatchInference struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired state of the BatchInference.
+	Spec BatchInferenceSpec `json:"spec,omitempty"`
+	// Status is the observed state of the BatchInference.
+	Status BatchInferenceStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// BatchInferenceList contains a list of BatchInference
+type BatchInferenceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BatchInference `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BatchInference{}, &BatchInferenceList{})
+}
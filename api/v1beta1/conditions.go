@@ -0,0 +1,66 @@
+package v1beta1
+
+const (
+	ConditionUploaded  = "Uploaded"
+	ConditionBuilt     = "Built"
+	ConditionComplete  = "Complete"
+	ConditionServing   = "Serving"
+	ConditionRefreshed = "Refreshed"
+
+	ConditionInventoried = "Inventoried"
+
+	ConditionDeletionBlocked = "DeletionBlocked"
+)
+
+const (
+	ReasonModelNotFound = "ModelNotFound"
+	ReasonModelNotReady = "ModelNotReady"
+
+	ReasonBaseModelNotFound = "BaseModelNotFound"
+	ReasonBaseModelNotReady = "BaseModelNotReady"
+
+	ReasonDatasetNotFound = "DatasetNotFound"
+	ReasonDatasetNotReady = "ReasonDatasetNotReady"
+	// ReasonDatasetKindUnsupported is reported when a Dataset reference has
+	// a kind that can not be used as a Dataset.
+	ReasonDatasetKindUnsupported = "DatasetKindUnsupported"
+	// ReasonDatasetMountInvalid is reported when Dataset mounts can never be
+	// satisfied (i.e. they form a cycle or share a directory).
+	ReasonDatasetMountInvalid = "DatasetMountInvalid"
+
+	ReasonJobNotComplete     = "JobNotComplete"
+	ReasonJobComplete        = "JobComplete"
+	ReasonJobFailed          = "JobFailed"
+	ReasonJobPreempted       = "JobPreempted"
+	ReasonDeploymentReady    = "DeploymentReady"
+	ReasonDeploymentNotReady = "DeploymentNotReady"
+	ReasonPodReady           = "PodReady"
+	ReasonPodNotReady        = "PodNotReady"
+
+	ReasonSuspended = "Suspended"
+
+	ReasonInvalidSchedule = "InvalidSchedule"
+
+	ReasonResultsInvalid = "ResultsInvalid"
+
+	ReasonArtifactsAdopted  = "ArtifactsAdopted"
+	ReasonArtifactsNotFound = "ArtifactsNotFound"
+	ReasonArtifactsInvalid  = "ArtifactsInvalid"
+	ReasonArtifactsListed   = "ArtifactsListed"
+
+	ReasonReferenced = "Referenced"
+
+	ReasonTrialsNotComplete = "TrialsNotComplete"
+	ReasonTrialsComplete    = "TrialsComplete"
+	ReasonAllTrialsFailed   = "AllTrialsFailed"
+	ReasonTooManyTrials     = "TooManyTrials"
+
+	ReasonStagesNotReady  = "StagesNotReady"
+	ReasonStagesReady     = "StagesReady"
+	ReasonStageFailed     = "StageFailed"
+	ReasonStageInvalid    = "StageInvalid"
+	ReasonWaitingForStage = "WaitingForStage"
+
+	ReasonAwaitingUpload = "AwaitingUpload"
+	ReasonUploadFound    = "UploadFound"
+)
@@ -0,0 +1,33 @@
+package v1beta1
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// v1beta1 is the conversion hub (and storage version). All other versions
+// convert to and from it.
+
+func (*Model) Hub()    {}
+func (*Dataset) Hub()  {}
+func (*Server) Hub()   {}
+func (*Notebook) Hub() {}
+
+// SetupWebhookWithManager registers the conversion webhook for Models.
+func (m *Model) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(m).Complete()
+}
+
+// SetupWebhookWithManager registers the conversion webhook for Datasets.
+func (d *Dataset) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(d).Complete()
+}
+
+// SetupWebhookWithManager registers the conversion webhook for Servers.
+func (s *Server) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(s).Complete()
+}
+
+// SetupWebhookWithManager registers the conversion webhook for Notebooks.
+func (n *Notebook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(n).Complete()
+}
@@ -0,0 +1,204 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+// RefreshAnnotation can be set to a new value to request a Dataset to be
+// loaded again.
+const RefreshAnnotation = "substratus.ai/refresh"
+
+// DatasetSpec defines the desired state of Dataset.
+type DatasetSpec struct {
+	// Command to run in the container.
+	Command []string `json:"command,omitempty"`
+
+	// Environment variables in the container
+	Env map[string]string `json:"env,omitempty"`
+
+	// Image that contains dataset loading code and dependencies.
+	Image *string `json:"image,omitempty"`
+
+	// Build specifies how to build an image.
+	Build *Build `json:"build,omitempty"`
+
+	// Import data using the built-in importer instead of an image.
+	Import *ImportSource `json:"import,omitempty"`
+
+	// Artifacts adopts existing data instead of running a Job. The
+	// Dataset becomes ready once the artifacts are found.
+	Artifacts *ArtifactsSpec `json:"artifacts,omitempty"`
+
+	// Resources are the compute resources required by the container.
+	Resources *Resources `json:"resources,omitempty"`
+
+	// Scheduling controls where the workload is placed.
+	Scheduling *Scheduling `json:"scheduling,omitempty"`
+
+	// RunPolicy controls retries, timeouts and cleanup of the Job.
+	RunPolicy *RunPolicy `json:"runPolicy,omitempty"`
+
+	// Datasets are input Datasets that are mounted read-only under
+	// /content/data. The Dataset is loaded once all inputs are ready.
+	Datasets []DatasetMount `json:"datasets,omitempty"`
+
+	// Refresh controls when the Dataset is loaded again. A refresh can also
+	// be requested by setting the "substratus.ai/refresh" annotation to a
+	// new value (i.e. a timestamp).
+	Refresh *RefreshPolicy `json:"refresh,omitempty"`
+
+	// Params will be passed into the loading process as environment variables.
+	Params map[string]intstr.IntOrString `json:"params,omitempty"`
+}
+
+func (d *Dataset) GetParams() map[string]intstr.IntOrString {
+	return d.Spec.Params
+}
+
+func (d *Dataset) GetBuild() *Build {
+	return d.Spec.Build
+}
+func (d *Dataset) SetBuild(b *Build) {
+	d.Spec.Build = b
+}
+
+func (d *Dataset) SetImage(image string) {
+	d.Spec.Image = ptr.To(image)
+}
+func (d *Dataset) GetImage() string {
+	if d.Spec.Image == nil {
+		return ""
+	}
+	return *d.Spec.Image
+}
+
+func (d *Dataset) GetConditions() *[]metav1.Condition {
+	return &d.Status.Conditions
+}
+
+func (d *Dataset) GetStatusReady() bool {
+	return d.Status.Ready
+}
+
+func (d *Dataset) SetStatusReady(r bool) {
+	d.Status.Ready = r
+}
+
+func (d *Dataset) GetStatusArtifacts() ArtifactsStatus {
+	return d.Status.Artifacts
+}
+
+func (d *Dataset) SetStatusArtifacts(a ArtifactsStatus) {
+	d.Status.Artifacts = a
+}
+
+func (d *Dataset) SetStatusUpload(us UploadStatus) {
+	d.Status.BuildUpload = us
+}
+
+func (d *Dataset) GetStatusUpload() UploadStatus {
+	return d.Status.BuildUpload
+}
+
+func (d *Dataset) GetStatusDownload() *DownloadStatus {
+	return d.Status.Download
+}
+
+func (d *Dataset) SetStatusDownload(ds *DownloadStatus) {
+	d.Status.Download = ds
+}
+
+// DatasetStatus defines the observed state of Dataset.
+type DatasetStatus struct {
+	// Ready indicates that the Dataset is ready to use. See Conditions for more details.
+	//+kubebuilder:default:=false
+	Ready bool `json:"ready"`
+
+	// Conditions is the list of conditions that describe the current state of the Dataset.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Artifacts status.
+	Artifacts ArtifactsStatus `json:"artifacts,omitempty"`
+
+	// BuildUpload contains the status of the build context upload.
+	BuildUpload UploadStatus `json:"buildUpload,omitempty"`
+
+	// Download contains signed URLs of the artifacts (see the
+	// "substratus.ai/download-request" annotation).
+	Download *DownloadStatus `json:"download,omitempty"`
+
+	// Version of the artifacts that are used by consumers. It is
+	// incremented every time a refresh completes.
+	Version int32 `json:"version,omitempty"`
+
+	// LastRefreshTime is when the latest load or refresh was started.
+	LastRefreshTime *metav1.Time `json:"lastRefreshTime,omitempty"`
+
+	// RefreshRequest is the value of the refresh annotation that was last
+	// handled.
+	RefreshRequest string `json:"refreshRequest,omitempty"`
+
+	// Refresh is the status of a refresh that is in progress. The previous
+	// version of the artifacts is used until the refresh completes.
+	Refresh *DatasetRefreshStatus `json:"refresh,omitempty"`
+}
+
+// RefreshPolicy controls when a Dataset is reloaded.
+type RefreshPolicy struct {
+	// Schedule is a cron expression (i.e. "0 3 * * *") that determines when
+	// the Dataset is loaded again.
+	Schedule string `json:"schedule,omitempty"`
+}
+
+// DatasetRefreshStatus describes a refresh that is in progress.
+type DatasetRefreshStatus struct {
+	// Version of the artifacts that are being loaded.
+	Version int32 `json:"version"`
+
+	// URL of the artifacts that are being loaded.
+	URL string `json:"url"`
+}
+
+//+kubebuilder:resource:categories=ai,shortName=data
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+
+// The Dataset API is used to describe data that can be referenced for training Models.
+//
+//   - Datasets pull in remote data sources using containerized data loaders.
+//
+//   - Users can specify their own ETL logic by referencing a repository from a Dataset.
+//
+//   - Users can leverage pre-built data loader integrations with various sources.
+//
+//   - Training typically requires a large dataset. The Dataset API pulls a dataset once and stores it in a bucket, which is mounted directly into training Jobs.
+//
+//   - The Dataset API allows users to query ready-to-use datasets (`kubectl get datasets`).
+//
+//   - The Dataset API allows Kubernetes RBAC to be applied as a mechanism for controlling access to data.
+type Dataset struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired state of the Dataset.
+	Spec DatasetSpec `json:"spec,omitempty"`
+	// Status is the observed state of the Dataset.
+	Status DatasetStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// DatasetList contains a list of Dataset
+type DatasetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Dataset `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Dataset{}, &DatasetList{})
+}
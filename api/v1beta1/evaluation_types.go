@@ -0,0 +1,144 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+// EvaluationSpec defines the desired state of Evaluation.
+type EvaluationSpec struct {
+	// Command to run in the container.
+	Command []string `json:"command,omitempty"`
+
+	// Environment variables in the container
+	Env map[string]string `json:"env,omitempty"`
+
+	// Image that contains evaluation code and dependencies.
+	Image *string `json:"image,omitempty"`
+
+	// Build specifies how to build an image.
+	Build *Build `json:"build,omitempty"`
+
+	// Resources are the compute resources required by the container.
+	Resources *Resources `json:"resources,omitempty"`
+
+	// Scheduling controls where the workload is placed.
+	Scheduling *Scheduling `json:"scheduling,omitempty"`
+
+	// RunPolicy controls retries, timeouts and cleanup of the Job.
+	RunPolicy *RunPolicy `json:"runPolicy,omitempty"`
+
+	// Model to evaluate. It is mounted read-only at /content/model.
+	Model ObjectRef `json:"model"`
+
+	// Dataset to evaluate the Model against. It is mounted read-only at
+	// /content/data.
+	Dataset DatasetRef `json:"dataset"`
+
+	// Params will be passed into the evaluation process as environment variables.
+	Params map[string]intstr.IntOrString `json:"params,omitempty"`
+}
+
+func (e *Evaluation) GetParams() map[string]intstr.IntOrString {
+	return e.Spec.Params
+}
+
+func (e *Evaluation) GetBuild() *Build {
+	return e.Spec.Build
+}
+
+func (e *Evaluation) SetBuild(b *Build) {
+	e.Spec.Build = b
+}
+
+func (e *Evaluation) SetImage(image string) {
+	e.Spec.Image = ptr.To(image)
+}
+
+func (e *Evaluation) GetImage() string {
+	if e.Spec.Image == nil {
+		return ""
+	}
+	return *e.Spec.Image
+}
+
+func (e *Evaluation) GetConditions() *[]metav1.Condition {
+	return &e.Status.Conditions
+}
+
+func (e *Evaluation) GetStatusReady() bool {
+	return e.Status.Ready
+}
+
+func (e *Evaluation) SetStatusReady(r bool) {
+	e.Status.Ready = r
+}
+
+func (e *Evaluation) GetStatusArtifacts() ArtifactsStatus {
+	return e.Status.Artifacts
+}
+
+func (e *Evaluation) SetStatusUpload(us UploadStatus) {
+	e.Status.BuildUpload = us
+}
+
+func (e *Evaluation) GetStatusUpload() UploadStatus {
+	return e.Status.BuildUpload
+}
+
+// EvaluationStatus defines the observed state of Evaluation.
+type EvaluationStatus struct {
+	// Ready indicates that the Evaluation is complete and its metrics are
+	// available. See Conditions for more details.
+	//+kubebuilder:default:=false
+	Ready bool `json:"ready"`
+
+	// Conditions is the list of conditions that describe the current state of the Evaluation.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Artifacts status.
+	Artifacts ArtifactsStatus `json:"artifacts,omitempty"`
+
+	// BuildUpload contains the status of the build context upload.
+	BuildUpload UploadStatus `json:"buildUpload,omitempty"`
+
+	// Metrics are the results reported by the evaluation container in
+	// /content/artifacts/results.json.
+	Metrics map[string]string `json:"metrics,omitempty"`
+}
+
+//+kubebuilder:resource:categories=ai,shortName=eval
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Model",type="string",JSONPath=".spec.model.name"
+//+kubebuilder:printcolumn:name="Dataset",type="string",JSONPath=".spec.dataset.name"
+//+kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+
+// The Evaluation API is used to score a Model against a Dataset.
+//
+//   - Evaluations run a container with the Model and Dataset mounted read-only.
+//
+//   - Results are reported as metrics in the status of the Evaluation and summarized in the status of the Model.
+type Evaluation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired state of the Evaluation.
+	Spec EvaluationSpec `json:"spec,omitempty"`
+	// Status is the observed state of the Evaluation.
+	Status EvaluationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// EvaluationList contains a list of Evaluation
+type EvaluationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Evaluation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Evaluation{}, &EvaluationList{})
+}
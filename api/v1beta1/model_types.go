@@ -0,0 +1,277 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+// ModelSpec defines the desired state of Model
+type ModelSpec struct {
+	// Command to run in the container.
+	Command []string `json:"command,omitempty"`
+
+	// Environment variables in the container
+	Env map[string]string `json:"env,omitempty"`
+
+	// Image that contains model code and dependencies.
+	Image *string `json:"image,omitempty"`
+
+	// Build specifies how to build an image.
+	Build *Build `json:"build,omitempty"`
+
+	// Import model artifacts using the built-in importer instead of an image.
+	Import *ImportSource `json:"import,omitempty"`
+
+	// Artifacts adopts existing model artifacts instead of running a Job. The
+	// Model becomes ready once the artifacts are found.
+	Artifacts *ArtifactsSpec `json:"artifacts,omitempty"`
+
+	// Resources are the compute resources required by the container.
+	Resources *Resources `json:"resources,omitempty"`
+
+	// Scheduling controls where the workload is placed.
+	Scheduling *Scheduling `json:"scheduling,omitempty"`
+
+	// RunPolicy controls retries, timeouts and cleanup of the Job.
+	RunPolicy *RunPolicy `json:"runPolicy,omitempty"`
+
+	// Resume should be set to restart training from the last checkpoint
+	// when the modeller Pod is preempted.
+	Resume *ResumePolicy `json:"resume,omitempty"`
+
+	//+kubebuilder:validation:Minimum=1
+	// Workers is the number of Pods (typically one per node) that train the
+	// Model together. Defaults to 1. When greater than 1, rendezvous
+	// environment variables are injected for distributed training.
+	Workers *int32 `json:"workers,omitempty"`
+
+	// Model should be set in order to mount another model to be
+	// used for transfer learning.
+	Model *ObjectRef `json:"model,omitempty"`
+
+	// Dataset to mount for training.
+	Dataset *DatasetRef `json:"dataset,omitempty"`
+
+	// Datasets are additional named Datasets to mount for training (for
+	// example separate training and validation sets). Every Dataset is
+	// mounted read-only at /content/data/<name> (defaults to the name of the
+	// Dataset). When combined with `.dataset`, that Dataset is mounted at
+	// /content/data/<dataset-name>.
+	Datasets []DatasetMount `json:"datasets,omitempty"`
+
+	// Parameters are passing into the model training/loading container as environment variables.
+	// Environment variable name will be `"PARAM_" + uppercase(key)`.
+	Params map[string]intstr.IntOrString `json:"params,omitempty"`
+}
+
+// ResumePolicy controls how preempted training runs are resumed.
+type ResumePolicy struct {
+	//+kubebuilder:default:=5
+	//+kubebuilder:validation:Minimum=1
+	// MaxAttempts is the maximum number of times the modeller Job is started
+	// (including the first attempt) before the Model is considered failed.
+	MaxAttempts int32 `json:"maxAttempts,omitempty"`
+}
+
+// GetDatasetMounts returns every Dataset that is mounted for training,
+// including the single `.dataset` reference. Entries of `.datasets` are
+// always named so that they are mounted in a subdirectory of /content/data.
+func (m *Model) GetDatasetMounts() []DatasetMount {
+	var mounts []DatasetMount
+	if m.Spec.Dataset != nil {
+		mounts = append(mounts, DatasetMount{Dataset: *m.Spec.Dataset})
+	}
+	for _, d := range m.Spec.Datasets {
+		if d.Name == "" {
+			d.Name = d.Dataset.Name
+		}
+		mounts = append(mounts, d)
+	}
+	return mounts
+}
+
+// GetWorkers returns the number of distributed training workers.
+func (m *Model) GetWorkers() int32 {
+	if m.Spec.Workers == nil || *m.Spec.Workers < 1 {
+		return 1
+	}
+	return *m.Spec.Workers
+}
+
+func (m *Model) GetParams() map[string]intstr.IntOrString {
+	return m.Spec.Params
+}
+
+func (m *Model) GetBuild() *Build {
+	return m.Spec.Build
+}
+
+func (m *Model) SetBuild(b *Build) {
+	m.Spec.Build = b
+}
+
+func (m *Model) SetImage(image string) {
+	m.Spec.Image = ptr.To(image)
+}
+
+func (m *Model) GetImage() string {
+	if m.Spec.Image == nil {
+		return ""
+	}
+	return *m.Spec.Image
+}
+
+func (m *Model) GetConditions() *[]metav1.Condition {
+	return &m.Status.Conditions
+}
+
+func (m *Model) GetStatusReady() bool {
+	return m.Status.Ready
+}
+
+func (m *Model) SetStatusReady(r bool) {
+	m.Status.Ready = r
+}
+
+func (m *Model) GetStatusArtifacts() ArtifactsStatus {
+	return m.Status.Artifacts
+}
+
+func (m *Model) SetStatusArtifacts(a ArtifactsStatus) {
+	m.Status.Artifacts = a
+}
+
+func (m *Model) SetStatusUpload(b UploadStatus) {
+	m.Status.BuildUpload = b
+}
+
+func (m *Model) GetStatusUpload() UploadStatus {
+	return m.Status.BuildUpload
+}
+
+func (m *Model) GetStatusDownload() *DownloadStatus {
+	return m.Status.Download
+}
+
+func (m *Model) SetStatusDownload(ds *DownloadStatus) {
+	m.Status.Download = ds
+}
+
+// ModelStatus defines the observed state of Model
+type ModelStatus struct {
+	// Ready indicates that the Model is ready to use. See Conditions for more details.
+	//+kubebuilder:default:=false
+	Ready bool `json:"ready"`
+
+	// Conditions is the list of conditions that describe the current state of the Model.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Artifacts status.
+	Artifacts ArtifactsStatus `json:"artifacts,omitempty"`
+
+	// BuildUpload contains the status of the build context upload.
+	BuildUpload UploadStatus `json:"buildUpload,omitempty"`
+
+	// Download contains signed URLs of the artifacts (see the
+	// "substratus.ai/download-request" annotation).
+	Download *DownloadStatus `json:"download,omitempty"`
+
+	// Attempts is the number of times the modeller Job has been started.
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// Preemptions is the history of attempts that were interrupted by
+	// node preemption.
+	Preemptions []Preemption `json:"preemptions,omitempty"`
+
+	// Progress is the latest training progress reported by the container.
+	Progress *TrainingProgress `json:"progress,omitempty"`
+
+	// Evaluations summarizes the latest completed Evaluations of the Model.
+	Evaluations []EvaluationSummary `json:"evaluations,omitempty"`
+}
+
+// EvaluationSummary is the result of an Evaluation of a Model.
+type EvaluationSummary struct {
+	// Name of the Evaluation.
+	Name string `json:"name"`
+
+	// Dataset that the Model was evaluated against.
+	Dataset string `json:"dataset"`
+
+	// Metrics reported by the Evaluation.
+	Metrics map[string]string `json:"metrics,omitempty"`
+
+	// CompletionTime is when the Evaluation completed.
+	CompletionTime metav1.Time `json:"completionTime"`
+}
+
+// TrainingProgress is reported by the training container in
+// /content/artifacts/status.json.
+type TrainingProgress struct {
+	// Step is the current training step.
+	Step int64 `json:"step,omitempty"`
+
+	// TotalSteps is the total number of training steps (if known).
+	TotalSteps int64 `json:"totalSteps,omitempty"`
+
+	// Epoch is the current (possibly fractional) epoch.
+	Epoch string `json:"epoch,omitempty"`
+
+	// Loss is the latest training loss.
+	Loss string `json:"loss,omitempty"`
+
+	// Metrics are the latest evaluation metrics.
+	Metrics map[string]string `json:"metrics,omitempty"`
+
+	// UpdateTime is when the progress was last observed to change.
+	UpdateTime metav1.Time `json:"updateTime,omitempty"`
+}
+
+// Preemption records an attempt that was interrupted.
+type Preemption struct {
+	// Attempt is the attempt number that was interrupted.
+	Attempt int32 `json:"attempt"`
+
+	// Time is when the interruption was observed.
+	Time metav1.Time `json:"time"`
+
+	// Message describes the cause of the interruption.
+	Message string `json:"message,omitempty"`
+}
+
+//+kubebuilder:resource:categories=ai
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+
+// The Model API is used to build and train machine learning models.
+//
+//   - Base models can be built from a Git repository.
+//
+//   - Models can be trained by combining a base Model with a Dataset.
+//
+//   - Model artifacts are persisted in cloud buckets.
+type Model struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired state of the Model.
+	Spec ModelSpec `json:"spec,omitempty"`
+	// Status is the observed state of the Model.
+	Status ModelStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ModelList contains a list of Model
+type ModelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Model `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Model{}, &ModelList{})
+}
@@ -0,0 +1,158 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// +kubebuilder:validation:Enum=grid;random
+type SweepStrategy string
+
+const (
+	// SweepStrategyGrid tries every combination of parameter values.
+	SweepStrategyGrid SweepStrategy = "grid"
+	// SweepStrategyRandom tries random combinations of parameter values.
+	SweepStrategyRandom SweepStrategy = "random"
+)
+
+// +kubebuilder:validation:Enum=minimize;maximize
+type ObjectiveGoal string
+
+const (
+	ObjectiveGoalMinimize ObjectiveGoal = "minimize"
+	ObjectiveGoalMaximize ObjectiveGoal = "maximize"
+)
+
+// ModelSweepSpec defines the desired state of ModelSweep.
+type ModelSweepSpec struct {
+	// Template is the spec of the Models that are created for every trial.
+	// Trial parameters are merged into the params of the template.
+	Template ModelSpec `json:"template"`
+
+	//+kubebuilder:validation:MinItems=1
+	// Parameters is the search space over keys of `.params`.
+	Parameters []SweepParameter `json:"parameters"`
+
+	//+kubebuilder:default:=grid
+	// Strategy determines how parameter values are combined into trials.
+	Strategy SweepStrategy `json:"strategy,omitempty"`
+
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=1000
+	// MaxTrials limits the number of Models that are created. Defaults to
+	// the number of combinations of parameter values, which must not exceed
+	// 1000 then.
+	MaxTrials *int32 `json:"maxTrials,omitempty"`
+
+	//+kubebuilder:validation:Minimum=1
+	// Parallelism is the maximum number of Models that are trained at the
+	// same time. Defaults to 1.
+	Parallelism *int32 `json:"parallelism,omitempty"`
+
+	// Objective is used to pick the best trial.
+	Objective SweepObjective `json:"objective"`
+}
+
+// SweepParameter is a param key and the values it is swept over.
+type SweepParameter struct {
+	// Name of the key in `.params`.
+	Name string `json:"name"`
+
+	//+kubebuilder:validation:MinItems=1
+	// Values to try.
+	Values []intstr.IntOrString `json:"values"`
+}
+
+// SweepObjective is the metric that is optimized by a sweep.
+type SweepObjective struct {
+	// Metric is the name of a metric reported by the trial Models. It is
+	// looked up in `.status.progress` (where "loss" refers to the training
+	// loss) and then in `.status.evaluations` of each Model.
+	Metric string `json:"metric"`
+
+	//+kubebuilder:default:=minimize
+	// Goal is whether the metric should be minimized or maximized.
+	Goal ObjectiveGoal `json:"goal,omitempty"`
+}
+
+// ModelSweepStatus defines the observed state of ModelSweep.
+type ModelSweepStatus struct {
+	// Ready indicates that all trials are finished. See Conditions for more details.
+	//+kubebuilder:default:=false
+	Ready bool `json:"ready"`
+
+	// Conditions is the list of conditions that describe the current state of the ModelSweep.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Trials is the status of every Model created by the sweep.
+	Trials []SweepTrial `json:"trials,omitempty"`
+
+	// BestTrial is the name of the Model with the best objective value.
+	BestTrial string `json:"bestTrial,omitempty"`
+
+	// BestObjective is the objective value of the best trial.
+	BestObjective string `json:"bestObjective,omitempty"`
+}
+
+// SweepTrial is the status of a single Model of a sweep.
+type SweepTrial struct {
+	// Name of the Model.
+	Name string `json:"name"`
+
+	// Params that were swept.
+	Params map[string]intstr.IntOrString `json:"params,omitempty"`
+
+	// Ready indicates that the Model is trained.
+	Ready bool `json:"ready,omitempty"`
+
+	// Failed indicates that the Model failed to train.
+	Failed bool `json:"failed,omitempty"`
+
+	// Objective is the value of the objective metric (if reported).
+	Objective string `json:"objective,omitempty"`
+}
+
+func (s *ModelSweep) GetConditions() *[]metav1.Condition {
+	return &s.Status.Conditions
+}
+
+func (s *ModelSweep) GetStatusReady() bool {
+	return s.Status.Ready
+}
+
+//+kubebuilder:resource:categories=ai,shortName=sweep
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Best",type="string",JSONPath=".status.bestTrial"
+//+kubebuilder:printcolumn:name="Objective",type="string",JSONPath=".status.bestObjective"
+//+kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+
+// The ModelSweep API is used to search for the best hyperparameters of a Model.
+//
+//   - A ModelSweep creates a Model for every combination of parameters (trial).
+//
+//   - The number of Models that train at the same time is limited.
+//
+//   - The best trial is picked based on an objective metric reported by the Models.
+type ModelSweep struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired state of the ModelSweep.
+	Spec ModelSweepSpec `json:"spec,omitempty"`
+	// Status is the observed state of the ModelSweep.
+	Status ModelSweepStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ModelSweepList contains a list of ModelSweep
+type ModelSweepList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ModelSweep `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ModelSweep{}, &ModelSweepList{})
+}
@@ -0,0 +1,142 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+// NotebookSpec defines the desired state of Notebook
+type NotebookSpec struct {
+	// Command to run in the container.
+	Command []string `json:"command,omitempty"`
+
+	// Environment variables in the container
+	Env map[string]string `json:"env,omitempty"`
+
+	// Suspend should be set to true to stop the notebook (Pod) from running.
+	// This is a pointer to distinguish between explicit false and not specified.
+	Suspend *bool `json:"suspend,omitempty"`
+
+	// Image that contains notebook and dependencies.
+	Image *string `json:"image,omitempty"`
+
+	// Build specifies how to build an image.
+	Build *Build `json:"build,omitempty"`
+
+	// Resources are the compute resources required by the container.
+	Resources *Resources `json:"resources,omitempty"`
+
+	// Scheduling controls where the workload is placed.
+	Scheduling *Scheduling `json:"scheduling,omitempty"`
+
+	// Model to load into the notebook container.
+	Model *ObjectRef `json:"model,omitempty"`
+
+	// Dataset to load into the notebook container.
+	Dataset *DatasetRef `json:"dataset,omitempty"`
+
+	// Params will be passed into the notebook container as environment variables.
+	Params map[string]intstr.IntOrString `json:"params,omitempty"`
+}
+
+func (n *Notebook) GetParams() map[string]intstr.IntOrString {
+	return n.Spec.Params
+}
+
+func (n *Notebook) GetBuild() *Build {
+	return n.Spec.Build
+}
+
+func (n *Notebook) SetBuild(b *Build) {
+	n.Spec.Build = b
+}
+
+func (n *Notebook) GetImage() string {
+	if n.Spec.Image == nil {
+		return ""
+	}
+	return *n.Spec.Image
+}
+
+func (n *Notebook) SetImage(image string) {
+	n.Spec.Image = ptr.To(image)
+}
+
+func (n *Notebook) GetConditions() *[]metav1.Condition {
+	return &n.Status.Conditions
+}
+
+func (n *Notebook) GetStatusReady() bool {
+	return n.Status.Ready
+}
+
+func (n *Notebook) SetStatusReady(r bool) {
+	n.Status.Ready = r
+}
+
+func (n *Notebook) SetStatusUpload(b UploadStatus) {
+	n.Status.BuildUpload = b
+}
+
+func (n *Notebook) GetStatusUpload() UploadStatus {
+	return n.Status.BuildUpload
+}
+
+func (n *Notebook) GetStatusArtifacts() ArtifactsStatus {
+	return n.Status.Artifacts
+}
+
+func (n *Notebook) IsSuspended() bool {
+	return n.Spec.Suspend != nil && *n.Spec.Suspend
+}
+
+// NotebookStatus defines the observed state of Notebook
+type NotebookStatus struct {
+	// Ready indicates that the Notebook is ready to serve. See Conditions for more details.
+	//+kubebuilder:default:=false
+	Ready bool `json:"ready"`
+
+	// Conditions is the list of conditions that describe the current state of the Notebook.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Artifacts status.
+	Artifacts ArtifactsStatus `json:"artifacts,omitempty"`
+
+	// BuildUpload contains the status of the build context upload.
+	BuildUpload UploadStatus `json:"buildUpload,omitempty"`
+}
+
+//+kubebuilder:resource:categories=ai,shortName=nb
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+
+// The Notebook API can be used to quickly spin up a development environment backed by high performance compute.
+//
+//   - Notebooks integrate with the Model and Dataset APIs allow for quick iteration.
+//
+//   - Notebooks can be synced to local directories to streamline developer experiences using Substratus kubectl plugins.
+type Notebook struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the observed state of the Notebook.
+	Spec NotebookSpec `json:"spec,omitempty"`
+	// Status is the observed state of the Notebook.
+	Status NotebookStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// NotebookList contains a list of Notebook
+type NotebookList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Notebook `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Notebook{}, &NotebookList{})
+}
@@ -0,0 +1,125 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PipelineSpec defines the desired state of Pipeline.
+type PipelineSpec struct {
+	//+kubebuilder:validation:MinItems=1
+	// Stages are created in order. Object references (for example
+	// `.model.dataset.name`) that match the name of an earlier stage resolve
+	// to the object created by that stage and the referencing stage waits
+	// until that object is ready. Other references refer to existing objects.
+	Stages []PipelineStage `json:"stages"`
+
+	// RunID can be changed to a new value to re-run the Pipeline without
+	// changing the stages. Every change to the spec creates a fresh set of
+	// objects.
+	RunID string `json:"runID,omitempty"`
+}
+
+// PipelineStage is a template for a single object. Exactly one of the object
+// specs should be set.
+type PipelineStage struct {
+	//+kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	//+kubebuilder:validation:MaxLength=40
+	// Name of the stage. Must be unique within the Pipeline.
+	Name string `json:"name"`
+
+	// Dataset to create.
+	Dataset *DatasetSpec `json:"dataset,omitempty"`
+
+	// Model to create.
+	Model *ModelSpec `json:"model,omitempty"`
+
+	// Evaluation to create.
+	Evaluation *EvaluationSpec `json:"evaluation,omitempty"`
+
+	// Server to create.
+	Server *ServerSpec `json:"server,omitempty"`
+}
+
+// PipelineStatus defines the observed state of Pipeline.
+type PipelineStatus struct {
+	// Ready indicates that the objects of every stage are ready. See
+	// Conditions for more details.
+	//+kubebuilder:default:=false
+	Ready bool `json:"ready"`
+
+	// Conditions is the list of conditions that describe the current state of the Pipeline.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Version is incremented every time the Pipeline is run. It is part of
+	// the names of the objects created by the run.
+	Version int32 `json:"version,omitempty"`
+
+	// VersionGeneration is the generation of the Pipeline that the current
+	// version was created for.
+	VersionGeneration int64 `json:"versionGeneration,omitempty"`
+
+	// Stages is the status of every stage of the current version.
+	Stages []PipelineStageStatus `json:"stages,omitempty"`
+}
+
+// PipelineStageStatus is the observed state of a single stage.
+type PipelineStageStatus struct {
+	// Name of the stage.
+	Name string `json:"name"`
+
+	// Kind of the object created by the stage.
+	Kind string `json:"kind,omitempty"`
+
+	// Object is the name of the object created by the stage. It is empty
+	// while the stage is waiting for earlier stages.
+	Object string `json:"object,omitempty"`
+
+	// Ready indicates that the object is ready.
+	Ready bool `json:"ready,omitempty"`
+
+	// Reason is the reason of the latest condition of the object (or why
+	// the object was not created yet).
+	Reason string `json:"reason,omitempty"`
+}
+
+func (p *Pipeline) GetConditions() *[]metav1.Condition {
+	return &p.Status.Conditions
+}
+
+func (p *Pipeline) GetStatusReady() bool {
+	return p.Status.Ready
+}
+
+//+kubebuilder:resource:categories=ai
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Version",type="integer",JSONPath=".status.version"
+//+kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+
+// The Pipeline API is used to chain Datasets, Models, Evaluations and Servers.
+//
+//   - Stages are created in dependency order once the objects they reference are ready.
+//
+//   - Every run of a Pipeline creates a fresh, versioned set of objects.
+type Pipeline struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired state of the Pipeline.
+	Spec PipelineSpec `json:"spec,omitempty"`
+	// Status is the observed state of the Pipeline.
+	Status PipelineStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// PipelineList contains a list of Pipeline
+type PipelineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Pipeline `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Pipeline{}, &PipelineList{})
+}
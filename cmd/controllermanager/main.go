@@ -3,12 +3,13 @@ package main
 import (
 	"bytes"
 	"context"
+	"errors"
 	"flag"
 	"io/ioutil"
 	"os"
+	"time"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 	"gopkg.in/yaml.v2"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -20,8 +21,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 	"github.com/substratusai/substratus/internal/cloud"
 	"github.com/substratusai/substratus/internal/controller"
+	"github.com/substratusai/substratus/internal/resources"
 	"github.com/substratusai/substratus/internal/sci"
 )
 
@@ -34,6 +37,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(apiv1.AddToScheme(scheme))
+	utilruntime.Must(apiv1beta1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -43,9 +47,21 @@ func main() {
 	var probeAddr string
 	var configDumpPath string
 	var sciAddr string
+	var gpuCatalogPath string
+	var importerImage string
+	var artifactsManifests bool
+	var systemNamespace string
+	var artifactsSweepInterval time.Duration
+	var sciOpts sci.ClientOptions
 	flag.StringVar(&configDumpPath, "config-dump-path", "", "The filepath to dump the running config to.")
 	// TODO: Change SCI Service name to be cloud-agnostic.
 	flag.StringVar(&sciAddr, "sci-address", "sci.substratus.svc.cluster.local:10080", "The address of the Substratus Cloud Interface server.")
+	sciOpts.BindFlags(flag.CommandLine)
+	flag.StringVar(&gpuCatalogPath, "gpu-catalog-path", "", "The filepath to a GPU catalog (i.e. a mounted ConfigMap). Uses the built-in catalog if not set.")
+	flag.StringVar(&importerImage, "importer-image", "", "The image of the built-in importer that is used for Datasets and Models with an import source (required).")
+	flag.BoolVar(&artifactsManifests, "artifacts-manifests", false, "Run a Job (using the importer image) that writes a manifest of sha256 checksums next to the artifacts of every ready Model and Dataset.")
+	flag.StringVar(&systemNamespace, "system-namespace", "substratus", "The namespace that Substratus is installed in. Records of the artifacts in the bucket are kept here.")
+	flag.DurationVar(&artifactsSweepInterval, "artifacts-sweep-interval", 0, "How often to delete the artifacts that this cluster recorded in the bucket and whose owning objects no longer exist (i.e. 1h). Disabled by default.")
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
@@ -59,6 +75,11 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if importerImage == "" {
+		setupLog.Error(errors.New("--importer-image is required"), "invalid flags")
+		os.Exit(1)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     metricsAddr,
@@ -91,20 +112,31 @@ func main() {
 		os.Exit(1)
 	}
 
+	gpuCatalog := resources.DefaultGPUCatalog()
+	if gpuCatalogPath != "" {
+		gpuCatalog, err = resources.LoadGPUCatalog(gpuCatalogPath)
+		if err != nil {
+			setupLog.Error(err, "unable to load gpu catalog")
+			os.Exit(1)
+		}
+	}
+
 	if configDumpPath != "" {
 		if err := dumpConfigToFile(configDumpPath, struct {
-			Cloud cloud.Cloud
-		}{Cloud: cld}); err != nil {
+			Cloud      cloud.Cloud
+			GPUCatalog *resources.GPUCatalog
+		}{Cloud: cld, GPUCatalog: gpuCatalog}); err != nil {
 			setupLog.Error(err, "unable to dump config to path")
 			os.Exit(1)
 		}
 	}
 
-	// TODO(any): setup TLS
-	conn, err := grpc.Dial(
-		sciAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	dialOpts, err := sciOpts.DialOptions()
+	if err != nil {
+		setupLog.Error(err, "unable to configure the SCI gRPC client")
+		os.Exit(1)
+	}
+	conn, err := grpc.Dial(sciAddr, dialOpts...)
 	if err != nil {
 		setupLog.Error(err, "unable to create an SCI gRPC client")
 		os.Exit(1)
@@ -126,15 +158,31 @@ func main() {
 		}
 	}
 
+	artifactsCollector := &controller.ArtifactsCollector{
+		Client:        mgr.GetClient(),
+		Cloud:         cld,
+		SCI:           sciClient,
+		Namespace:     systemNamespace,
+		SweepInterval: artifactsSweepInterval,
+	}
+	if err := mgr.Add(artifactsCollector); err != nil {
+		setupLog.Error(err, "unable to add artifacts collector")
+		os.Exit(1)
+	}
+
 	if err = (&controller.ModelReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Cloud:  cld,
-		SCI:    sciClient,
+		Client:             mgr.GetClient(),
+		Scheme:             mgr.GetScheme(),
+		Cloud:              cld,
+		SCI:                sciClient,
+		GPUCatalog:         gpuCatalog,
+		ImporterImage:      importerImage,
+		ArtifactsManifests: artifactsManifests,
 		ParamsReconciler: &controller.ParamsReconciler{
 			Scheme: mgr.GetScheme(),
 			Client: mgr.GetClient(),
 		},
+		Artifacts: artifactsCollector,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Model")
 		os.Exit(1)
@@ -144,17 +192,18 @@ func main() {
 		Client:    mgr.GetClient(),
 		Cloud:     cld,
 		SCI:       sciClient,
-		NewObject: func() controller.BuildableObject { return &apiv1.Model{} },
+		NewObject: func() controller.BuildableObject { return &apiv1beta1.Model{} },
 		Kind:      "Model",
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ModelBuilder")
 		os.Exit(1)
 	}
 	if err = (&controller.ServerReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Cloud:  cld,
-		SCI:    sciClient,
+		Client:     mgr.GetClient(),
+		Scheme:     mgr.GetScheme(),
+		Cloud:      cld,
+		SCI:        sciClient,
+		GPUCatalog: gpuCatalog,
 		ParamsReconciler: &controller.ParamsReconciler{
 			Scheme: mgr.GetScheme(),
 			Client: mgr.GetClient(),
@@ -168,21 +217,23 @@ func main() {
 		Client:    mgr.GetClient(),
 		Cloud:     cld,
 		SCI:       sciClient,
-		NewObject: func() controller.BuildableObject { return &apiv1.Server{} },
+		NewObject: func() controller.BuildableObject { return &apiv1beta1.Server{} },
 		Kind:      "Server",
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ServerBuilder")
 		os.Exit(1)
 	}
 	if err = (&controller.NotebookReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Cloud:  cld,
-		SCI:    sciClient,
+		Client:     mgr.GetClient(),
+		Scheme:     mgr.GetScheme(),
+		Cloud:      cld,
+		SCI:        sciClient,
+		GPUCatalog: gpuCatalog,
 		ParamsReconciler: &controller.ParamsReconciler{
 			Scheme: mgr.GetScheme(),
 			Client: mgr.GetClient(),
 		},
+		Artifacts: artifactsCollector,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Notebook")
 		os.Exit(1)
@@ -192,21 +243,25 @@ func main() {
 		Client:    mgr.GetClient(),
 		Cloud:     cld,
 		SCI:       sciClient,
-		NewObject: func() controller.BuildableObject { return &apiv1.Notebook{} },
+		NewObject: func() controller.BuildableObject { return &apiv1beta1.Notebook{} },
 		Kind:      "Notebook",
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "NotebookBuilder")
 		os.Exit(1)
 	}
 	if err = (&controller.DatasetReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Cloud:  cld,
-		SCI:    sciClient,
+		Client:             mgr.GetClient(),
+		Scheme:             mgr.GetScheme(),
+		Cloud:              cld,
+		SCI:                sciClient,
+		GPUCatalog:         gpuCatalog,
+		ImporterImage:      importerImage,
+		ArtifactsManifests: artifactsManifests,
 		ParamsReconciler: &controller.ParamsReconciler{
 			Scheme: mgr.GetScheme(),
 			Client: mgr.GetClient(),
 		},
+		Artifacts: artifactsCollector,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Dataset")
 		os.Exit(1)
@@ -216,12 +271,95 @@ func main() {
 		Client:    mgr.GetClient(),
 		Cloud:     cld,
 		SCI:       sciClient,
-		NewObject: func() controller.BuildableObject { return &apiv1.Dataset{} },
+		NewObject: func() controller.BuildableObject { return &apiv1beta1.Dataset{} },
 		Kind:      "Dataset",
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DatasetBuilder")
 		os.Exit(1)
 	}
+	if err = (&controller.EvaluationReconciler{
+		Client:     mgr.GetClient(),
+		Scheme:     mgr.GetScheme(),
+		Cloud:      cld,
+		SCI:        sciClient,
+		GPUCatalog: gpuCatalog,
+		ParamsReconciler: &controller.ParamsReconciler{
+			Scheme: mgr.GetScheme(),
+			Client: mgr.GetClient(),
+		},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Evaluation")
+		os.Exit(1)
+	}
+	if err = (&controller.BuildReconciler{
+		Scheme:    mgr.GetScheme(),
+		Client:    mgr.GetClient(),
+		Cloud:     cld,
+		SCI:       sciClient,
+		NewObject: func() controller.BuildableObject { return &apiv1beta1.Evaluation{} },
+		Kind:      "Evaluation",
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EvaluationBuilder")
+		os.Exit(1)
+	}
+	if err = (&controller.BatchInferenceReconciler{
+		Client:     mgr.GetClient(),
+		Scheme:     mgr.GetScheme(),
+		Cloud:      cld,
+		SCI:        sciClient,
+		GPUCatalog: gpuCatalog,
+		ParamsReconciler: &controller.ParamsReconciler{
+			Scheme: mgr.GetScheme(),
+			Client: mgr.GetClient(),
+		},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BatchInference")
+		os.Exit(1)
+	}
+	if err = (&controller.BuildReconciler{
+		Scheme:    mgr.GetScheme(),
+		Client:    mgr.GetClient(),
+		Cloud:     cld,
+		SCI:       sciClient,
+		NewObject: func() controller.BuildableObject { return &apiv1beta1.BatchInference{} },
+		Kind:      "BatchInference",
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BatchInferenceBuilder")
+		os.Exit(1)
+	}
+	if err = (&controller.ModelSweepReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ModelSweep")
+		os.Exit(1)
+	}
+	if err = (&controller.PipelineReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Pipeline")
+		os.Exit(1)
+	}
+
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&apiv1beta1.Model{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Model")
+			os.Exit(1)
+		}
+		if err = (&apiv1beta1.Dataset{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Dataset")
+			os.Exit(1)
+		}
+		if err = (&apiv1beta1.Server{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Server")
+			os.Exit(1)
+		}
+		if err = (&apiv1beta1.Notebook{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Notebook")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+	"github.com/substratusai/substratus/internal/importer"
+)
+
+func main() {
+	var cfg struct {
+		dest     string
+		source   string
+		retries  int
+		manifest string
+	}
+	flag.StringVar(&cfg.dest, "dest", "/content/artifacts", "directory to import into")
+	flag.StringVar(&cfg.source, "source", "/content/source", "directory that bucket sources are mounted at")
+	flag.IntVar(&cfg.retries, "retries", 5, "number of times a failed download is resumed")
+	flag.StringVar(&cfg.manifest, "manifest", "", "instead of importing, write a manifest of the files in -dest to this file")
+	flag.Parse()
+
+	if cfg.manifest != "" {
+		if err := importer.WriteManifest(cfg.dest, cfg.manifest); err != nil {
+			log.Fatalf("failed to write manifest: %v", err)
+		}
+		log.Printf("Manifest written to %v", cfg.manifest)
+		return
+	}
+
+	var src apiv1beta1.ImportSource
+	if err := json.Unmarshal([]byte(os.Getenv(importer.SourceEnvVar)), &src); err != nil {
+		log.Fatalf("failed to parse %v: %v", importer.SourceEnvVar, err)
+	}
+
+	im := &importer.Importer{
+		HuggingFaceEndpoint: os.Getenv("HF_ENDPOINT"),
+		HuggingFaceToken:    os.Getenv("HF_TOKEN"),
+		SourceDir:           cfg.source,
+		Retries:             cfg.retries,
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if err := im.Import(ctx, src, cfg.dest); err != nil {
+		log.Fatalf("failed to import: %v", err)
+	}
+	log.Printf("Import complete")
+}
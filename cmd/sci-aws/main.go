@@ -22,6 +22,8 @@ func main() {
 	// serve by default on port 10081
 	var port int
 	flag.IntVar(&port, "port", 10081, "port number to listen on")
+	var sciOpts sci.ServerOptions
+	sciOpts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
 	// Create new AWS Server
@@ -30,7 +32,11 @@ func main() {
 		log.Fatalf("failed to create AWS server: %v", err)
 	}
 
-	gs := grpc.NewServer()
+	serverOpts, err := sciOpts.GRPCServerOptions()
+	if err != nil {
+		log.Fatalf("failed to configure grpc server: %v", err)
+	}
+	gs := grpc.NewServer(serverOpts...)
 	sci.RegisterControllerServer(gs, s)
 
 	// Setup Health Check
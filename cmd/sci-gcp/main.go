@@ -29,6 +29,8 @@ func main() {
 	// serve by default on port 10080
 	var port int
 	flag.IntVar(&port, "port", 10080, "port number to listen on")
+	var sciOpts sci.ServerOptions
+	sciOpts.BindFlags(flag.CommandLine)
 
 	opts := zap.Options{
 		Development: true,
@@ -81,7 +83,12 @@ func main() {
 		setupLog.Error(err, "failed to validate server")
 		os.Exit(1)
 	}
-	gs := grpc.NewServer()
+	serverOpts, err := sciOpts.GRPCServerOptions()
+	if err != nil {
+		setupLog.Error(err, "failed to configure grpc server")
+		os.Exit(1)
+	}
+	gs := grpc.NewServer(serverOpts...)
 	sci.RegisterControllerServer(gs, s)
 
 	// Setup Health Check
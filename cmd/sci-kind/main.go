@@ -19,15 +19,29 @@ func main() {
 		port                 int
 		signedURLPort        int
 		hostSignedURLAddress string
+		bucketDir            string
+		signingKeyFile       string
 	}
 	flag.IntVar(&cfg.port, "port", 10080, "port number to listen on")
 	flag.IntVar(&cfg.signedURLPort, "signed-url-port", 8080, "port to listen for signed url traffic")
 	flag.StringVar(&cfg.hostSignedURLAddress, "host-signed-url-address", "http://localhost:30080",
 		"host address that port forwards to the signed url port within the cluster. this should be set in kind config.yaml.")
+	flag.StringVar(&cfg.bucketDir, "bucket-dir", "/bucket", "directory that the bucket hostPath is mounted at. signed urls are only accepted for objects within it.")
+	flag.StringVar(&cfg.signingKeyFile, "signing-key-file", "",
+		"file containing the key that signed urls are signed with. a random key is generated when not set (urls are invalidated on restart).")
+	var sciOpts sci.ServerOptions
+	sciOpts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
+	signingKey, err := sci.LoadSigningKey(cfg.signingKeyFile)
+	if err != nil {
+		log.Fatalf("failed to load signing key: %v", err)
+	}
+
 	s := &scikind.Server{
+		Dir:              cfg.bucketDir,
 		SignedURLAddress: cfg.hostSignedURLAddress,
+		SigningKey:       signingKey,
 	}
 	signedURLServer := &http.Server{
 		Addr:    fmt.Sprintf(":%v", cfg.signedURLPort),
@@ -38,7 +52,11 @@ func main() {
 		log.Fatal(signedURLServer.ListenAndServe())
 	}()
 
-	gs := grpc.NewServer()
+	serverOpts, err := sciOpts.GRPCServerOptions()
+	if err != nil {
+		log.Fatalf("failed to configure grpc server: %v", err)
+	}
+	gs := grpc.NewServer(serverOpts...)
 	sci.RegisterControllerServer(gs, s)
 
 	// Setup Health Check
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+
+	"github.com/substratusai/substratus/internal/sci"
+	scipvc "github.com/substratusai/substratus/internal/sci/pvc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	hv1 "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func main() {
+	var cfg struct {
+		port             int
+		signedURLPort    int
+		signedURLAddress string
+		bucketDir        string
+		signingKeyFile   string
+	}
+	flag.IntVar(&cfg.port, "port", 10080, "port number to listen on")
+	flag.IntVar(&cfg.signedURLPort, "signed-url-port", 8080, "port to listen for signed url traffic")
+	flag.StringVar(&cfg.signedURLAddress, "signed-url-address", "",
+		"address that clients use to reach the signed url port within the cluster (i.e. an Ingress or LoadBalancer address).")
+	flag.StringVar(&cfg.bucketDir, "bucket-dir", "/bucket", "directory that the artifacts PVC is mounted at")
+	flag.StringVar(&cfg.signingKeyFile, "signing-key-file", "",
+		"file containing the key that signed urls are signed with. a random key is generated when not set (urls are invalidated on restart).")
+	var sciOpts sci.ServerOptions
+	sciOpts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	if cfg.signedURLAddress == "" {
+		log.Fatal("--signed-url-address must be set")
+	}
+
+	signingKey, err := sci.LoadSigningKey(cfg.signingKeyFile)
+	if err != nil {
+		log.Fatalf("failed to load signing key: %v", err)
+	}
+
+	s := &scipvc.Server{
+		Dir:              cfg.bucketDir,
+		SignedURLAddress: cfg.signedURLAddress,
+		SigningKey:       signingKey,
+	}
+	signedURLServer := &http.Server{
+		Addr:    fmt.Sprintf(":%v", cfg.signedURLPort),
+		Handler: s,
+	}
+	go func() {
+		log.Printf("Listening for signed URL traffic on address: %v", cfg.signedURLPort)
+		log.Fatal(signedURLServer.ListenAndServe())
+	}()
+
+	serverOpts, err := sciOpts.GRPCServerOptions()
+	if err != nil {
+		log.Fatalf("failed to configure grpc server: %v", err)
+	}
+	gs := grpc.NewServer(serverOpts...)
+	sci.RegisterControllerServer(gs, s)
+
+	// Setup Health Check
+	hs := health.NewServer()
+	hs.SetServingStatus("", hv1.HealthCheckResponse_SERVING)
+	hv1.RegisterHealthServer(gs, hs)
+
+	addr := fmt.Sprintf(":%v", cfg.port)
+	log.Printf("Listening for gRPC traffic on address: %v", addr)
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+
+	if err := gs.Serve(lis); err != nil {
+		log.Fatalf("failed to serve: %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/substratusai/substratus/internal/sci"
+	scis3 "github.com/substratusai/substratus/internal/sci/s3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	hv1 "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func main() {
+	// serve by default on port 10080
+	var port int
+	flag.IntVar(&port, "port", 10080, "port number to listen on")
+	var sciOpts sci.ServerOptions
+	sciOpts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	cfg := scis3.Config{
+		Endpoint:        os.Getenv("S3_ENDPOINT"),
+		Region:          os.Getenv("S3_REGION"),
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+	}
+	s3Client, err := scis3.NewS3Client(cfg)
+	if err != nil {
+		log.Fatalf("failed to create S3 client: %v", err)
+	}
+
+	s := &scis3.Server{
+		Clients: scis3.Clients{S3Client: s3Client},
+	}
+
+	// Signed URLs are used from outside of the cluster, so they are signed
+	// against the public endpoint of the store (if it differs).
+	if publicEndpoint := os.Getenv("S3_PUBLIC_ENDPOINT"); publicEndpoint != "" {
+		cfg.Endpoint = publicEndpoint
+		s.Clients.PresignClient, err = scis3.NewS3Client(cfg)
+		if err != nil {
+			log.Fatalf("failed to create S3 presign client: %v", err)
+		}
+	} else {
+		log.Printf("S3_PUBLIC_ENDPOINT is not set, signing URLs against %v", cfg.Endpoint)
+	}
+
+	serverOpts, err := sciOpts.GRPCServerOptions()
+	if err != nil {
+		log.Fatalf("failed to configure grpc server: %v", err)
+	}
+	gs := grpc.NewServer(serverOpts...)
+	sci.RegisterControllerServer(gs, s)
+
+	// Setup Health Check
+	hs := health.NewServer()
+	hs.SetServingStatus("", hv1.HealthCheckResponse_SERVING)
+	hv1.RegisterHealthServer(gs, hs)
+
+	log.Printf("sci.s3 server listening on port %v...", port)
+	lis, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+
+	if err := gs.Serve(lis); err != nil {
+		log.Fatalf("failed to serve: %v", err)
+	}
+}
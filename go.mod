@@ -12,6 +12,7 @@ require (
 	github.com/go-logr/logr v1.2.4
 	github.com/go-playground/validator/v10 v10.14.1
 	github.com/pkg/browser v0.0.0-20210911075715-681adbf594b8
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/sethvargo/go-envconfig v0.9.0
 	github.com/spf13/cobra v1.6.0
 	github.com/stretchr/testify v1.8.4
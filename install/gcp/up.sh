@@ -94,6 +94,9 @@ kubectl apply -f https://raw.githubusercontent.com/GoogleCloudPlatform/container
 
 # Install cluster components.
 if [ "${INSTALL_OPERATOR}" == "yes" ]; then
+# Install cert-manager (used to issue the conversion webhook certificate).
+kubectl apply -f https://github.com/cert-manager/cert-manager/releases/download/v1.12.3/cert-manager.yaml
+kubectl wait --for=condition=Available --timeout=300s deployment --all -n cert-manager
 kubectl create ns substratus
 kubectl apply -f - << EOF
 apiVersion: v1
@@ -49,3 +49,7 @@ helm repo update
 helm install --wait --generate-name \
      -n gpu-operator --create-namespace \
      nvidia/gpu-operator --set driver.enabled=false
+
+# Install cert-manager (used to issue the conversion webhook certificate).
+kubectl apply -f https://github.com/cert-manager/cert-manager/releases/download/v1.12.3/cert-manager.yaml
+kubectl wait --for=condition=Available --timeout=300s deployment --all -n cert-manager
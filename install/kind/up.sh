@@ -11,4 +11,8 @@ nodes:
   extraPortMappings:
   - containerPort: 30080
     hostPort: 30080
-EOF
\ No newline at end of file
+EOF
+
+# Install cert-manager (used to issue the conversion webhook certificate).
+kubectl apply -f https://github.com/cert-manager/cert-manager/releases/download/v1.12.3/cert-manager.yaml
+kubectl wait --for=condition=Available --timeout=300s deployment --all -n cert-manager
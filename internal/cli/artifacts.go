@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/substratusai/substratus/internal/cli/utils"
+	"github.com/substratusai/substratus/internal/tui"
+)
+
+func artifactsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "artifacts",
+		Aliases: []string{"art"},
+		Short:   "Work with the artifacts of Models and Datasets",
+	}
+
+	cmd.AddCommand(artifactsPullCommand())
+
+	return cmd
+}
+
+func artifactsPullCommand() *cobra.Command {
+	var flags struct {
+		namespace   string
+		kubeconfig  string
+		parallelism int
+	}
+
+	run := func(cmd *cobra.Command, args []string) error {
+		defer tui.LogFile.Close()
+
+		kubeconfigNamespace, restConfig, err := utils.BuildConfigFromFlags("", flags.kubeconfig)
+		if err != nil {
+			return fmt.Errorf("rest config: %w", err)
+		}
+
+		clientset, err := kubernetes.NewForConfig(restConfig)
+		if err != nil {
+			return fmt.Errorf("clientset: %w", err)
+		}
+
+		client, err := NewClient(clientset, restConfig)
+		if err != nil {
+			return fmt.Errorf("client: %w", err)
+		}
+
+		dir := "."
+		if len(args) > 1 {
+			dir = args[1]
+		}
+
+		// Initialize our program
+		tui.P = tea.NewProgram((&tui.PullModel{
+			Ctx:   cmd.Context(),
+			Scope: args[0],
+			Namespace: tui.Namespace{
+				Contextual: kubeconfigNamespace,
+				Specified:  flags.namespace,
+			},
+			Dir:         dir,
+			Parallelism: flags.parallelism,
+			Client:      client,
+		}).New())
+		if _, err := tui.P.Run(); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	cmd := &cobra.Command{
+		Use:   "pull <models|datasets>/<name> [dir]",
+		Short: "Download the artifacts of a Model or Dataset",
+		Long: `Download the artifacts of a Model or Dataset into a local directory (defaults to the
+current directory). Files are downloaded in parallel and verified against their
+checksums. Running the command again resumes an interrupted download.`,
+		Example: `  # Download the artifacts of a Model.
+  sub artifacts pull model/falcon-7b ./falcon-7b`,
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := run(cmd, args); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	defaultKubeconfig := os.Getenv("KUBECONFIG")
+	if defaultKubeconfig == "" {
+		defaultKubeconfig = clientcmd.RecommendedHomeFile
+	}
+	cmd.Flags().StringVarP(&flags.kubeconfig, "kubeconfig", "", defaultKubeconfig, "")
+
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "Namespace of the Model or Dataset")
+	cmd.Flags().IntVarP(&flags.parallelism, "parallelism", "p", 4, "Number of files to download concurrently")
+
+	return cmd
+}
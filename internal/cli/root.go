@@ -19,6 +19,7 @@ func Command() *cobra.Command {
 	// cmd.AddCommand(inferCommand())
 	cmd.AddCommand(deleteCommand())
 	cmd.AddCommand(serveCommand())
+	cmd.AddCommand(artifactsCommand())
 
 	return cmd
 }
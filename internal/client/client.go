@@ -22,6 +22,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 )
 
 var Version = "development"
@@ -32,6 +33,7 @@ var FieldManager = "kubectl"
 
 func init() {
 	apiv1.AddToScheme(scheme.Scheme)
+	apiv1beta1.AddToScheme(scheme.Scheme)
 }
 
 var _ Interface = &Client{}
@@ -39,7 +41,7 @@ var _ Interface = &Client{}
 type Interface interface {
 	PortForward(ctx context.Context, logger io.Writer, podRef types.NamespacedName, ports ForwardedPorts, ready chan struct{}) error
 	Resource(obj Object) (*Resource, error)
-	SyncFilesFromNotebook(context.Context, *apiv1.Notebook, string,
+	SyncFilesFromNotebook(context.Context, *apiv1beta1.Notebook, string,
 		io.Writer,
 		func(file string, complete bool, err error),
 	) error
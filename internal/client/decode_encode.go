@@ -5,7 +5,10 @@ import (
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
 	"sigs.k8s.io/yaml"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 )
 
 func Decode(data []byte) (Object, error) {
@@ -27,6 +30,21 @@ func Decode(data []byte) (Object, error) {
 		return nil, err
 	}
 
+	// Older API versions (i.e. substratus.ai/v1) are converted to the hub
+	// version so that the rest of the client only deals with one version.
+	if convertible, ok := runtimeObject.(conversion.Convertible); ok {
+		hubObj, err := scheme.Scheme.New(apiv1beta1.GroupVersion.WithKind(gvk.Kind))
+		if err != nil {
+			return nil, fmt.Errorf("creating %v hub object: %w", gvk.Kind, err)
+		}
+		hub := hubObj.(conversion.Hub)
+		if err := convertible.ConvertTo(hub); err != nil {
+			return nil, fmt.Errorf("converting %v to %v: %w", gvk, apiv1beta1.GroupVersion, err)
+		}
+		hub.GetObjectKind().SetGroupVersionKind(apiv1beta1.GroupVersion.WithKind(gvk.Kind))
+		return hub.(Object), nil
+	}
+
 	return runtimeObject.(Object), nil
 }
 
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+)
+
+// Dependents returns the objects (i.e. "servers/<name>") in the same
+// namespace that reference the given Model or Dataset. These are the
+// objects that the controller waits on before deleting it (see the
+// substratus.ai/force-delete annotation).
+func Dependents(c Interface, obj Object) ([]string, error) {
+	var kinds []string
+	switch obj.(type) {
+	case *apiv1beta1.Model:
+		kinds = []string{"Model", "Server", "Notebook", "Evaluation", "BatchInference"}
+	case *apiv1beta1.Dataset:
+		kinds = []string{"Model", "Dataset", "Notebook", "Evaluation", "BatchInference"}
+	}
+
+	var deps []string
+	for _, kind := range kinds {
+		objs, err := list(c, obj.GetNamespace(), kind)
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range objs {
+			if o.GetDeletionTimestamp().IsZero() && references(o, obj) {
+				deps = append(deps, strings.ToLower(kind)+"s/"+o.GetName())
+			}
+		}
+	}
+
+	sort.Strings(deps)
+	return deps, nil
+}
+
+// references returns true if o references the given Model or Dataset.
+func references(o, target Object) bool {
+	if o.GetNamespace() != target.GetNamespace() {
+		return false
+	}
+	name := target.GetName()
+
+	switch target.(type) {
+	case *apiv1beta1.Model:
+		switch o := o.(type) {
+		case *apiv1beta1.Model:
+			return o.Spec.Model != nil && o.Spec.Model.Name == name
+		case *apiv1beta1.Server:
+			return o.Spec.Model.Name == name
+		case *apiv1beta1.Notebook:
+			return o.Spec.Model != nil && o.Spec.Model.Name == name
+		case *apiv1beta1.Evaluation:
+			return o.Spec.Model.Name == name
+		case *apiv1beta1.BatchInference:
+			return o.Spec.Model.Name == name
+		}
+
+	case *apiv1beta1.Dataset:
+		isTarget := func(ref apiv1beta1.DatasetRef) bool {
+			return ref.Name == name && (ref.Kind == "" || ref.Kind == "Dataset")
+		}
+		switch o := o.(type) {
+		case *apiv1beta1.Model:
+			for _, d := range o.GetDatasetMounts() {
+				if isTarget(d.Dataset) {
+					return true
+				}
+			}
+		case *apiv1beta1.Dataset:
+			// A Dataset that mounts itself does not block its own deletion.
+			if o.Name == name {
+				return false
+			}
+			for _, d := range o.Spec.Datasets {
+				if isTarget(d.Dataset) {
+					return true
+				}
+			}
+		case *apiv1beta1.Notebook:
+			return o.Spec.Dataset != nil && isTarget(*o.Spec.Dataset)
+		case *apiv1beta1.Evaluation:
+			return isTarget(o.Spec.Dataset)
+		case *apiv1beta1.BatchInference:
+			return isTarget(o.Spec.Dataset)
+		}
+	}
+	return false
+}
+
+// list lists the objects of a kind in a namespace.
+func list(c Interface, namespace, kind string) ([]Object, error) {
+	var obj Object
+	switch kind {
+	case "Model":
+		obj = &apiv1beta1.Model{}
+	case "Dataset":
+		obj = &apiv1beta1.Dataset{}
+	case "Server":
+		obj = &apiv1beta1.Server{}
+	case "Notebook":
+		obj = &apiv1beta1.Notebook{}
+	case "Evaluation":
+		obj = &apiv1beta1.Evaluation{}
+	case "BatchInference":
+		obj = &apiv1beta1.BatchInference{}
+	default:
+		return nil, fmt.Errorf("unsupported kind: %v", kind)
+	}
+	obj.GetObjectKind().SetGroupVersionKind(apiv1beta1.GroupVersion.WithKind(kind))
+
+	res, err := c.Resource(obj)
+	if err != nil {
+		return nil, fmt.Errorf("resource client: %w", err)
+	}
+	l, err := res.List(namespace, apiv1beta1.GroupVersion.String(), &metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing %ss: %w", strings.ToLower(kind), err)
+	}
+	items, err := meta.ExtractList(l)
+	if err != nil {
+		return nil, fmt.Errorf("extracting %ss: %w", strings.ToLower(kind), err)
+	}
+	objs := make([]Object, 0, len(items))
+	for _, item := range items {
+		o, ok := item.(Object)
+		if !ok {
+			return nil, fmt.Errorf("unexpected list item type: %T", item)
+		}
+		objs = append(objs, o)
+	}
+	return objs, nil
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+)
+
+func Test_references(t *testing.T) {
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: "default"}
+	}
+	model := &apiv1beta1.Model{ObjectMeta: meta("base")}
+	dataset := &apiv1beta1.Dataset{ObjectMeta: meta("squad")}
+	modelRef := apiv1beta1.ObjectRef{Name: "base"}
+	datasetRef := apiv1beta1.DatasetRef{Name: "squad"}
+
+	cases := []struct {
+		name   string
+		obj    Object
+		target Object
+		expect bool
+	}{
+		{
+			name:   "model from model",
+			obj:    &apiv1beta1.Model{ObjectMeta: meta("finetuned"), Spec: apiv1beta1.ModelSpec{Model: &modelRef}},
+			target: model,
+			expect: true,
+		},
+		{
+			name:   "model from server",
+			obj:    &apiv1beta1.Server{ObjectMeta: meta("srv"), Spec: apiv1beta1.ServerSpec{Model: modelRef}},
+			target: model,
+			expect: true,
+		},
+		{
+			name:   "model from notebook",
+			obj:    &apiv1beta1.Notebook{ObjectMeta: meta("nb"), Spec: apiv1beta1.NotebookSpec{Model: &modelRef}},
+			target: model,
+			expect: true,
+		},
+		{
+			name:   "model from evaluation",
+			obj:    &apiv1beta1.Evaluation{ObjectMeta: meta("eval"), Spec: apiv1beta1.EvaluationSpec{Model: modelRef}},
+			target: model,
+			expect: true,
+		},
+		{
+			name:   "model from batch inference",
+			obj:    &apiv1beta1.BatchInference{ObjectMeta: meta("bi"), Spec: apiv1beta1.BatchInferenceSpec{Model: modelRef}},
+			target: model,
+			expect: true,
+		},
+		{
+			name:   "model from another namespace",
+			obj:    &apiv1beta1.Server{ObjectMeta: metav1.ObjectMeta{Name: "srv", Namespace: "other"}, Spec: apiv1beta1.ServerSpec{Model: modelRef}},
+			target: model,
+		},
+		{
+			name:   "dataset from model",
+			obj:    &apiv1beta1.Model{ObjectMeta: meta("finetuned"), Spec: apiv1beta1.ModelSpec{Dataset: &datasetRef}},
+			target: dataset,
+			expect: true,
+		},
+		{
+			name: "dataset from dataset",
+			obj: &apiv1beta1.Dataset{ObjectMeta: meta("squad-tokenized"), Spec: apiv1beta1.DatasetSpec{
+				Datasets: []apiv1beta1.DatasetMount{{Dataset: datasetRef}},
+			}},
+			target: dataset,
+			expect: true,
+		},
+		{
+			name:   "dataset from notebook",
+			obj:    &apiv1beta1.Notebook{ObjectMeta: meta("nb"), Spec: apiv1beta1.NotebookSpec{Dataset: &datasetRef}},
+			target: dataset,
+			expect: true,
+		},
+		{
+			name:   "dataset from evaluation",
+			obj:    &apiv1beta1.Evaluation{ObjectMeta: meta("eval"), Spec: apiv1beta1.EvaluationSpec{Dataset: datasetRef}},
+			target: dataset,
+			expect: true,
+		},
+		{
+			name:   "dataset from batch inference",
+			obj:    &apiv1beta1.BatchInference{ObjectMeta: meta("bi"), Spec: apiv1beta1.BatchInferenceSpec{Dataset: datasetRef}},
+			target: dataset,
+			expect: true,
+		},
+		{
+			name: "batch inference with the same name as the dataset",
+			obj: &apiv1beta1.Evaluation{ObjectMeta: meta("eval"), Spec: apiv1beta1.EvaluationSpec{
+				Dataset: apiv1beta1.DatasetRef{Name: "squad", Kind: "BatchInference"},
+			}},
+			target: dataset,
+		},
+		{
+			name: "dataset from itself",
+			obj: &apiv1beta1.Dataset{ObjectMeta: meta("squad"), Spec: apiv1beta1.DatasetSpec{
+				Datasets: []apiv1beta1.DatasetMount{{Dataset: datasetRef}},
+			}},
+			target: dataset,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expect, references(tc.obj, tc.target))
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package client
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+)
+
+// ErrDownloadExpired is returned when the signed URLs of a download expired
+// before all files were downloaded. A new download can be requested to
+// resume.
+var ErrDownloadExpired = errors.New("download urls expired")
+
+// partialSuffix is appended to the names of files that are still being
+// downloaded.
+const partialSuffix = ".partial"
+
+// RequestDownload sets the download request annotation on the object and
+// waits for the controller to populate the signed URLs of its artifacts.
+func (r *Resource) RequestDownload(ctx context.Context, obj Object, requestID string) (*apiv1beta1.DownloadStatus, error) {
+	patch := fmt.Sprintf(`{"metadata": {"annotations": {%q: %q}}}`, apiv1beta1.DownloadRequestAnnotation, requestID)
+	patched, err := r.Patch(obj.GetNamespace(), obj.GetName(), types.MergePatchType, []byte(patch), &metav1.PatchOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("patching download request: %w", err)
+	}
+
+	// NOTE: The r.Helper.WatchSingle() method does not support passing a context, calling the code
+	// below instead (it was pulled from the Helper implementation).
+	watcher, err := r.RESTClient.Get().
+		NamespaceIfScoped(obj.GetNamespace(), r.NamespaceScoped).
+		Resource(r.Resource).
+		VersionedParams(&metav1.ListOptions{
+			ResourceVersion: patched.(Object).GetResourceVersion(),
+			Watch:           true,
+			FieldSelector:   fields.OneTermEqualSelector("metadata.name", obj.GetName()).String(),
+		}, metav1.ParameterCodec).
+		Watch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer watcher.Stop()
+
+	check := func(o interface{}) (*apiv1beta1.DownloadStatus, error) {
+		d, ok := o.(interface {
+			GetStatusDownload() *apiv1beta1.DownloadStatus
+		})
+		if !ok {
+			return nil, fmt.Errorf("object is not downloadable: %T", o)
+		}
+		status := d.GetStatusDownload()
+		if status == nil || status.RequestID != requestID {
+			return nil, nil
+		}
+		if status.Message != "" {
+			return nil, errors.New(status.Message)
+		}
+		return status, nil
+	}
+
+	// The controller might have responded before the watch was started.
+	if status, err := check(patched); status != nil || err != nil {
+		return status, err
+	}
+
+	for event := range watcher.ResultChan() {
+		switch event.Type {
+		case watch.Added, watch.Modified:
+			if status, err := check(event.Object); status != nil || err != nil {
+				return status, err
+			}
+		case watch.Error:
+			if status, ok := event.Object.(*metav1.Status); ok {
+				return nil, fmt.Errorf("watch error occurred: %s", status.Message)
+			}
+			return nil, errors.New("unknown watch error occurred")
+		case watch.Deleted:
+			return nil, fmt.Errorf("object deleted before download urls were created")
+		default:
+			return nil, errors.New("unhandled event type")
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return nil, errors.New("watch closed before download urls were created")
+}
+
+// DownloadFiles downloads files into dir using up to parallelism concurrent
+// requests. Files that were already downloaded (matching size and checksum)
+// are skipped and partially downloaded files are resumed. The progressF
+// function is called with the number of bytes that each step completed.
+func DownloadFiles(ctx context.Context, dir string, download *apiv1beta1.DownloadStatus, parallelism int, progressF func(int64)) error {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mtx      sync.Mutex
+		firstErr error
+	)
+	files := make(chan apiv1beta1.DownloadFile)
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for f := range files {
+				if err := downloadFile(ctx, dir, download.Expiration.Time, f, progressF); err != nil {
+					mtx.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mtx.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, f := range download.Files {
+		mtx.Lock()
+		failed := firstErr != nil
+		mtx.Unlock()
+		if failed || ctx.Err() != nil {
+			break
+		}
+		files <- f
+	}
+	close(files)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+func downloadFile(ctx context.Context, dir string, expiration time.Time, f apiv1beta1.DownloadFile, progressF func(int64)) error {
+	dest := filepath.Join(dir, filepath.FromSlash(f.Path))
+	if rel, err := filepath.Rel(dir, dest); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("invalid file path: %q", f.Path)
+	}
+
+	if info, err := os.Stat(dest); err == nil && info.Size() == f.Size {
+		if err := verifyFile(dest, f); err == nil {
+			log.Printf("Skipping downloaded file: %v", f.Path)
+			progressF(f.Size)
+			return nil
+		}
+	}
+
+	if time.Now().After(expiration) {
+		return ErrDownloadExpired
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	partial := dest + partialSuffix
+
+	var offset int64
+	if info, err := os.Stat(partial); err == nil && info.Size() <= f.Size {
+		offset = info.Size()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.SignedURL, nil)
+	if err != nil {
+		return fmt.Errorf("download %v: %w", f.Path, err)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	log.Printf("Downloading %v (from byte %d)", f.Path, offset)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("download %v: %w", f.Path, err)
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// The server did not honor the range, start over.
+		offset = 0
+		flags |= os.O_TRUNC
+	case http.StatusPartialContent:
+		flags |= os.O_APPEND
+	case http.StatusRequestedRangeNotSatisfiable:
+		// Nothing left to download.
+		flags |= os.O_APPEND
+	case http.StatusForbidden:
+		return ErrDownloadExpired
+	default:
+		if time.Now().After(expiration) {
+			// Some clouds respond with 400 to expired URLs.
+			return ErrDownloadExpired
+		}
+		return fmt.Errorf("download %v: unexpected response status: %d", f.Path, resp.StatusCode)
+	}
+	progressF(offset)
+
+	out, err := os.OpenFile(partial, flags, 0644)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusRequestedRangeNotSatisfiable {
+		if _, err := io.Copy(out, &countingReader{r: resp.Body, f: progressF}); err != nil {
+			out.Close()
+			return fmt.Errorf("download %v: %w", f.Path, err)
+		}
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	if err := verifyFile(partial, f); err != nil {
+		// Start over the next time.
+		os.Remove(partial)
+		return fmt.Errorf("download %v: %w", f.Path, err)
+	}
+
+	return os.Rename(partial, dest)
+}
+
+// verifyFile checks the size and checksum of a file. Files without a known
+// checksum are only checked for their size.
+func verifyFile(p string, f apiv1beta1.DownloadFile) error {
+	var (
+		h    hash.Hash
+		want string
+	)
+	switch {
+	case f.SHA256 != "":
+		h, want = sha256.New(), f.SHA256
+	case f.MD5Checksum != "":
+		h, want = md5.New(), f.MD5Checksum
+	default:
+		h = md5.New()
+	}
+
+	file, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	n, err := io.Copy(h, file)
+	if err != nil {
+		return err
+	}
+	if n != f.Size {
+		return fmt.Errorf("size mismatch: expected %d bytes, got %d", f.Size, n)
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); want != "" && !strings.EqualFold(got, want) {
+		return fmt.Errorf("checksum mismatch: expected %v, got %v", want, got)
+	}
+	return nil
+}
+
+// countingReader calls f with the number of bytes of every read.
+type countingReader struct {
+	r io.Reader
+	f func(int64)
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.f(int64(n))
+	return n, err
+}
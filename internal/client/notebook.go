@@ -6,10 +6,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
-	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 )
 
-func PodForNotebook(nb *apiv1.Notebook) types.NamespacedName {
+func PodForNotebook(nb *apiv1beta1.Notebook) types.NamespacedName {
 	// TODO: Pull Pod info from status of Notebook.
 	return types.NamespacedName{
 		Namespace: nb.GetNamespace(),
@@ -17,20 +17,20 @@ func PodForNotebook(nb *apiv1.Notebook) types.NamespacedName {
 	}
 }
 
-func NotebookForObject(obj Object) (*apiv1.Notebook, error) {
-	var nb *apiv1.Notebook
+func NotebookForObject(obj Object) (*apiv1beta1.Notebook, error) {
+	var nb *apiv1beta1.Notebook
 
 	switch obj := obj.DeepCopyObject().(type) {
-	case *apiv1.Notebook:
+	case *apiv1beta1.Notebook:
 		nb = obj
 
-	case *apiv1.Model:
-		nb = &apiv1.Notebook{
+	case *apiv1beta1.Model:
+		nb = &apiv1beta1.Notebook{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      obj.Name + "-model",
 				Namespace: obj.Namespace,
 			},
-			Spec: apiv1.NotebookSpec{
+			Spec: apiv1beta1.NotebookSpec{
 				Image:     obj.Spec.Image,
 				Env:       obj.Spec.Env,
 				Params:    obj.Spec.Params,
@@ -40,13 +40,13 @@ func NotebookForObject(obj Object) (*apiv1.Notebook, error) {
 			},
 		}
 
-	case *apiv1.Server:
-		nb = &apiv1.Notebook{
+	case *apiv1beta1.Server:
+		nb = &apiv1beta1.Notebook{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      obj.Name + "-server",
 				Namespace: obj.Namespace,
 			},
-			Spec: apiv1.NotebookSpec{
+			Spec: apiv1beta1.NotebookSpec{
 				Image:     obj.Spec.Image,
 				Env:       obj.Spec.Env,
 				Params:    obj.Spec.Params,
@@ -55,13 +55,13 @@ func NotebookForObject(obj Object) (*apiv1.Notebook, error) {
 			},
 		}
 
-	case *apiv1.Dataset:
-		nb = &apiv1.Notebook{
+	case *apiv1beta1.Dataset:
+		nb = &apiv1beta1.Notebook{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      obj.Name + "-dataset",
 				Namespace: obj.Namespace,
 			},
-			Spec: apiv1.NotebookSpec{
+			Spec: apiv1beta1.NotebookSpec{
 				Image:     obj.Spec.Image,
 				Env:       obj.Spec.Env,
 				Params:    obj.Spec.Params,
@@ -78,7 +78,7 @@ func NotebookForObject(obj Object) (*apiv1.Notebook, error) {
 	nb.ObjectMeta.ManagedFields = nil
 
 	nb.TypeMeta = metav1.TypeMeta{
-		APIVersion: "substratus.ai/v1",
+		APIVersion: "substratus.ai/v1beta1",
 		Kind:       "Notebook",
 	}
 
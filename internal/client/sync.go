@@ -21,11 +21,11 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/remotecommand"
 
-	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 	"github.com/substratusai/substratus/internal/client/cp"
 )
 
-func (c *Client) SyncFilesFromNotebook(ctx context.Context, nb *apiv1.Notebook, localDir string,
+func (c *Client) SyncFilesFromNotebook(ctx context.Context, nb *apiv1beta1.Notebook, localDir string,
 	logger io.Writer,
 	progressF func(file string, complete bool, err error),
 ) error {
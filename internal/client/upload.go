@@ -24,7 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/utils/ptr"
 
-	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 )
 
 var httpClient = &http.Client{}
@@ -69,8 +69,8 @@ func PrepareImageTarball(ctx context.Context, buildPath string, progressF func(f
 
 func SetUploadContainerSpec(obj Object, tb *Tarball, requestID string) error {
 	type buildable interface {
-		GetBuild() *apiv1.Build
-		SetBuild(*apiv1.Build)
+		GetBuild() *apiv1beta1.Build
+		SetBuild(*apiv1beta1.Build)
 	}
 
 	bObj, ok := obj.(buildable)
@@ -80,10 +80,10 @@ func SetUploadContainerSpec(obj Object, tb *Tarball, requestID string) error {
 
 	b := bObj.GetBuild()
 	if b == nil {
-		b = &apiv1.Build{}
+		b = &apiv1beta1.Build{}
 	}
 	b.Git = nil
-	b.Upload = &apiv1.BuildUpload{
+	b.Upload = &apiv1beta1.BuildUpload{
 		MD5Checksum: tb.MD5Checksum,
 		RequestID:   requestID,
 	}
@@ -146,8 +146,8 @@ loop:
 		switch event.Type {
 		case watch.Added, watch.Modified:
 			o := event.Object.(interface {
-				GetStatusUpload() apiv1.UploadStatus
-				GetBuild() *apiv1.Build
+				GetStatusUpload() apiv1beta1.UploadStatus
+				GetBuild() *apiv1beta1.Build
 			})
 			status := o.GetStatusUpload()
 			spec := o.GetBuild().Upload
@@ -12,7 +12,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 )
 
 const CloudEnvVar = "CLOUD"
@@ -31,6 +31,10 @@ type Cloud interface {
 	// ObjectArtifactURL returns the URL of the artifact that was stored for a given Object.
 	ObjectArtifactURL(Object) *BucketURL
 
+	// BaseArtifactURL returns the URL that the artifacts of all Objects are
+	// stored under.
+	BaseArtifactURL() *BucketURL
+
 	// AssociatePrincipal associates the given K8s service account with a cloud
 	// identity (i.e. updates cloud specific annotations on K8s SA)
 	AssociatePrincipal(*corev1.ServiceAccount)
@@ -61,6 +65,10 @@ func New(ctx context.Context) (Cloud, error) {
 		c = &GCP{}
 	case KindName:
 		c = &Kind{}
+	case S3Name:
+		c = &S3{}
+	case PVCName:
+		c = &PVC{}
 	default:
 		if cloudName == "" {
 			return nil, fmt.Errorf("unable to determine cloud, if running remotely, specify %v environment variable", CloudEnvVar)
@@ -100,12 +108,12 @@ type Object = client.Object
 
 type BuildableObject interface {
 	client.Object
-	GetBuild() *apiv1.Build
+	GetBuild() *apiv1beta1.Build
 	SetImage(string)
 	GetImage() string
 }
 
 type ArtifactObject interface {
 	client.Object
-	GetStatusArtifacts() apiv1.ArtifactsStatus
+	GetStatusArtifacts() apiv1beta1.ArtifactsStatus
 }
@@ -48,6 +48,11 @@ func (c *Common) ObjectArtifactURL(obj Object) *BucketURL {
 	return &u
 }
 
+func (c *Common) BaseArtifactURL() *BucketURL {
+	u := *c.ArtifactBucketURL
+	return &u
+}
+
 func objectHash(cluster string, obj Object) string {
 	h := md5.New()
 	io.WriteString(h, objectHashInput(cluster, obj))
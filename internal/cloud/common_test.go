@@ -8,7 +8,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/sethvargo/go-envconfig"
 	"github.com/stretchr/testify/require"
-	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 	"github.com/substratusai/substratus/internal/cloud"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -31,45 +31,45 @@ func TestCommon(t *testing.T) {
 		Principal:         "dummy-value",
 	}, common)
 
-	require.Equal(t, "gcr.io/my-project/my-cluster-model-my-ns-my-model:latest", common.ObjectBuiltImageURL(&apiv1.Model{
+	require.Equal(t, "gcr.io/my-project/my-cluster-model-my-ns-my-model:latest", common.ObjectBuiltImageURL(&apiv1beta1.Model{
 		TypeMeta:   metav1.TypeMeta{Kind: "Model"},
 		ObjectMeta: metav1.ObjectMeta{Name: "my-model", Namespace: "my-ns"},
-		Spec: apiv1.ModelSpec{
-			Build: &apiv1.Build{},
+		Spec: apiv1beta1.ModelSpec{
+			Build: &apiv1beta1.Build{},
 		},
 	}))
-	require.Equal(t, "gcr.io/my-project/my-cluster-model-my-ns-my-model:v1.2.3", common.ObjectBuiltImageURL(&apiv1.Model{
+	require.Equal(t, "gcr.io/my-project/my-cluster-model-my-ns-my-model:v1.2.3", common.ObjectBuiltImageURL(&apiv1beta1.Model{
 		TypeMeta:   metav1.TypeMeta{Kind: "Model"},
 		ObjectMeta: metav1.ObjectMeta{Name: "my-model", Namespace: "my-ns"},
-		Spec: apiv1.ModelSpec{
-			Build: &apiv1.Build{
-				Git: &apiv1.BuildGit{
+		Spec: apiv1beta1.ModelSpec{
+			Build: &apiv1beta1.Build{
+				Git: &apiv1beta1.BuildGit{
 					Tag: "v1.2.3",
 				},
 			},
 		},
 	}))
-	require.Equal(t, "gcr.io/my-project/my-cluster-model-my-ns-my-model:feature-x", common.ObjectBuiltImageURL(&apiv1.Model{
+	require.Equal(t, "gcr.io/my-project/my-cluster-model-my-ns-my-model:feature-x", common.ObjectBuiltImageURL(&apiv1beta1.Model{
 		TypeMeta:   metav1.TypeMeta{Kind: "Model"},
 		ObjectMeta: metav1.ObjectMeta{Name: "my-model", Namespace: "my-ns"},
-		Spec: apiv1.ModelSpec{
-			Build: &apiv1.Build{
-				Git: &apiv1.BuildGit{
+		Spec: apiv1beta1.ModelSpec{
+			Build: &apiv1beta1.Build{
+				Git: &apiv1beta1.BuildGit{
 					Branch: "feature-x",
 				},
 			},
 		},
 	}))
-	require.Equal(t, "gcr.io/my-project/my-cluster-model-my-ns-my-model:80355073480594a99470dcacccd8cf2c", common.ObjectBuiltImageURL(&apiv1.Model{
+	require.Equal(t, "gcr.io/my-project/my-cluster-model-my-ns-my-model:80355073480594a99470dcacccd8cf2c", common.ObjectBuiltImageURL(&apiv1beta1.Model{
 		TypeMeta:   metav1.TypeMeta{Kind: "Model"},
 		ObjectMeta: metav1.ObjectMeta{Name: "my-model", Namespace: "my-ns"},
-		Spec: apiv1.ModelSpec{
-			Build: &apiv1.Build{
-				Upload: &apiv1.BuildUpload{
+		Spec: apiv1beta1.ModelSpec{
+			Build: &apiv1beta1.Build{
+				Upload: &apiv1beta1.BuildUpload{
 					MD5Checksum: "80355073480594a99470dcacccd8cf2c",
 				},
 			},
 		},
 	}))
-	require.Equal(t, "gs://my-artifact-bucket/93ea94b18012ca14d84e1468d65e8709", common.ObjectArtifactURL(&apiv1.Model{TypeMeta: metav1.TypeMeta{Kind: "Model"}, ObjectMeta: metav1.ObjectMeta{Name: "my-model", Namespace: "my-ns"}}).String())
+	require.Equal(t, "gs://my-artifact-bucket/93ea94b18012ca14d84e1468d65e8709", common.ObjectArtifactURL(&apiv1beta1.Model{TypeMeta: metav1.TypeMeta{Kind: "Model"}, ObjectMeta: metav1.ObjectMeta{Name: "my-model", Namespace: "my-ns"}}).String())
 }
@@ -0,0 +1,125 @@
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	PVCName = "pvc"
+
+	// PVCMountPath is the path that the artifacts PVC is mounted at in the
+	// SCI and in container builder Pods.
+	PVCMountPath = "/bucket"
+)
+
+// PVC stores artifacts on a ReadWriteMany PersistentVolumeClaim (i.e. NFS,
+// CephFS). It is intended for multi-node clusters that do not have access to
+// an object store.
+type PVC struct {
+	// ClaimName is the name of the ReadWriteMany PVC that artifacts are stored on.
+	// A PVC with this name is expected to exist in the substratus namespace
+	// and in each namespace that Substratus objects are created in, all bound
+	// to the same underlying storage.
+	ClaimName string `env:"ARTIFACT_PVC_NAME,default=substratus-artifacts" validate:"required"`
+
+	Common
+}
+
+func (p *PVC) Name() string { return PVCName }
+
+func (p *PVC) AutoConfigure(ctx context.Context) error {
+	if p.ArtifactBucketURL == nil {
+		// Translates to: "tar:///bucket"
+		//
+		// NOTE: Just like the Kind cloud, kaniko is able to read build
+		// contexts from this address because the PVC is mounted at /bucket
+		// in the builder container.
+		p.ArtifactBucketURL = &BucketURL{
+			Scheme: "tar",
+			Bucket: "",
+			Path:   PVCMountPath,
+		}
+	}
+
+	return nil
+}
+
+func (p *PVC) MountBucket(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, obj ArtifactObject, req MountBucketConfig) error {
+	var bktURL *BucketURL
+	if statusURL := obj.GetStatusArtifacts().URL; statusURL != "" {
+		var err error
+		bktURL, err = ParseBucketURL(statusURL)
+		if err != nil {
+			return fmt.Errorf("parsing status bucket url: %w", err)
+		}
+	} else {
+		bktURL = p.ObjectArtifactURL(obj)
+	}
+
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: req.Name,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: p.ClaimName,
+				ReadOnly:  req.ReadOnly,
+			},
+		},
+	})
+
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Name == req.Container {
+			for _, mount := range req.Mounts {
+				subPath, err := pvcSubPath(bktURL.Path, mount.BucketSubdir)
+				if err != nil {
+					return err
+				}
+				podSpec.Containers[i].VolumeMounts = append(podSpec.Containers[i].VolumeMounts,
+					corev1.VolumeMount{
+						Name:      req.Name,
+						MountPath: "/content/" + mount.ContentSubdir,
+						SubPath:   subPath,
+						ReadOnly:  req.ReadOnly,
+					},
+				)
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("container not found: %s", req.Container)
+}
+
+// pvcSubPath translates a bucket path (rooted at PVCMountPath) into a path
+// relative to the root of the PVC.
+func pvcSubPath(bucketPath, subdir string) (string, error) {
+	rel, err := filepath.Rel(PVCMountPath, filepath.Join("/", bucketPath, subdir))
+	if err != nil {
+		return "", fmt.Errorf("relative path: %w", err)
+	}
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", fmt.Errorf("path %q is outside of %s", bucketPath, PVCMountPath)
+	}
+	return rel, nil
+}
+
+// BucketVolume returns a Volume that mounts the root of the artifacts PVC.
+func (p *PVC) BucketVolume(name string) corev1.Volume {
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: p.ClaimName,
+			},
+		},
+	}
+}
+
+func (p *PVC) AssociatePrincipal(*corev1.ServiceAccount) {}
+
+func (p *PVC) GetPrincipal(*corev1.ServiceAccount) (string, bool) { return "", true }
@@ -0,0 +1,85 @@
+package cloud_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sethvargo/go-envconfig"
+	"github.com/stretchr/testify/require"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+	"github.com/substratusai/substratus/internal/cloud"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPVC(t *testing.T) {
+	var pvc cloud.PVC
+	os.Setenv("CLUSTER_NAME", "my-cluster")
+	os.Unsetenv("ARTIFACT_BUCKET_URL")
+	os.Setenv("REGISTRY_URL", "registry.local/substratus")
+	os.Setenv("PRINCIPAL", "unused")
+	os.Setenv("ARTIFACT_PVC_NAME", "my-artifacts")
+
+	require.NoError(t, envconfig.Process(context.Background(), &pvc))
+	require.NoError(t, pvc.AutoConfigure(context.Background()))
+	require.NoError(t, validator.New().Struct(&pvc))
+
+	require.Equal(t, "my-artifacts", pvc.ClaimName)
+
+	principal, bound := pvc.GetPrincipal(&corev1.ServiceAccount{})
+	require.Equal(t, "", principal)
+	require.True(t, bound)
+
+	model := &apiv1beta1.Model{TypeMeta: metav1.TypeMeta{Kind: "Model"}, ObjectMeta: metav1.ObjectMeta{Name: "my-model", Namespace: "my-ns"}}
+
+	cases := []struct {
+		name      string
+		statusURL string
+	}{
+		{name: "from-spec"},
+		{name: "from-status", statusURL: pvc.ObjectArtifactURL(model).String()},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			model := model.DeepCopy()
+			model.Status.Artifacts.URL = c.statusURL
+
+			podMeta := metav1.ObjectMeta{}
+			podSpec := corev1.PodSpec{Containers: []corev1.Container{{Name: "model"}}}
+			require.NoError(t, pvc.MountBucket(&podMeta, &podSpec, model, cloud.MountBucketConfig{
+				Name:      "artifacts",
+				Mounts:    []cloud.BucketMount{{BucketSubdir: "artifacts", ContentSubdir: "artifacts"}},
+				Container: "model",
+				ReadOnly:  true,
+			}))
+			require.Equal(t, []corev1.Volume{{
+				Name: "artifacts",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "my-artifacts",
+						ReadOnly:  true,
+					},
+				},
+			}}, podSpec.Volumes)
+			require.Equal(t, []corev1.VolumeMount{{
+				Name:      "artifacts",
+				MountPath: "/content/artifacts",
+				SubPath:   "93ea94b18012ca14d84e1468d65e8709/artifacts",
+				ReadOnly:  true,
+			}}, podSpec.Containers[0].VolumeMounts)
+		})
+	}
+
+	{
+		outside := model.DeepCopy()
+		outside.Status.Artifacts.URL = "tar:///etc"
+		podSpec := corev1.PodSpec{Containers: []corev1.Container{{Name: "model"}}}
+		require.Error(t, pvc.MountBucket(&metav1.ObjectMeta{}, &podSpec, outside, cloud.MountBucketConfig{
+			Name:      "artifacts",
+			Mounts:    []cloud.BucketMount{{BucketSubdir: "artifacts", ContentSubdir: "artifacts"}},
+			Container: "model",
+		}))
+	}
+}
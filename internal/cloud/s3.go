@@ -0,0 +1,124 @@
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const S3Name = "s3"
+
+// S3 targets any S3-compatible object store (i.e. MinIO, Ceph RGW) that is
+// reachable from within the cluster. It is intended for on-prem clusters that
+// do not have access to a hyperscaler bucket.
+type S3 struct {
+	Common
+
+	// Endpoint is the URL of the S3-compatible API.
+	// Example: http://minio.minio.svc.cluster.local:9000
+	Endpoint string `env:"S3_ENDPOINT" validate:"required"`
+	// Region is passed to S3 clients. Most S3-compatible stores ignore it.
+	Region string `env:"S3_REGION,default=us-east-1"`
+
+	// CredentialsSecretName is the name of the Secret that holds access key
+	// credentials for the object store. The Secret is expected to exist in
+	// each namespace that Substratus objects are created in and contain the
+	// keys: "accessKeyID", "secretAccessKey" and "endpoint".
+	CredentialsSecretName string `env:"S3_CREDENTIALS_SECRET,default=s3-credentials"`
+
+	// CSIDriver is the name of the CSI driver that is used to mount buckets
+	// into Pods. The driver must support inline ephemeral volumes.
+	CSIDriver string `env:"S3_CSI_DRIVER,default=ru.yandex.s3.csi"`
+}
+
+func (s *S3) Name() string { return S3Name }
+
+func (s *S3) AutoConfigure(ctx context.Context) error {
+	if s.ArtifactBucketURL == nil {
+		s.ArtifactBucketURL = &BucketURL{
+			Scheme: "s3",
+			Bucket: "substratus-artifacts",
+		}
+	}
+
+	return nil
+}
+
+func (s *S3) MountBucket(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, obj ArtifactObject, req MountBucketConfig) error {
+	var bktURL *BucketURL
+	if statusURL := obj.GetStatusArtifacts().URL; statusURL != "" {
+		var err error
+		bktURL, err = ParseBucketURL(statusURL)
+		if err != nil {
+			return fmt.Errorf("parsing status bucket url: %w", err)
+		}
+	} else {
+		bktURL = s.ObjectArtifactURL(obj)
+	}
+
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: req.Name,
+		VolumeSource: corev1.VolumeSource{
+			CSI: &corev1.CSIVolumeSource{
+				Driver:   s.CSIDriver,
+				ReadOnly: ptr.To(req.ReadOnly),
+				VolumeAttributes: map[string]string{
+					"bucket":  bktURL.Bucket,
+					"mounter": "geesefs",
+					"options": "--uid=0 --gid=3003 --dir-mode=0775 --file-mode=0664",
+				},
+				NodePublishSecretRef: &corev1.LocalObjectReference{
+					Name: s.CredentialsSecretName,
+				},
+			},
+		},
+	})
+
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Name == req.Container {
+			for _, mount := range req.Mounts {
+				podSpec.Containers[i].VolumeMounts = append(podSpec.Containers[i].VolumeMounts,
+					corev1.VolumeMount{
+						Name:      req.Name,
+						MountPath: "/content/" + mount.ContentSubdir,
+						SubPath:   strings.TrimPrefix(bktURL.Path+"/"+mount.BucketSubdir, "/"),
+						ReadOnly:  req.ReadOnly,
+					},
+				)
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("container not found: %s", req.Container)
+}
+
+// BuilderEnv returns the environment variables that kaniko needs in order to
+// pull a build context from an S3-compatible endpoint.
+func (s *S3) BuilderEnv() []corev1.EnvVar {
+	secretKey := func(key string) *corev1.EnvVarSource {
+		return &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: s.CredentialsSecretName},
+				Key:                  key,
+			},
+		}
+	}
+	return []corev1.EnvVar{
+		{Name: "S3_ENDPOINT", Value: s.Endpoint},
+		{Name: "S3_FORCE_PATH_STYLE", Value: "true"},
+		{Name: "AWS_REGION", Value: s.Region},
+		{Name: "AWS_ACCESS_KEY_ID", ValueFrom: secretKey("accessKeyID")},
+		{Name: "AWS_SECRET_ACCESS_KEY", ValueFrom: secretKey("secretAccessKey")},
+	}
+}
+
+// AssociatePrincipal is a no-op: access to the object store is granted via
+// static credentials rather than workload identity.
+func (s *S3) AssociatePrincipal(*corev1.ServiceAccount) {}
+
+func (s *S3) GetPrincipal(*corev1.ServiceAccount) (string, bool) { return "", true }
@@ -0,0 +1,55 @@
+package cloud_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sethvargo/go-envconfig"
+	"github.com/stretchr/testify/require"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+	"github.com/substratusai/substratus/internal/cloud"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestS3(t *testing.T) {
+	var s3 cloud.S3
+	os.Setenv("CLUSTER_NAME", "my-cluster")
+	os.Setenv("ARTIFACT_BUCKET_URL", "s3://my-artifact-bucket")
+	os.Setenv("REGISTRY_URL", "registry.local/substratus")
+	os.Setenv("PRINCIPAL", "unused")
+	os.Setenv("S3_ENDPOINT", "http://minio.minio.svc.cluster.local:9000")
+
+	require.Error(t, validator.New().Struct(&s3))
+	require.NoError(t, envconfig.Process(context.Background(), &s3))
+	require.NoError(t, s3.AutoConfigure(context.Background()))
+	require.NoError(t, validator.New().Struct(&s3))
+
+	require.Equal(t, "s3-credentials", s3.CredentialsSecretName)
+	require.Equal(t, "us-east-1", s3.Region)
+
+	principal, bound := s3.GetPrincipal(&corev1.ServiceAccount{})
+	require.Equal(t, "", principal)
+	require.True(t, bound)
+
+	model := &apiv1beta1.Model{TypeMeta: metav1.TypeMeta{Kind: "Model"}, ObjectMeta: metav1.ObjectMeta{Name: "my-model", Namespace: "my-ns"}}
+	require.Equal(t, "s3://my-artifact-bucket/93ea94b18012ca14d84e1468d65e8709", s3.ObjectArtifactURL(model).String())
+
+	podMeta := metav1.ObjectMeta{}
+	podSpec := corev1.PodSpec{Containers: []corev1.Container{{Name: "model"}}}
+	require.NoError(t, s3.MountBucket(&podMeta, &podSpec, model, cloud.MountBucketConfig{
+		Name:      "artifacts",
+		Mounts:    []cloud.BucketMount{{BucketSubdir: "artifacts", ContentSubdir: "artifacts"}},
+		Container: "model",
+	}))
+	require.Len(t, podSpec.Volumes, 1)
+	require.Equal(t, "my-artifact-bucket", podSpec.Volumes[0].CSI.VolumeAttributes["bucket"])
+	require.Equal(t, "s3-credentials", podSpec.Volumes[0].CSI.NodePublishSecretRef.Name)
+	require.Equal(t, []corev1.VolumeMount{{
+		Name:      "artifacts",
+		MountPath: "/content/artifacts",
+		SubPath:   "93ea94b18012ca14d84e1468d65e8709/artifacts",
+	}}, podSpec.Containers[0].VolumeMounts)
+}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+	"github.com/substratusai/substratus/internal/cloud"
+	"github.com/substratusai/substratus/internal/sci"
+)
+
+// adoptedArtifactsRecheckInterval is how often artifacts that were not
+// found (yet) are looked up again.
+const adoptedArtifactsRecheckInterval = time.Minute
+
+// artifactsObject is implemented by kinds that can adopt existing
+// artifacts.
+type artifactsObject interface {
+	client.Object
+	GetConditions() *[]metav1.Condition
+	GetStatusReady() bool
+	SetStatusReady(bool)
+	GetStatusArtifacts() apiv1beta1.ArtifactsStatus
+	SetStatusArtifacts(apiv1beta1.ArtifactsStatus)
+}
+
+// reconcileAdoptedArtifacts marks an object as ready once the existing
+// artifacts at the given URL are found (via the SCI) instead of running a
+// Job to produce them.
+func reconcileAdoptedArtifacts(ctx context.Context, c client.Client, sciClient sci.ControllerClient, obj artifactsObject, url string) (result, error) {
+	log := log.FromContext(ctx)
+
+	if obj.GetStatusReady() && obj.GetStatusArtifacts().URL == url {
+		return result{success: true}, nil
+	}
+
+	notReady := func(reason, message string) error {
+		obj.SetStatusReady(false)
+		meta.SetStatusCondition(obj.GetConditions(), metav1.Condition{
+			Type:               apiv1beta1.ConditionComplete,
+			Status:             metav1.ConditionFalse,
+			Reason:             reason,
+			ObservedGeneration: obj.GetGeneration(),
+			Message:            message,
+		})
+		if err := c.Status().Update(ctx, obj); err != nil {
+			return fmt.Errorf("updating status: %w", err)
+		}
+		return nil
+	}
+
+	u, err := cloud.ParseBucketURL(url)
+	if err != nil || u.Scheme == "" {
+		if err := notReady(apiv1beta1.ReasonArtifactsInvalid, fmt.Sprintf("Invalid artifacts url: %q", url)); err != nil {
+			return result{}, err
+		}
+		// No use in retrying...
+		return result{}, nil
+	}
+
+	files, size, err := listArtifacts(ctx, sciClient, u)
+	if err != nil {
+		return result{}, err
+	}
+	if files == 0 {
+		log.Info("No artifacts found", "url", url)
+		if err := notReady(apiv1beta1.ReasonArtifactsNotFound, fmt.Sprintf("No objects found under %v/artifacts/", url)); err != nil {
+			return result{}, err
+		}
+		// The artifacts might still be copied into place.
+		return result{Result: ctrl.Result{RequeueAfter: adoptedArtifactsRecheckInterval}}, nil
+	}
+
+	obj.SetStatusArtifacts(apiv1beta1.ArtifactsStatus{
+		URL:       url,
+		SizeBytes: size,
+		FileCount: files,
+	})
+	obj.SetStatusReady(true)
+	meta.SetStatusCondition(obj.GetConditions(), metav1.Condition{
+		Type:               apiv1beta1.ConditionComplete,
+		Status:             metav1.ConditionTrue,
+		Reason:             apiv1beta1.ReasonArtifactsAdopted,
+		ObservedGeneration: obj.GetGeneration(),
+	})
+	if err := c.Status().Update(ctx, obj); err != nil {
+		return result{}, fmt.Errorf("updating status: %w", err)
+	}
+
+	return result{success: true}, nil
+}
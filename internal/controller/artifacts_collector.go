@@ -0,0 +1,372 @@
+package controller
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+	"github.com/substratusai/substratus/internal/cloud"
+	"github.com/substratusai/substratus/internal/sci"
+)
+
+const (
+	// artifactsFinalizer makes sure that the artifacts and uploads of an
+	// object are deleted from the bucket before the object is gone.
+	artifactsFinalizer = "substratus.ai/artifacts"
+
+	// retainedArtifactsConfigMapName is the ConfigMap that records the
+	// prefixes of retained artifacts so that they are never swept. Removing
+	// an entry allows the next sweeps to delete the prefix.
+	retainedArtifactsConfigMapName = "substratus-retained-artifacts"
+
+	// artifactPrefixesConfigMapName is the ConfigMap that records the
+	// prefixes that objects of this cluster store artifacts in. Only these
+	// prefixes are ever swept: the bucket might be shared with other
+	// clusters.
+	artifactPrefixesConfigMapName = "substratus-artifact-prefixes"
+)
+
+// ArtifactsCollector deletes the objects that were stored in the bucket for
+// Models, Datasets and Notebooks once they are deleted. When added to a
+// manager, it also periodically sweeps the recorded prefixes whose owning
+// objects no longer exist (i.e. the finalizer was removed by hand).
+type ArtifactsCollector struct {
+	Client client.Client
+	Cloud  cloud.Cloud
+	SCI    sci.ControllerClient
+
+	// Namespace is where the records of artifact prefixes are kept.
+	Namespace string
+
+	// SweepInterval is how often orphaned prefixes are swept. Zero disables
+	// sweeping.
+	SweepInterval time.Duration
+
+	// orphans are the prefixes that the previous sweep found without an
+	// owner. Prefixes are only deleted when they are found orphaned twice in
+	// a row to avoid racing with objects that were just created.
+	orphans map[string]bool
+}
+
+// ReconcileArtifactsFinalizer records the artifacts prefix of an object that
+// is not being deleted and adds the artifacts finalizer. Once the object is
+// being deleted, its artifacts are deleted (unless they are retained) and
+// the finalizer is removed. The result is only successful if the object is
+// not being deleted.
+func (r *ArtifactsCollector) ReconcileArtifactsFinalizer(ctx context.Context, obj client.Object) (result, error) {
+	if obj.GetDeletionTimestamp().IsZero() {
+		name := path.Base(r.Cloud.ObjectArtifactURL(obj).Path)
+		if err := r.setRecord(ctx, artifactPrefixesConfigMapName, name, artifactsOwner(obj)); err != nil {
+			return result{}, fmt.Errorf("recording artifacts prefix: %w", err)
+		}
+		if controllerutil.AddFinalizer(obj, artifactsFinalizer) {
+			if err := updateObject(ctx, r.Client, obj); err != nil {
+				return result{}, fmt.Errorf("adding finalizer: %w", err)
+			}
+		}
+		return result{success: true}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(obj, artifactsFinalizer) {
+		return result{}, nil
+	}
+
+	if err := r.collect(ctx, obj); err != nil {
+		return result{}, err
+	}
+
+	controllerutil.RemoveFinalizer(obj, artifactsFinalizer)
+	if err := r.Client.Update(ctx, obj); err != nil {
+		return result{}, fmt.Errorf("removing finalizer: %w", err)
+	}
+
+	return result{}, nil
+}
+
+// collect deletes everything under the artifact URL of the given object
+// unless the artifacts are retained or adopted by another object.
+func (r *ArtifactsCollector) collect(ctx context.Context, obj client.Object) error {
+	log := log.FromContext(ctx)
+
+	u := r.Cloud.ObjectArtifactURL(obj)
+	name := path.Base(u.Path)
+	owner := artifactsOwner(obj)
+
+	if retain, _ := strconv.ParseBool(obj.GetAnnotations()[apiv1beta1.RetainArtifactsAnnotation]); retain {
+		log.Info("Retaining artifacts", "url", u.String())
+		if err := r.setRecord(ctx, retainedArtifactsConfigMapName, name, owner); err != nil {
+			return fmt.Errorf("recording retained artifacts: %w", err)
+		}
+		return nil
+	}
+
+	owners, err := r.prefixOwners(ctx)
+	if err != nil {
+		return fmt.Errorf("listing artifact owners: %w", err)
+	}
+	for _, o := range owners[name] {
+		if o != owner {
+			log.Info("Retaining artifacts that are in use", "url", u.String(), "owner", o)
+			return nil
+		}
+	}
+
+	resp, err := r.SCI.DeleteObjects(ctx, &sci.DeleteObjectsRequest{
+		BucketName: u.Bucket,
+		Prefix:     strings.TrimPrefix(u.Path, "/") + "/",
+	})
+	if err != nil {
+		return fmt.Errorf("calling the sci service to DeleteObjects: %w", err)
+	}
+	log.Info("Deleted artifacts", "url", u.String(), "count", resp.DeletedCount)
+
+	// A previous object with the same name might have retained them.
+	if err := r.deleteRecord(ctx, retainedArtifactsConfigMapName, name); err != nil {
+		return fmt.Errorf("forgetting retained artifacts: %w", err)
+	}
+	if err := r.deleteRecord(ctx, artifactPrefixesConfigMapName, name); err != nil {
+		return fmt.Errorf("forgetting artifacts prefix: %w", err)
+	}
+
+	return nil
+}
+
+// Start implements manager.Runnable by sweeping orphaned prefixes every
+// SweepInterval until the context is done.
+func (r *ArtifactsCollector) Start(ctx context.Context) error {
+	if r.SweepInterval <= 0 {
+		return nil
+	}
+	log := log.FromContext(ctx).WithName("artifacts-collector")
+
+	ticker := time.NewTicker(r.SweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := r.Sweep(ctx); err != nil {
+				log.Error(err, "Sweeping orphaned artifacts")
+			}
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, only the
+// leader should be deleting objects.
+func (r *ArtifactsCollector) NeedLeaderElection() bool {
+	return true
+}
+
+// Sweep deletes the recorded prefixes under the base artifact URL that have
+// had no owning object since the previous sweep.
+func (r *ArtifactsCollector) Sweep(ctx context.Context) error {
+	log := log.FromContext(ctx)
+
+	base := r.Cloud.BaseArtifactURL()
+	var listPrefix string
+	if dir := strings.Trim(base.Path, "/"); dir != "" {
+		listPrefix = dir + "/"
+	}
+
+	// The records are read before the owners so that prefixes of objects
+	// that are created in between are seen as owned.
+	recorded, err := r.records(ctx, artifactPrefixesConfigMapName)
+	if err != nil {
+		return fmt.Errorf("listing recorded artifacts prefixes: %w", err)
+	}
+	owners, err := r.prefixOwners(ctx)
+	if err != nil {
+		return fmt.Errorf("listing artifact owners: %w", err)
+	}
+	retained, err := r.records(ctx, retainedArtifactsConfigMapName)
+	if err != nil {
+		return fmt.Errorf("listing retained artifacts: %w", err)
+	}
+
+	orphans := map[string]bool{}
+	for name := range recorded {
+		// Guard against records that were edited by hand.
+		if !isObjectHash(name) {
+			continue
+		}
+		if _, ok := retained[name]; ok || len(owners[name]) > 0 {
+			continue
+		}
+		if !r.orphans[name] {
+			orphans[name] = true
+			continue
+		}
+
+		resp, err := r.SCI.DeleteObjects(ctx, &sci.DeleteObjectsRequest{
+			BucketName: base.Bucket,
+			Prefix:     listPrefix + name + "/",
+		})
+		if err != nil {
+			log.Error(err, "Deleting orphaned artifacts", "prefix", listPrefix+name)
+			orphans[name] = true
+			continue
+		}
+		log.Info("Deleted orphaned artifacts", "prefix", listPrefix+name, "count", resp.DeletedCount)
+		if err := r.deleteRecord(ctx, artifactPrefixesConfigMapName, name); err != nil {
+			log.Error(err, "Forgetting artifacts prefix", "prefix", listPrefix+name)
+		}
+	}
+	r.orphans = orphans
+
+	return nil
+}
+
+// prefixOwners maps the prefixes directly under the base artifact URL to
+// the objects that store or reference artifacts in them.
+func (r *ArtifactsCollector) prefixOwners(ctx context.Context) (map[string][]string, error) {
+	base := r.Cloud.BaseArtifactURL()
+	owners := map[string][]string{}
+	add := func(u *cloud.BucketURL, owner string) {
+		if name := basePrefix(base, u); name != "" {
+			owners[name] = append(owners[name], owner)
+		}
+	}
+
+	// Every kind that stores objects under its ObjectArtifactURL.
+	lists := []client.ObjectList{
+		&apiv1beta1.ModelList{},
+		&apiv1beta1.DatasetList{},
+		&apiv1beta1.NotebookList{},
+		&apiv1beta1.ServerList{},
+		&apiv1beta1.EvaluationList{},
+		&apiv1beta1.BatchInferenceList{},
+	}
+	for _, list := range lists {
+		if err := r.Client.List(ctx, list); err != nil {
+			return nil, fmt.Errorf("listing: %w", err)
+		}
+		gvk, err := apiutil.GVKForObject(list, r.Client.Scheme())
+		if err != nil {
+			return nil, err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			obj := item.(client.Object)
+			obj.GetObjectKind().SetGroupVersionKind(gvk.GroupVersion().WithKind(strings.TrimSuffix(gvk.Kind, "List")))
+			owner := artifactsOwner(obj)
+			add(r.Cloud.ObjectArtifactURL(obj), owner)
+
+			var urls []string
+			if a, ok := obj.(cloud.ArtifactObject); ok {
+				urls = append(urls, a.GetStatusArtifacts().URL)
+			}
+			switch o := obj.(type) {
+			case *apiv1beta1.Model:
+				if o.Spec.Artifacts != nil {
+					urls = append(urls, o.Spec.Artifacts.URL)
+				}
+			case *apiv1beta1.Dataset:
+				if o.Spec.Artifacts != nil {
+					urls = append(urls, o.Spec.Artifacts.URL)
+				}
+			}
+			for _, s := range urls {
+				if s == "" {
+					continue
+				}
+				if u, err := cloud.ParseBucketURL(s); err == nil {
+					add(u, owner)
+				}
+			}
+		}
+	}
+
+	return owners, nil
+}
+
+// setRecord sets an entry of a ConfigMap of records (in Namespace).
+func (r *ArtifactsCollector) setRecord(ctx context.Context, configMap, name, owner string) error {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.Namespace,
+			Name:      configMap,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[name] = owner
+		return nil
+	})
+	return err
+}
+
+func (r *ArtifactsCollector) deleteRecord(ctx context.Context, configMap, name string) error {
+	var cm corev1.ConfigMap
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: r.Namespace, Name: configMap}, &cm); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if _, ok := cm.Data[name]; !ok {
+		return nil
+	}
+	delete(cm.Data, name)
+	return r.Client.Update(ctx, &cm)
+}
+
+// records returns the entries of a ConfigMap of records (prefix to owner).
+func (r *ArtifactsCollector) records(ctx context.Context, configMap string) (map[string]string, error) {
+	var cm corev1.ConfigMap
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: r.Namespace, Name: configMap}, &cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+	return cm.Data, nil
+}
+
+// artifactsOwner identifies an object, i.e. "models/<namespace>/<name>".
+func artifactsOwner(obj client.Object) string {
+	kind := strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind)
+	return fmt.Sprintf("%ss/%s/%s", kind, obj.GetNamespace(), obj.GetName())
+}
+
+// basePrefix returns the name of the prefix directly under base that u is
+// within, or "" if it is not within base.
+func basePrefix(base, u *cloud.BucketURL) string {
+	if u.Bucket != base.Bucket {
+		return ""
+	}
+	p := strings.Trim(path.Clean("/"+u.Path), "/")
+	if dir := strings.Trim(base.Path, "/"); dir != "" {
+		if !strings.HasPrefix(p, dir+"/") {
+			return ""
+		}
+		p = strings.TrimPrefix(p, dir+"/")
+	}
+	name, _, _ := strings.Cut(p, "/")
+	return name
+}
+
+// isObjectHash returns true if name looks like the md5 hash that
+// ObjectArtifactURL uses.
+func isObjectHash(name string) bool {
+	b, err := hex.DecodeString(name)
+	return err == nil && len(b) == 16
+}
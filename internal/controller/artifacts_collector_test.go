@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/substratusai/substratus/internal/cloud"
+)
+
+func Test_basePrefix(t *testing.T) {
+	const hash = "93ea94b18012ca14d84e1468d65e8709"
+
+	cases := []struct {
+		name string
+		base cloud.BucketURL
+		url  string
+		exp  string
+	}{
+		{
+			name: "bucket root",
+			base: cloud.BucketURL{Scheme: "gs", Bucket: "artifacts", Path: "/"},
+			url:  "gs://artifacts/" + hash,
+			exp:  hash,
+		},
+		{
+			name: "nested",
+			base: cloud.BucketURL{Scheme: "gs", Bucket: "artifacts", Path: "substratus"},
+			url:  "gs://artifacts/substratus/" + hash + "/v2",
+			exp:  hash,
+		},
+		{
+			name: "outside of base path",
+			base: cloud.BucketURL{Scheme: "gs", Bucket: "artifacts", Path: "substratus"},
+			url:  "gs://artifacts/other/" + hash,
+			exp:  "",
+		},
+		{
+			name: "other bucket",
+			base: cloud.BucketURL{Scheme: "gs", Bucket: "artifacts", Path: "/"},
+			url:  "gs://other/" + hash,
+			exp:  "",
+		},
+		{
+			name: "kind",
+			base: cloud.BucketURL{Scheme: "tar", Path: "/bucket"},
+			url:  "tar:////bucket/" + hash,
+			exp:  hash,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			u, err := cloud.ParseBucketURL(c.url)
+			require.NoError(t, err)
+			require.Equal(t, c.exp, basePrefix(&c.base, u))
+		})
+	}
+}
+
+func Test_isObjectHash(t *testing.T) {
+	require.True(t, isObjectHash("93ea94b18012ca14d84e1468d65e8709"))
+	require.False(t, isObjectHash("93ea94b18012ca14"))
+	require.False(t, isObjectHash("not-a-hash-not-a-hash-not-a-hash"))
+	require.False(t, isObjectHash(""))
+}
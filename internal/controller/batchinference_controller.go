@@ -0,0 +1,377 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+	"github.com/substratusai/substratus/internal/cloud"
+	"github.com/substratusai/substratus/internal/resources"
+	"github.com/substratusai/substratus/internal/sci"
+)
+
+// BatchInferenceReconciler reconciles a BatchInference object.
+type BatchInferenceReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	*ParamsReconciler
+
+	Cloud cloud.Cloud
+	SCI   sci.ControllerClient
+
+	// GPUCatalog describes how to schedule GPUs. If nil, the default
+	// catalog is used.
+	GPUCatalog *resources.GPUCatalog
+}
+
+func (r *BatchInferenceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	log.Info("Reconciling BatchInference")
+	defer log.Info("Done reconciling BatchInference")
+
+	var bi apiv1beta1.BatchInference
+	if err := r.Get(ctx, req.NamespacedName, &bi); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if bi.GetImage() == "" {
+		// Image must be building.
+		return ctrl.Result{}, nil
+	}
+
+	if result, err := r.ReconcileParamsConfigMap(ctx, &bi); !result.success {
+		return result.Result, err
+	}
+
+	if result, err := r.reconcileBatchInference(ctx, &bi); !result.success {
+		return result.Result, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+//+kubebuilder:rbac:groups=substratus.ai,resources=batchinferences,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=substratus.ai,resources=batchinferences/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=substratus.ai,resources=batchinferences/finalizers,verbs=update
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BatchInferenceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&apiv1beta1.BatchInference{}).
+		Watches(&apiv1beta1.Model{}, handler.EnqueueRequestsFromMapFunc(handler.MapFunc(r.findBatchInferencesForModel))).
+		Watches(&apiv1beta1.Dataset{}, handler.EnqueueRequestsFromMapFunc(handler.MapFunc(r.findBatchInferencesForDataset))).
+		Watches(&apiv1beta1.BatchInference{}, handler.EnqueueRequestsFromMapFunc(handler.MapFunc(r.findBatchInferencesForDataset))).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
+
+func (r *BatchInferenceReconciler) findBatchInferencesForModel(ctx context.Context, obj client.Object) []reconcile.Request {
+	var bis apiv1beta1.BatchInferenceList
+	if err := r.List(ctx, &bis,
+		client.MatchingFields{batchInferenceModelIndex: obj.GetName()},
+		client.InNamespace(obj.GetNamespace()),
+	); err != nil {
+		log.Log.Error(err, "unable to list batch inferences for model")
+		return nil
+	}
+	return batchInferenceRequests(bis)
+}
+
+func (r *BatchInferenceReconciler) findBatchInferencesForDataset(ctx context.Context, obj client.Object) []reconcile.Request {
+	var bis apiv1beta1.BatchInferenceList
+	if err := r.List(ctx, &bis,
+		client.MatchingFields{batchInferenceDatasetIndex: obj.GetName()},
+		client.InNamespace(obj.GetNamespace()),
+	); err != nil {
+		log.Log.Error(err, "unable to list batch inferences for dataset")
+		return nil
+	}
+	return batchInferenceRequests(bis)
+}
+
+func batchInferenceRequests(bis apiv1beta1.BatchInferenceList) []reconcile.Request {
+	reqs := []reconcile.Request{}
+	for _, bi := range bis.Items {
+		reqs = append(reqs, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      bi.Name,
+				Namespace: bi.Namespace,
+			},
+		})
+	}
+	return reqs
+}
+
+func (r *BatchInferenceReconciler) reconcileBatchInference(ctx context.Context, bi *apiv1beta1.BatchInference) (result, error) {
+	log := log.FromContext(ctx)
+
+	if bi.Status.Ready {
+		return result{success: true}, nil
+	}
+
+	bi.Status.Artifacts.URL = r.Cloud.ObjectArtifactURL(bi).String()
+
+	// ServiceAccount for the inference Job.
+	// Within the context of GCP, this ServiceAccount will need IAM permissions
+	// to read the GCS bucket containing the Model and Dataset and write to
+	// the bucket that contains the inference outputs.
+	if result, err := reconcileServiceAccount(ctx, r.Cloud, r.SCI, r.Client, &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      batchInferenceServiceAccountName,
+			Namespace: bi.Namespace,
+		},
+	}); !result.success {
+		return result, err
+	}
+
+	notReady := func(reason string) (result, error) {
+		bi.Status.Ready = false
+		meta.SetStatusCondition(&bi.Status.Conditions, metav1.Condition{
+			Type:               apiv1beta1.ConditionComplete,
+			Status:             metav1.ConditionFalse,
+			Reason:             reason,
+			ObservedGeneration: bi.Generation,
+		})
+		if err := r.Status().Update(ctx, bi); err != nil {
+			return result{}, fmt.Errorf("failed to update batch inference status: %w", err)
+		}
+
+		// Allow for watch to requeue.
+		return result{}, nil
+	}
+
+	var model apiv1beta1.Model
+	if err := r.Get(ctx, types.NamespacedName{Namespace: bi.Namespace, Name: bi.Spec.Model.Name}, &model); err != nil {
+		if apierrors.IsNotFound(err) {
+			return notReady(apiv1beta1.ReasonModelNotFound)
+		}
+		return result{}, fmt.Errorf("getting model: %w", err)
+	}
+	if !model.Status.Ready {
+		return notReady(apiv1beta1.ReasonModelNotReady)
+	}
+
+	if msg, err := invalidDatasetMounts(ctx, r.Client, bi, nil); err != nil {
+		return result{}, err
+	} else if msg != "" {
+		log.Info("Invalid dataset mounts", "reason", msg)
+		bi.Status.Ready = false
+		meta.SetStatusCondition(&bi.Status.Conditions, metav1.Condition{
+			Type:               apiv1beta1.ConditionComplete,
+			Status:             metav1.ConditionFalse,
+			Reason:             apiv1beta1.ReasonDatasetMountInvalid,
+			Message:            msg,
+			ObservedGeneration: bi.Generation,
+		})
+		if err := r.Status().Update(ctx, bi); err != nil {
+			return result{}, fmt.Errorf("failed to update batch inference status: %w", err)
+		}
+
+		// No use in retrying...
+		return result{}, nil
+	}
+
+	dataset, err := getDatasetObject(ctx, r.Client, bi.Namespace, bi.Spec.Dataset)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return notReady(apiv1beta1.ReasonDatasetNotFound)
+		}
+		if errors.Is(err, errUnsupportedDatasetKind) {
+			log.Error(err, "Unable to use dataset")
+			return notReady(apiv1beta1.ReasonDatasetKindUnsupported)
+		}
+		return result{}, fmt.Errorf("getting dataset: %w", err)
+	}
+	if !dataset.GetStatusReady() {
+		return notReady(apiv1beta1.ReasonDatasetNotReady)
+	}
+
+	job, err := r.inferenceJob(bi, &model, dataset)
+	if err != nil {
+		log.Error(err, "unable to construct batch inference Job")
+		// No use in retrying...
+		return result{}, nil
+	}
+
+	if err := r.Status().Update(ctx, bi); err != nil {
+		return result{}, fmt.Errorf("updating status: %w", err)
+	}
+
+	if jobFailedBeforeCleanup(bi.Spec.RunPolicy, bi.Status.Conditions, bi.Generation) {
+		return result{failure: true}, nil
+	}
+
+	jobResult, err := reconcileJob(ctx, r.Client, job)
+	if !jobResult.success {
+		bi.Status.Ready = false
+		if !jobResult.failure {
+			meta.SetStatusCondition(bi.GetConditions(), metav1.Condition{
+				Type:               apiv1beta1.ConditionComplete,
+				Status:             metav1.ConditionFalse,
+				Reason:             apiv1beta1.ReasonJobNotComplete,
+				ObservedGeneration: bi.Generation,
+				Message:            "Waiting for batch inference Job to complete",
+			})
+		} else {
+			meta.SetStatusCondition(bi.GetConditions(), metav1.Condition{
+				Type:               apiv1beta1.ConditionComplete,
+				Status:             metav1.ConditionFalse,
+				Reason:             apiv1beta1.ReasonJobFailed,
+				ObservedGeneration: bi.Generation,
+			})
+		}
+		if err := r.Status().Update(ctx, bi); err != nil {
+			return result{}, fmt.Errorf("updating status: %w", err)
+		}
+		return jobResult, err
+	}
+
+	bi.Status.Ready = true
+	meta.SetStatusCondition(bi.GetConditions(), metav1.Condition{
+		Type:               apiv1beta1.ConditionComplete,
+		Status:             metav1.ConditionTrue,
+		Reason:             apiv1beta1.ReasonJobComplete,
+		ObservedGeneration: bi.Generation,
+	})
+	if err := r.Status().Update(ctx, bi); err != nil {
+		return result{}, fmt.Errorf("updating status: %w", err)
+	}
+
+	return result{success: true}, nil
+}
+
+func (r *BatchInferenceReconciler) inferenceJob(bi *apiv1beta1.BatchInference, model *apiv1beta1.Model, dataset datasetObject) (*batchv1.Job, error) {
+	const containerName = "infer"
+	envVars, err := resolveEnv(bi.Spec.Env)
+	if err != nil {
+		return nil, fmt.Errorf("resolving env: %w", err)
+	}
+
+	shards := bi.GetShards()
+	parallelism := shards
+	if bi.Spec.Parallelism != nil && *bi.Spec.Parallelism < shards {
+		parallelism = *bi.Spec.Parallelism
+	}
+	envVars = append(envVars,
+		corev1.EnvVar{
+			Name: "SHARD_INDEX",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: fmt.Sprintf("metadata.annotations['%v']", batchv1.JobCompletionIndexAnnotation),
+				},
+			},
+		},
+		corev1.EnvVar{Name: "SHARD_COUNT", Value: fmt.Sprint(shards)},
+	)
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: bi.Name + "-inference",
+			// Cross-Namespace owners not allowed, must be same as batch inference:
+			Namespace: bi.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:   ptr.To(int32(0)),
+			CompletionMode: ptr.To(batchv1.IndexedCompletion),
+			Completions:    ptr.To(shards),
+			Parallelism:    ptr.To(parallelism),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"kubectl.kubernetes.io/default-container": containerName,
+					},
+					Labels: map[string]string{
+						"batchinference": bi.Name,
+						"role":           "run",
+					},
+				},
+				Spec: corev1.PodSpec{
+					SecurityContext: &corev1.PodSecurityContext{
+						FSGroup: ptr.To(int64(3003)),
+					},
+					ServiceAccountName: batchInferenceServiceAccountName,
+					Containers: []corev1.Container{
+						{
+							Name:    containerName,
+							Image:   bi.GetImage(),
+							Command: bi.Spec.Command,
+							Env:     envVars,
+						},
+					},
+					RestartPolicy: "Never",
+				},
+			},
+		},
+	}
+
+	applyRunPolicy(job, bi.Spec.RunPolicy)
+
+	if err := mountParamsConfigMap(&job.Spec.Template.Spec, bi, containerName); err != nil {
+		return nil, fmt.Errorf("mounting params configmap: %w", err)
+	}
+
+	if err := r.Cloud.MountBucket(&job.Spec.Template.ObjectMeta, &job.Spec.Template.Spec, bi, cloud.MountBucketConfig{
+		Name: "artifacts",
+		Mounts: []cloud.BucketMount{
+			{BucketSubdir: "artifacts", ContentSubdir: "artifacts"},
+		},
+		Container: containerName,
+		ReadOnly:  false,
+	}); err != nil {
+		return nil, fmt.Errorf("mounting artifacts: %w", err)
+	}
+
+	if err := r.Cloud.MountBucket(&job.Spec.Template.ObjectMeta, &job.Spec.Template.Spec, model, cloud.MountBucketConfig{
+		Name: "model",
+		Mounts: []cloud.BucketMount{
+			{BucketSubdir: "artifacts", ContentSubdir: "model"},
+		},
+		Container: containerName,
+		ReadOnly:  true,
+	}); err != nil {
+		return nil, fmt.Errorf("mounting model: %w", err)
+	}
+
+	if err := r.Cloud.MountBucket(&job.Spec.Template.ObjectMeta, &job.Spec.Template.Spec, dataset, cloud.MountBucketConfig{
+		Name: "dataset",
+		Mounts: []cloud.BucketMount{
+			{BucketSubdir: "artifacts", ContentSubdir: "data"},
+		},
+		Container: containerName,
+		ReadOnly:  true,
+	}); err != nil {
+		return nil, fmt.Errorf("mounting dataset: %w", err)
+	}
+
+	if err := controllerutil.SetControllerReference(bi, job, r.Scheme); err != nil {
+		return nil, fmt.Errorf("setting owner reference: %w", err)
+	}
+
+	if err := resources.Apply(&job.Spec.Template.ObjectMeta, &job.Spec.Template.Spec, containerName,
+		r.Cloud.Name(), r.GPUCatalog, bi.Spec.Resources, bi.Spec.Scheduling); err != nil {
+		return nil, fmt.Errorf("applying resources: %w", err)
+	}
+
+	return job, nil
+}
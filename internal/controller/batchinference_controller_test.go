@@ -0,0 +1,100 @@
+package controller_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+)
+
+func TestBatchInference(t *testing.T) {
+	name := strings.ToLower(t.Name())
+
+	model := &apiv1beta1.Model{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-mdl",
+			Namespace: "default",
+		},
+		Spec: apiv1beta1.ModelSpec{
+			Image: ptr.To("some-model-image"),
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, model), "create a model to run inference with")
+	t.Cleanup(debugObject(t, model))
+	testModelLoad(t, model)
+
+	dataset := &apiv1beta1.Dataset{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-ds",
+			Namespace: "default",
+		},
+		Spec: apiv1beta1.DatasetSpec{
+			Image: ptr.To("some-dataset-image"),
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, dataset), "create a dataset to run inference over")
+	t.Cleanup(debugObject(t, dataset))
+	testDatasetLoad(t, dataset)
+
+	bi := &apiv1beta1.BatchInference{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-bi",
+			Namespace: "default",
+		},
+		Spec: apiv1beta1.BatchInferenceSpec{
+			Image:   ptr.To("some-inference-image"),
+			Model:   apiv1beta1.ObjectRef{Name: model.Name},
+			Dataset: apiv1beta1.DatasetRef{Name: dataset.Name},
+			Shards:  ptr.To[int32](4),
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, bi), "create a batch inference")
+	t.Cleanup(debugObject(t, bi))
+
+	var job batchv1.Job
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: bi.Namespace, Name: bi.Name + "-inference"}, &job)
+		assert.NoError(t, err, "getting the batch inference job")
+	}, timeout, interval, "waiting for the batch inference job to be created")
+	require.Equal(t, "infer", job.Spec.Template.Spec.Containers[0].Name)
+	require.Equal(t, batchv1.IndexedCompletion, *job.Spec.CompletionMode)
+	require.Equal(t, int32(4), *job.Spec.Completions)
+
+	fakeJobComplete(t, &job)
+
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(bi), bi)
+		assert.NoError(t, err, "getting the batch inference")
+		assert.True(t, meta.IsStatusConditionTrue(bi.Status.Conditions, apiv1beta1.ConditionComplete))
+		assert.True(t, bi.Status.Ready)
+	}, timeout, interval, "waiting for the batch inference to be ready")
+
+	// The outputs of the batch inference can be used as a Dataset.
+	trainedModel := &apiv1beta1.Model{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-trained",
+			Namespace: "default",
+		},
+		Spec: apiv1beta1.ModelSpec{
+			Image:   ptr.To("some-model-image"),
+			Dataset: &apiv1beta1.DatasetRef{Kind: "BatchInference", Name: bi.Name},
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, trainedModel), "create a model trained on the batch inference outputs")
+	t.Cleanup(debugObject(t, trainedModel))
+
+	var modellerJob batchv1.Job
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: trainedModel.Namespace, Name: trainedModel.Name + "-modeller"}, &modellerJob)
+		assert.NoError(t, err, "getting the model training job")
+	}, timeout, interval, "waiting for the model training job to be created")
+}
@@ -20,7 +20,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
-	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 	"github.com/substratusai/substratus/internal/cloud"
 	"github.com/substratusai/substratus/internal/resources"
 	"github.com/substratusai/substratus/internal/sci"
@@ -31,14 +31,14 @@ const latestUploadPath = "uploads/latest.tar.gz"
 type BuildableObject interface {
 	client.Object
 
-	GetBuild() *apiv1.Build
+	GetBuild() *apiv1beta1.Build
 	GetImage() string
 	SetImage(string)
 
 	GetConditions() *[]metav1.Condition
 	SetStatusReady(bool)
-	GetStatusUpload() apiv1.UploadStatus
-	SetStatusUpload(apiv1.UploadStatus)
+	GetStatusUpload() apiv1beta1.UploadStatus
+	SetStatusUpload(apiv1beta1.UploadStatus)
 }
 
 //+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
@@ -140,9 +140,9 @@ func (r *BuildReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 
 		obj.SetStatusReady(false)
 		meta.SetStatusCondition(obj.GetConditions(), metav1.Condition{
-			Type:               apiv1.ConditionBuilt,
+			Type:               apiv1beta1.ConditionBuilt,
 			Status:             metav1.ConditionFalse,
-			Reason:             apiv1.ReasonJobNotComplete,
+			Reason:             apiv1beta1.ReasonJobNotComplete,
 			ObservedGeneration: obj.GetGeneration(),
 			Message:            fmt.Sprintf("Waiting for builder Job to complete: %v", buildJob.Name),
 		})
@@ -160,9 +160,9 @@ func (r *BuildReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	}
 
 	meta.SetStatusCondition(obj.GetConditions(), metav1.Condition{
-		Type:               apiv1.ConditionBuilt,
+		Type:               apiv1beta1.ConditionBuilt,
 		Status:             metav1.ConditionTrue,
-		Reason:             apiv1.ReasonJobComplete,
+		Reason:             apiv1beta1.ReasonJobComplete,
 		ObservedGeneration: obj.GetGeneration(),
 		Message:            fmt.Sprintf("Builder Job completed: %v", buildJob.Name),
 	})
@@ -189,17 +189,17 @@ func (r *BuildReconciler) reconcileUploadFile(ctx context.Context, obj Buildable
 	if spec.RequestID != status.RequestID {
 		// Account for the edge-case where an uploaded file matching the checksum
 		// already exists in storage.
-		// For example: This can happen if a Notebook is deleted and recreated
-		// but the underlying storage was not cleared.
+		// For example: This can happen if a Notebook is deleted (with its
+		// artifacts retained) and recreated.
 		existingUploadChecksum, _ := r.storageObjectMd5(obj, r.SCI)
 		if existingUploadChecksum == spec.MD5Checksum {
-			obj.SetStatusUpload(apiv1.UploadStatus{
+			obj.SetStatusUpload(apiv1beta1.UploadStatus{
 				StoredMD5Checksum: spec.MD5Checksum,
 			})
 			meta.SetStatusCondition(obj.GetConditions(), metav1.Condition{
-				Type:               apiv1.ConditionUploaded,
+				Type:               apiv1beta1.ConditionUploaded,
 				Status:             metav1.ConditionTrue,
-				Reason:             apiv1.ReasonUploadFound,
+				Reason:             apiv1beta1.ReasonUploadFound,
 				ObservedGeneration: obj.GetGeneration(),
 				Message:            fmt.Sprintf("Existing upload found in storage with specified checksum: %s", spec.MD5Checksum),
 			})
@@ -215,15 +215,15 @@ func (r *BuildReconciler) reconcileUploadFile(ctx context.Context, obj Buildable
 			return result{}, fmt.Errorf("generating upload url for object %v: %w", objectURL, err)
 		}
 
-		obj.SetStatusUpload(apiv1.UploadStatus{
+		obj.SetStatusUpload(apiv1beta1.UploadStatus{
 			SignedURL:  url,
 			RequestID:  spec.RequestID,
 			Expiration: metav1.Time{Time: expiration},
 		})
 		meta.SetStatusCondition(obj.GetConditions(), metav1.Condition{
-			Type:               apiv1.ConditionUploaded,
+			Type:               apiv1beta1.ConditionUploaded,
 			Status:             metav1.ConditionFalse,
-			Reason:             apiv1.ReasonAwaitingUpload,
+			Reason:             apiv1beta1.ReasonAwaitingUpload,
 			ObservedGeneration: obj.GetGeneration(),
 			Message:            fmt.Sprintf("Waiting for upload with md5 checksum: %s", spec.MD5Checksum),
 		})
@@ -247,16 +247,16 @@ func (r *BuildReconciler) reconcileUploadFile(ctx context.Context, obj Buildable
 		return result{}, nil
 	}
 
-	obj.SetStatusUpload(apiv1.UploadStatus{
+	obj.SetStatusUpload(apiv1beta1.UploadStatus{
 		SignedURL:         "",
 		RequestID:         spec.RequestID,
 		Expiration:        metav1.Time{},
 		StoredMD5Checksum: storageMD5,
 	})
 	meta.SetStatusCondition(obj.GetConditions(), metav1.Condition{
-		Type:               apiv1.ConditionUploaded,
+		Type:               apiv1beta1.ConditionUploaded,
 		Status:             metav1.ConditionTrue,
-		Reason:             apiv1.ReasonUploadFound,
+		Reason:             apiv1beta1.ReasonUploadFound,
 		ObservedGeneration: obj.GetGeneration(),
 		Message:            fmt.Sprintf("Upload received with matching md5 checksum: %s", spec.MD5Checksum),
 	})
@@ -466,6 +466,20 @@ func (r *BuildReconciler) storageBuildJob(ctx context.Context, obj BuildableObje
 			},
 		})
 	}
+	if pvc, ok := r.Cloud.(*cloud.PVC); ok {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "bucket",
+			MountPath: cloud.PVCMountPath,
+		})
+		volumes = append(volumes, pvc.BucketVolume("bucket"))
+	}
+
+	// Kaniko reads "s3://" contexts using the AWS SDK which needs to be
+	// pointed at the S3-compatible endpoint.
+	var env []corev1.EnvVar
+	if s3, ok := r.Cloud.(*cloud.S3); ok {
+		env = append(env, s3.BuilderEnv()...)
+	}
 
 	// The GKE metadata server needs a few seconds before it can accept requests
 	// Kaniko will fail during checking push permissions without this hack
@@ -515,6 +529,7 @@ func (r *BuildReconciler) storageBuildJob(ctx context.Context, obj BuildableObje
 						Name:         builderContainerName,
 						Image:        "gcr.io/kaniko-project/executor:latest",
 						Args:         buildArgs,
+						Env:          env,
 						VolumeMounts: volumeMounts,
 						Resources:    resources.ContainerBuilderResources(r.Cloud.Name()),
 					}},
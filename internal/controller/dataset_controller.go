@@ -3,24 +3,36 @@ package controller
 import (
 	"context"
 	"fmt"
+	"path"
+	"strings"
+	"time"
 
+	"github.com/robfig/cron/v3"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 	"github.com/substratusai/substratus/internal/cloud"
 	"github.com/substratusai/substratus/internal/resources"
 	"github.com/substratusai/substratus/internal/sci"
 )
 
+// retainedDatasetVersions is the number of loaded versions of a Dataset that
+// are kept in the bucket: the current one and the previous one, which
+// consumers that started before the last refresh might still read.
+const retainedDatasetVersions = 2
+
 // DatasetReconciler reconciles a Dataset object.
 type DatasetReconciler struct {
 	client.Client
@@ -30,6 +42,22 @@ type DatasetReconciler struct {
 
 	Cloud cloud.Cloud
 	SCI   sci.ControllerClient
+
+	// GPUCatalog describes how to schedule GPUs. If nil, the default
+	// catalog is used.
+	GPUCatalog *resources.GPUCatalog
+
+	// ImporterImage is used for Datasets that are imported using the
+	// built-in importer (see cmd/importer).
+	ImporterImage string
+
+	// ArtifactsManifests enables a Job (running the ImporterImage) that
+	// writes a manifest of sha256 checksums next to the artifacts of ready
+	// Datasets.
+	ArtifactsManifests bool
+
+	// Artifacts deletes the artifacts of deleted Datasets.
+	Artifacts *ArtifactsCollector
 }
 
 func (r *DatasetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -38,12 +66,29 @@ func (r *DatasetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	log.Info("Reconciling Dataset")
 	defer log.Info("Done reconciling Dataset")
 
-	var dataset apiv1.Dataset
+	var dataset apiv1beta1.Dataset
 	if err := r.Get(ctx, req.NamespacedName, &dataset); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	if dataset.GetImage() == "" {
+	if result, err := reconcileDeletionProtection(ctx, r.Client, &dataset); !result.success {
+		return result.Result, err
+	}
+	if result, err := r.Artifacts.ReconcileArtifactsFinalizer(ctx, &dataset); !result.success {
+		return result.Result, err
+	}
+
+	if dataset.Spec.Artifacts != nil {
+		if result, err := reconcileAdoptedArtifacts(ctx, r.Client, r.SCI, &dataset, dataset.Spec.Artifacts.URL); !result.success {
+			return result.Result, err
+		}
+		if result, err := reconcileDownload(ctx, r.Client, r.SCI, &dataset); !result.success {
+			return result.Result, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if dataset.Spec.Import == nil && dataset.GetImage() == "" {
 		// Image must be building.
 		return ctrl.Result{}, nil
 	}
@@ -69,16 +114,52 @@ func (r *DatasetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 // SetupWithManager sets up the controller with the Manager.
 func (r *DatasetReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&apiv1.Dataset{}).
+		For(&apiv1beta1.Dataset{}).
+		Watches(&apiv1beta1.Dataset{}, handler.EnqueueRequestsFromMapFunc(handler.MapFunc(r.findDatasetsForDataset))).
+		Watches(&apiv1beta1.BatchInference{}, handler.EnqueueRequestsFromMapFunc(handler.MapFunc(r.findDatasetsForDataset))).
 		Owns(&batchv1.Job{}).
 		Complete(r)
 }
 
-func (r *DatasetReconciler) reconcileData(ctx context.Context, dataset *apiv1.Dataset) (result, error) {
+func (r *DatasetReconciler) findDatasetsForDataset(ctx context.Context, obj client.Object) []reconcile.Request {
+	var datasets apiv1beta1.DatasetList
+	if err := r.List(ctx, &datasets,
+		client.MatchingFields{datasetDatasetIndex: obj.GetName()},
+		client.InNamespace(obj.GetNamespace()),
+	); err != nil {
+		log.Log.Error(err, "unable to list datasets for input dataset")
+		return nil
+	}
+
+	reqs := []reconcile.Request{}
+	for _, ds := range datasets.Items {
+		reqs = append(reqs, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      ds.Name,
+				Namespace: ds.Namespace,
+			},
+		})
+	}
+	return reqs
+}
+
+func (r *DatasetReconciler) reconcileData(ctx context.Context, dataset *apiv1beta1.Dataset) (result, error) {
 	log := log.FromContext(ctx)
 
 	if dataset.Status.Ready {
-		return result{success: true}, nil
+		if result, err := reconcileInventory(ctx, r.Client, r.Scheme, r.Cloud, r.SCI, dataset, inventory{
+			kind:               "dataset",
+			jobName:            dataLoaderJobName(dataset, dataset.Status.Version) + "-inventory",
+			serviceAccountName: dataLoaderServiceAccountName,
+			image:              r.ImporterImage,
+			manifest:           r.ArtifactsManifests,
+		}); !result.success {
+			return result, err
+		}
+		if result, err := reconcileDownload(ctx, r.Client, r.SCI, dataset); !result.success {
+			return result, err
+		}
+		return r.reconcileRefresh(ctx, dataset)
 	}
 
 	dataset.Status.Artifacts.URL = r.Cloud.ObjectArtifactURL(dataset).String()
@@ -95,8 +176,48 @@ func (r *DatasetReconciler) reconcileData(ctx context.Context, dataset *apiv1.Da
 		return result, err
 	}
 
+	if msg, err := invalidDatasetMounts(ctx, r.Client, dataset, dataset.Spec.Datasets); err != nil {
+		return result{}, err
+	} else if msg != "" {
+		log.Info("Invalid dataset mounts", "reason", msg)
+		dataset.Status.Ready = false
+		meta.SetStatusCondition(dataset.GetConditions(), metav1.Condition{
+			Type:               apiv1beta1.ConditionComplete,
+			Status:             metav1.ConditionFalse,
+			Reason:             apiv1beta1.ReasonDatasetMountInvalid,
+			Message:            msg,
+			ObservedGeneration: dataset.Generation,
+		})
+		if err := r.Status().Update(ctx, dataset); err != nil {
+			return result{}, fmt.Errorf("failed to update dataset status: %w", err)
+		}
+
+		// No use in retrying...
+		return result{}, nil
+	}
+
+	inputs, reason, err := getDatasetInputs(ctx, r.Client, dataset.Namespace, dataset.Spec.Datasets)
+	if err != nil {
+		return result{}, err
+	}
+	if reason != "" {
+		dataset.Status.Ready = false
+		meta.SetStatusCondition(dataset.GetConditions(), metav1.Condition{
+			Type:               apiv1beta1.ConditionComplete,
+			Status:             metav1.ConditionFalse,
+			Reason:             reason,
+			ObservedGeneration: dataset.Generation,
+		})
+		if err := r.Status().Update(ctx, dataset); err != nil {
+			return result{}, fmt.Errorf("failed to update dataset status: %w", err)
+		}
+
+		// Allow for watch to requeue.
+		return result{}, nil
+	}
+
 	// Job that will run the data-loader image that was built by the previous Job.
-	loadJob, err := r.loadJob(ctx, dataset)
+	loadJob, err := r.loadJob(ctx, dataset, 1, inputs)
 	if err != nil {
 		log.Error(err, "unable to construct data-loader Job")
 		// No use in retrying...
@@ -107,22 +228,26 @@ func (r *DatasetReconciler) reconcileData(ctx context.Context, dataset *apiv1.Da
 		return result{}, fmt.Errorf("updating status: %w", err)
 	}
 
+	if jobFailedBeforeCleanup(dataset.Spec.RunPolicy, dataset.Status.Conditions, dataset.Generation) {
+		return result{failure: true}, nil
+	}
+
 	jobResult, err := reconcileJob(ctx, r.Client, loadJob)
 	if !jobResult.success {
 		dataset.Status.Ready = false
 		if !jobResult.failure {
 			meta.SetStatusCondition(dataset.GetConditions(), metav1.Condition{
-				Type:               apiv1.ConditionComplete,
+				Type:               apiv1beta1.ConditionComplete,
 				Status:             metav1.ConditionFalse,
-				Reason:             apiv1.ReasonJobNotComplete,
+				Reason:             apiv1beta1.ReasonJobNotComplete,
 				ObservedGeneration: dataset.Generation,
 				Message:            "Waiting for data loader Job to complete",
 			})
 		} else {
 			meta.SetStatusCondition(dataset.GetConditions(), metav1.Condition{
-				Type:               apiv1.ConditionComplete,
+				Type:               apiv1beta1.ConditionComplete,
 				Status:             metav1.ConditionFalse,
-				Reason:             apiv1.ReasonJobFailed,
+				Reason:             apiv1beta1.ReasonJobFailed,
 				ObservedGeneration: dataset.Generation,
 			})
 		}
@@ -133,10 +258,15 @@ func (r *DatasetReconciler) reconcileData(ctx context.Context, dataset *apiv1.Da
 	}
 
 	dataset.Status.Ready = true
+	dataset.Status.Version = 1
+	dataset.Status.LastRefreshTime = ptr.To(metav1.Now())
+	// A refresh annotation that was set before the first load does not
+	// trigger a refresh.
+	dataset.Status.RefreshRequest = dataset.Annotations[apiv1beta1.RefreshAnnotation]
 	meta.SetStatusCondition(dataset.GetConditions(), metav1.Condition{
-		Type:               apiv1.ConditionComplete,
+		Type:               apiv1beta1.ConditionComplete,
 		Status:             metav1.ConditionTrue,
-		Reason:             apiv1.ReasonJobComplete,
+		Reason:             apiv1beta1.ReasonJobComplete,
 		ObservedGeneration: dataset.Generation,
 	})
 	if err := r.Status().Update(ctx, dataset); err != nil {
@@ -146,7 +276,193 @@ func (r *DatasetReconciler) reconcileData(ctx context.Context, dataset *apiv1.Da
 	return result{success: true}, nil
 }
 
-func (r *DatasetReconciler) loadJob(ctx context.Context, dataset *apiv1.Dataset) (*batchv1.Job, error) {
+// reconcileRefresh loads a new version of the artifacts of a ready Dataset
+// when a refresh is due. Consumers keep using the current version until the
+// new one is loaded.
+func (r *DatasetReconciler) reconcileRefresh(ctx context.Context, dataset *apiv1beta1.Dataset) (result, error) {
+	log := log.FromContext(ctx)
+
+	setRefreshed := func(status metav1.ConditionStatus, reason, message string) {
+		meta.SetStatusCondition(dataset.GetConditions(), metav1.Condition{
+			Type:               apiv1beta1.ConditionRefreshed,
+			Status:             status,
+			Reason:             reason,
+			ObservedGeneration: dataset.Generation,
+			Message:            message,
+		})
+	}
+
+	if dataset.Status.Refresh == nil {
+		due, next, err := refreshDue(dataset, time.Now())
+		if err != nil {
+			setRefreshed(metav1.ConditionFalse, apiv1beta1.ReasonInvalidSchedule, err.Error())
+			if err := r.Status().Update(ctx, dataset); err != nil {
+				return result{}, fmt.Errorf("updating status: %w", err)
+			}
+			// No use in retrying...
+			return result{}, nil
+		}
+		if !due {
+			if next > 0 {
+				return result{Result: ctrl.Result{RequeueAfter: next}}, nil
+			}
+			return result{success: true}, nil
+		}
+
+		version := dataset.Status.Version + 1
+		u := r.Cloud.ObjectArtifactURL(dataset)
+		u.Path = path.Join(u.Path, fmt.Sprintf("v%d", version))
+		log.Info("Refreshing dataset", "version", version, "url", u.String())
+
+		dataset.Status.Refresh = &apiv1beta1.DatasetRefreshStatus{
+			Version: version,
+			URL:     u.String(),
+		}
+		dataset.Status.RefreshRequest = dataset.Annotations[apiv1beta1.RefreshAnnotation]
+		dataset.Status.LastRefreshTime = ptr.To(metav1.Now())
+		setRefreshed(metav1.ConditionFalse, apiv1beta1.ReasonJobNotComplete, "Waiting for data loader Job to complete")
+		if err := r.Status().Update(ctx, dataset); err != nil {
+			return result{}, fmt.Errorf("updating status: %w", err)
+		}
+	}
+
+	if msg, err := invalidDatasetMounts(ctx, r.Client, dataset, dataset.Spec.Datasets); err != nil {
+		return result{}, err
+	} else if msg != "" {
+		setRefreshed(metav1.ConditionFalse, apiv1beta1.ReasonDatasetMountInvalid, msg)
+		if err := r.Status().Update(ctx, dataset); err != nil {
+			return result{}, fmt.Errorf("updating status: %w", err)
+		}
+
+		// No use in retrying...
+		return result{}, nil
+	}
+
+	inputs, reason, err := getDatasetInputs(ctx, r.Client, dataset.Namespace, dataset.Spec.Datasets)
+	if err != nil {
+		return result{}, err
+	}
+	if reason != "" {
+		setRefreshed(metav1.ConditionFalse, reason, "")
+		if err := r.Status().Update(ctx, dataset); err != nil {
+			return result{}, fmt.Errorf("updating status: %w", err)
+		}
+
+		// Allow for watch to requeue.
+		return result{}, nil
+	}
+
+	// Load into the new version while the current one stays in the status.
+	target := dataset.DeepCopy()
+	target.Status.Artifacts.URL = dataset.Status.Refresh.URL
+	loadJob, err := r.loadJob(ctx, target, dataset.Status.Refresh.Version, inputs)
+	if err != nil {
+		log.Error(err, "unable to construct data-loader Job")
+		// No use in retrying...
+		return result{}, nil
+	}
+
+	jobResult, err := reconcileJob(ctx, r.Client, loadJob)
+	if !jobResult.success {
+		if jobResult.failure {
+			// Give up on this version, the next refresh will try again.
+			// The failed Job is deleted because the next attempt loads the
+			// same version (and therefore uses the same Job name).
+			log.Info("Dataset refresh failed", "version", dataset.Status.Refresh.Version)
+			if err := r.Delete(ctx, loadJob, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+				return result{}, fmt.Errorf("deleting failed Job: %w", err)
+			}
+			dataset.Status.Refresh = nil
+			setRefreshed(metav1.ConditionFalse, apiv1beta1.ReasonJobFailed, "")
+			if err := r.Status().Update(ctx, dataset); err != nil {
+				return result{}, fmt.Errorf("updating status: %w", err)
+			}
+		}
+		return jobResult, err
+	}
+
+	if err := r.deleteDatasetVersion(ctx, dataset, dataset.Status.Refresh.Version-retainedDatasetVersions); err != nil {
+		return result{}, err
+	}
+
+	dataset.Status.Version = dataset.Status.Refresh.Version
+	// The inventory of the new version is recorded on the next reconcile.
+	dataset.Status.Artifacts = apiv1beta1.ArtifactsStatus{URL: dataset.Status.Refresh.URL}
+	meta.RemoveStatusCondition(&dataset.Status.Conditions, apiv1beta1.ConditionInventoried)
+	dataset.Status.Refresh = nil
+	setRefreshed(metav1.ConditionTrue, apiv1beta1.ReasonJobComplete, "")
+	if err := r.Status().Update(ctx, dataset); err != nil {
+		return result{}, fmt.Errorf("updating status: %w", err)
+	}
+
+	return result{success: true}, nil
+}
+
+// deleteDatasetVersion deletes the artifacts that were loaded into an old
+// version of a Dataset. Versions that were never loaded are ignored.
+func (r *DatasetReconciler) deleteDatasetVersion(ctx context.Context, dataset *apiv1beta1.Dataset, version int32) error {
+	log := log.FromContext(ctx)
+
+	if version < 1 {
+		return nil
+	}
+
+	u := r.Cloud.ObjectArtifactURL(dataset)
+	dir := strings.TrimPrefix(u.Path, "/")
+	req := &sci.DeleteObjectsRequest{BucketName: u.Bucket}
+	if version == 1 {
+		// The first version is loaded into the root of the prefix, next to
+		// the uploads and the later versions.
+		req.Prefix = path.Join(dir, "artifacts") + "/"
+		req.ObjectNames = []string{path.Join(dir, manifestFile)}
+	} else {
+		req.Prefix = path.Join(dir, fmt.Sprintf("v%d", version)) + "/"
+	}
+	resp, err := r.SCI.DeleteObjects(ctx, req)
+	if err != nil {
+		return fmt.Errorf("calling the sci service to DeleteObjects: %w", err)
+	}
+	log.Info("Deleted old dataset version", "version", version, "count", resp.DeletedCount)
+
+	return nil
+}
+
+// refreshDue returns true if a Dataset should be refreshed. Otherwise, the
+// time until the next scheduled refresh is returned (if any).
+func refreshDue(dataset *apiv1beta1.Dataset, now time.Time) (bool, time.Duration, error) {
+	if req := dataset.Annotations[apiv1beta1.RefreshAnnotation]; req != "" && req != dataset.Status.RefreshRequest {
+		return true, 0, nil
+	}
+
+	if dataset.Spec.Refresh == nil || dataset.Spec.Refresh.Schedule == "" {
+		return false, 0, nil
+	}
+	schedule, err := cron.ParseStandard(dataset.Spec.Refresh.Schedule)
+	if err != nil {
+		return false, 0, fmt.Errorf("parsing refresh schedule: %w", err)
+	}
+
+	last := dataset.CreationTimestamp.Time
+	if dataset.Status.LastRefreshTime != nil {
+		last = dataset.Status.LastRefreshTime.Time
+	}
+	next := schedule.Next(last)
+	if !now.Before(next) {
+		return true, 0, nil
+	}
+	return false, next.Sub(now), nil
+}
+
+// dataLoaderJobName returns the name of the Job that loads a version of a
+// Dataset.
+func dataLoaderJobName(dataset *apiv1beta1.Dataset, version int32) string {
+	if version <= 1 {
+		return dataset.Name + "-data-loader"
+	}
+	return fmt.Sprintf("%s-data-loader-v%d", dataset.Name, version)
+}
+
+func (r *DatasetReconciler) loadJob(ctx context.Context, dataset *apiv1beta1.Dataset, version int32, inputs []datasetInput) (*batchv1.Job, error) {
 	const containerName = "load"
 	envVars, err := resolveEnv(dataset.Spec.Env)
 	if err != nil {
@@ -154,7 +470,7 @@ func (r *DatasetReconciler) loadJob(ctx context.Context, dataset *apiv1.Dataset)
 	}
 	job := &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: dataset.Name + "-data-loader",
+			Name: dataLoaderJobName(dataset, version),
 			// Cross-Namespace owners not allowed, must be same as dataset:
 			Namespace: dataset.Namespace,
 		},
@@ -189,6 +505,8 @@ func (r *DatasetReconciler) loadJob(ctx context.Context, dataset *apiv1.Dataset)
 		},
 	}
 
+	applyRunPolicy(job, dataset.Spec.RunPolicy)
+
 	if err := mountParamsConfigMap(&job.Spec.Template.Spec, dataset, containerName); err != nil {
 		return nil, fmt.Errorf("mounting params configmap: %w", err)
 	}
@@ -204,12 +522,22 @@ func (r *DatasetReconciler) loadJob(ctx context.Context, dataset *apiv1.Dataset)
 		return nil, fmt.Errorf("mounting bucket: %w", err)
 	}
 
+	if err := mountDatasetInputs(r.Cloud, &job.Spec.Template.ObjectMeta, &job.Spec.Template.Spec, containerName, inputs); err != nil {
+		return nil, err
+	}
+
+	if dataset.Spec.Import != nil {
+		if err := applyImport(r.Cloud, r.ImporterImage, &job.Spec.Template.ObjectMeta, &job.Spec.Template.Spec, containerName, dataset, dataset.Spec.Import, "dataset"); err != nil {
+			return nil, fmt.Errorf("applying import: %w", err)
+		}
+	}
+
 	if err := controllerutil.SetControllerReference(dataset, job, r.Scheme); err != nil {
 		return nil, fmt.Errorf("setting owner reference: %w", err)
 	}
 
 	if err := resources.Apply(&job.Spec.Template.ObjectMeta, &job.Spec.Template.Spec, containerName,
-		r.Cloud.Name(), dataset.Spec.Resources); err != nil {
+		r.Cloud.Name(), r.GPUCatalog, dataset.Spec.Resources, dataset.Spec.Scheduling); err != nil {
 		return nil, fmt.Errorf("applying resources: %w", err)
 	}
 
@@ -1,8 +1,14 @@
 package controller_test
 
 import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -12,22 +18,26 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+	"github.com/substratusai/substratus/internal/cloud"
+	"github.com/substratusai/substratus/internal/importer"
+	"github.com/substratusai/substratus/internal/sci"
 )
 
 func TestDataset(t *testing.T) {
 	name := strings.ToLower(t.Name())
 
-	dataset := &apiv1.Dataset{
+	dataset := &apiv1beta1.Dataset{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name + "-ds",
 			Namespace: "default",
 		},
-		Spec: apiv1.DatasetSpec{
-			Build: &apiv1.Build{
-				Git: &apiv1.BuildGit{
+		Spec: apiv1beta1.DatasetSpec{
+			Build: &apiv1beta1.Build{
+				Git: &apiv1beta1.BuildGit{
 					URL: "https://github.com/substratusai/dataset-some-dataset",
 				},
 			},
@@ -45,9 +55,69 @@ func TestDataset(t *testing.T) {
 	testParamsConfigMap(t, dataset, "Dataset", `{ "s": "something-dataset", "x": 123 }`)
 
 	testDatasetLoad(t, dataset)
+	testDatasetInventory(t, dataset)
+	testDatasetDownload(t, dataset)
 }
 
-func testDatasetLoad(t *testing.T, dataset *apiv1.Dataset) {
+func TestDatasetFromDatasets(t *testing.T) {
+	name := strings.ToLower(t.Name())
+
+	raw := &apiv1beta1.Dataset{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-raw",
+			Namespace: "default",
+		},
+		Spec: apiv1beta1.DatasetSpec{
+			Image: ptr.To("some-dataset-image"),
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, raw), "create a raw dataset")
+	t.Cleanup(debugObject(t, raw))
+
+	tokenized := &apiv1beta1.Dataset{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-tokenized",
+			Namespace: "default",
+		},
+		Spec: apiv1beta1.DatasetSpec{
+			Image: ptr.To("some-tokenizer-image"),
+			Datasets: []apiv1beta1.DatasetMount{
+				{Dataset: apiv1beta1.DatasetRef{Name: raw.Name}},
+			},
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, tokenized), "create a dataset derived from the raw dataset")
+	t.Cleanup(debugObject(t, tokenized))
+
+	// The derived dataset should wait for its input.
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(tokenized), tokenized)
+		assert.NoError(t, err, "getting the derived dataset")
+		cond := meta.FindStatusCondition(tokenized.Status.Conditions, apiv1beta1.ConditionComplete)
+		if assert.NotNil(t, cond) {
+			assert.Equal(t, apiv1beta1.ReasonDatasetNotReady, cond.Reason)
+		}
+	}, timeout, interval, "waiting for the derived dataset to wait for its input")
+
+	testDatasetLoad(t, raw)
+
+	var loaderJob batchv1.Job
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: tokenized.Namespace, Name: tokenized.Name + "-data-loader"}, &loaderJob)
+		assert.NoError(t, err, "getting the derived data loader job")
+	}, timeout, interval, "waiting for the derived data loader job to be created")
+
+	var dataMount *corev1.VolumeMount
+	for i, m := range loaderJob.Spec.Template.Spec.Containers[0].VolumeMounts {
+		if m.MountPath == "/content/data" {
+			dataMount = &loaderJob.Spec.Template.Spec.Containers[0].VolumeMounts[i]
+		}
+	}
+	require.NotNil(t, dataMount, "input dataset should be mounted at /content/data")
+	require.True(t, dataMount.ReadOnly)
+}
+
+func testDatasetLoad(t *testing.T, dataset *apiv1beta1.Dataset) {
 	// Test that a data loader ServiceAccount gets created by the controller.
 	var sa corev1.ServiceAccount
 	require.EventuallyWithT(t, func(t *assert.CollectT) {
@@ -64,13 +134,278 @@ func testDatasetLoad(t *testing.T, dataset *apiv1.Dataset) {
 	}, timeout, interval, "waiting for the data loader job to be created")
 	require.Equal(t, "load", loaderJob.Spec.Template.Spec.Containers[0].Name)
 
+	// Write the artifacts that the data loader would have produced.
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(dataset), dataset)
+		assert.NoError(t, err, "getting the dataset")
+		assert.NotEmpty(t, dataset.Status.Artifacts.URL)
+	}, timeout, interval, "waiting for the dataset artifacts url")
+	u, err := cloud.ParseBucketURL(dataset.Status.Artifacts.URL)
+	require.NoError(t, err)
+	sciClient.SetObject(u.Bucket, filepath.Join(u.Path, "artifacts/train.jsonl"), []byte("1234567890"))
+	sciClient.SetObject(u.Bucket, filepath.Join(u.Path, "artifacts/test.jsonl"), []byte("12345"))
+
 	fakeJobComplete(t, &loaderJob)
 
 	require.EventuallyWithT(t, func(t *assert.CollectT) {
 		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(dataset), dataset)
 		assert.NoError(t, err, "getting the dataset")
-		assert.True(t, meta.IsStatusConditionTrue(dataset.Status.Conditions, apiv1.ConditionComplete))
+		assert.True(t, meta.IsStatusConditionTrue(dataset.Status.Conditions, apiv1beta1.ConditionComplete))
 		assert.True(t, dataset.Status.Ready)
 	}, timeout, interval, "waiting for the dataset to be ready")
 	require.Contains(t, dataset.Status.Artifacts.URL, "gs://test-artifact-bucket")
 }
+
+func testDatasetInventory(t *testing.T, dataset *apiv1beta1.Dataset) {
+	// The size of the artifacts should be recorded without waiting on the
+	// manifest Job.
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(dataset), dataset)
+		assert.NoError(t, err, "getting the dataset")
+		assert.True(t, meta.IsStatusConditionTrue(dataset.Status.Conditions, apiv1beta1.ConditionInventoried))
+	}, timeout, interval, "waiting for the dataset to be inventoried")
+	require.Equal(t, int64(15), dataset.Status.Artifacts.SizeBytes)
+	require.Equal(t, int64(2), dataset.Status.Artifacts.FileCount)
+	require.Empty(t, dataset.Status.Artifacts.ManifestURL)
+
+	var inventoryJob batchv1.Job
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: dataset.Namespace, Name: dataset.Name + "-data-loader-inventory"}, &inventoryJob)
+		assert.NoError(t, err, "getting the inventory job")
+	}, timeout, interval, "waiting for the inventory job to be created")
+	require.Equal(t, []string{
+		"-dest=/content/inventory/artifacts",
+		"-manifest=/content/inventory/manifest.json",
+	}, inventoryJob.Spec.Template.Spec.Containers[0].Args)
+
+	fakeJobComplete(t, &inventoryJob)
+
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(dataset), dataset)
+		assert.NoError(t, err, "getting the dataset")
+		assert.Equal(t, dataset.Status.Artifacts.URL+"/manifest.json", dataset.Status.Artifacts.ManifestURL)
+	}, timeout, interval, "waiting for the dataset manifest")
+}
+
+func testDatasetDownload(t *testing.T, dataset *apiv1beta1.Dataset) {
+	// Only train.jsonl is listed in the manifest, the checksum of test.jsonl
+	// should be taken from the bucket instead.
+	u, err := cloud.ParseBucketURL(dataset.Status.Artifacts.URL)
+	require.NoError(t, err)
+	manifest, err := json.Marshal(importer.Manifest{
+		Size:  10,
+		Files: []importer.ManifestFile{{Path: "train.jsonl", Size: 10, SHA256: "abc123"}},
+	})
+	require.NoError(t, err)
+	sciClient.SetObject(u.Bucket, filepath.Join(u.Path, "manifest.json"), manifest)
+
+	dataset.Annotations = map[string]string{apiv1beta1.DownloadRequestAnnotation: "req-1"}
+	require.NoError(t, k8sClient.Update(ctx, dataset), "requesting a download")
+
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(dataset), dataset)
+		assert.NoError(t, err, "getting the dataset")
+		if assert.NotNil(t, dataset.Status.Download) {
+			assert.Equal(t, "req-1", dataset.Status.Download.RequestID)
+		}
+	}, timeout, interval, "waiting for the download urls")
+	require.Empty(t, dataset.Status.Download.Message)
+	require.True(t, dataset.Status.Download.Expiration.After(time.Now()))
+	require.Equal(t, []apiv1beta1.DownloadFile{
+		{
+			Path:        "test.jsonl",
+			Size:        5,
+			MD5Checksum: fmt.Sprintf("%x", md5.Sum([]byte("12345"))),
+			SignedURL:   "https://signed.example.com/" + path.Join(u.Bucket, u.Path, "artifacts/test.jsonl"),
+		},
+		{
+			Path:      "train.jsonl",
+			Size:      10,
+			SHA256:    "abc123",
+			SignedURL: "https://signed.example.com/" + path.Join(u.Bucket, u.Path, "artifacts/train.jsonl"),
+		},
+	}, dataset.Status.Download.Files)
+}
+
+func TestDatasetRefresh(t *testing.T) {
+	name := strings.ToLower(t.Name())
+
+	dataset := &apiv1beta1.Dataset{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: apiv1beta1.DatasetSpec{
+			Image: ptr.To("some-dataset-image"),
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, dataset), "create a dataset")
+	t.Cleanup(debugObject(t, dataset))
+
+	testDatasetLoad(t, dataset)
+	require.Equal(t, int32(1), dataset.Status.Version)
+	initialURL := dataset.Status.Artifacts.URL
+
+	// Request a refresh.
+	dataset.Annotations = map[string]string{apiv1beta1.RefreshAnnotation: "1"}
+	require.NoError(t, k8sClient.Update(ctx, dataset), "requesting a refresh")
+
+	var loaderJob batchv1.Job
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: dataset.Namespace, Name: dataset.Name + "-data-loader-v2"}, &loaderJob)
+		assert.NoError(t, err, "getting the refresh data loader job")
+	}, timeout, interval, "waiting for the refresh data loader job to be created")
+
+	// The current version should be kept while the refresh is running.
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(dataset), dataset))
+	require.True(t, dataset.Status.Ready)
+	require.Equal(t, initialURL, dataset.Status.Artifacts.URL)
+	require.NotNil(t, dataset.Status.Refresh)
+	require.Equal(t, int32(2), dataset.Status.Refresh.Version)
+
+	fakeJobComplete(t, &loaderJob)
+
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(dataset), dataset)
+		assert.NoError(t, err, "getting the dataset")
+		assert.True(t, meta.IsStatusConditionTrue(dataset.Status.Conditions, apiv1beta1.ConditionRefreshed))
+		assert.Equal(t, int32(2), dataset.Status.Version)
+	}, timeout, interval, "waiting for the dataset to be refreshed")
+	require.Nil(t, dataset.Status.Refresh)
+	require.Equal(t, initialURL+"/v2", dataset.Status.Artifacts.URL)
+
+	u, err := cloud.ParseBucketURL(initialURL)
+	require.NoError(t, err)
+	objects := func(prefix string) []*sci.ObjectInfo {
+		resp, err := sciClient.ListObjects(ctx, &sci.ListObjectsRequest{BucketName: u.Bucket, Prefix: path.Join(u.Path, prefix) + "/"})
+		require.NoError(t, err)
+		return resp.Objects
+	}
+	sciClient.SetObject(u.Bucket, path.Join(u.Path, "v2/artifacts/train.jsonl"), []byte("1234567890"))
+	sciClient.SetObject(u.Bucket, path.Join(u.Path, "uploads/latest.tar.gz"), []byte("upload"))
+	require.NotEmpty(t, objects("artifacts"), "the previous version should be kept")
+
+	// Refreshing again should delete the first version.
+	dataset.Annotations = map[string]string{apiv1beta1.RefreshAnnotation: "2"}
+	require.NoError(t, k8sClient.Update(ctx, dataset), "requesting another refresh")
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: dataset.Namespace, Name: dataset.Name + "-data-loader-v3"}, &loaderJob)
+		assert.NoError(t, err, "getting the second refresh data loader job")
+	}, timeout, interval, "waiting for the second refresh data loader job to be created")
+	fakeJobComplete(t, &loaderJob)
+
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(dataset), dataset)
+		assert.NoError(t, err, "getting the dataset")
+		assert.Equal(t, int32(3), dataset.Status.Version)
+	}, timeout, interval, "waiting for the dataset to be refreshed again")
+	require.Empty(t, objects("artifacts"), "the first version should be deleted")
+	require.NotEmpty(t, objects("v2"), "the previous version should be kept")
+	require.NotEmpty(t, objects("uploads"), "uploads should be kept")
+}
+
+func TestDatasetRefreshAfterFailure(t *testing.T) {
+	name := strings.ToLower(t.Name())
+
+	dataset := &apiv1beta1.Dataset{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: apiv1beta1.DatasetSpec{
+			Image: ptr.To("some-dataset-image"),
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, dataset), "create a dataset")
+	t.Cleanup(debugObject(t, dataset))
+
+	testDatasetLoad(t, dataset)
+	initialURL := dataset.Status.Artifacts.URL
+
+	// Request a refresh that fails.
+	dataset.Annotations = map[string]string{apiv1beta1.RefreshAnnotation: "1"}
+	require.NoError(t, k8sClient.Update(ctx, dataset), "requesting a refresh")
+
+	var failedJob batchv1.Job
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: dataset.Namespace, Name: dataset.Name + "-data-loader-v2"}, &failedJob)
+		assert.NoError(t, err, "getting the refresh data loader job")
+	}, timeout, interval, "waiting for the refresh data loader job to be created")
+
+	fakeJobPreempted(t, &failedJob)
+
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(dataset), dataset)
+		assert.NoError(t, err, "getting the dataset")
+		assert.Nil(t, dataset.Status.Refresh)
+		assert.Equal(t, apiv1beta1.ReasonJobFailed, meta.FindStatusCondition(dataset.Status.Conditions, apiv1beta1.ConditionRefreshed).Reason)
+	}, timeout, interval, "waiting for the refresh to fail")
+	require.True(t, dataset.Status.Ready, "the current version should still be served")
+	require.Equal(t, int32(1), dataset.Status.Version)
+	require.Equal(t, initialURL, dataset.Status.Artifacts.URL)
+
+	// Request another refresh, it should not see the failed Job.
+	dataset.Annotations = map[string]string{apiv1beta1.RefreshAnnotation: "2"}
+	require.NoError(t, k8sClient.Update(ctx, dataset), "requesting another refresh")
+
+	var loaderJob batchv1.Job
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: dataset.Namespace, Name: dataset.Name + "-data-loader-v2"}, &loaderJob)
+		assert.NoError(t, err, "getting the refresh data loader job")
+		assert.NotEqual(t, failedJob.UID, loaderJob.UID, "expected a new job")
+	}, timeout, interval, "waiting for the retried data loader job to be created")
+
+	fakeJobComplete(t, &loaderJob)
+
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(dataset), dataset)
+		assert.NoError(t, err, "getting the dataset")
+		assert.True(t, meta.IsStatusConditionTrue(dataset.Status.Conditions, apiv1beta1.ConditionRefreshed))
+		assert.Equal(t, int32(2), dataset.Status.Version)
+	}, timeout, interval, "waiting for the dataset to be refreshed")
+	require.Equal(t, initialURL+"/v2", dataset.Status.Artifacts.URL)
+}
+
+func TestDatasetImport(t *testing.T) {
+	name := strings.ToLower(t.Name())
+
+	dataset := &apiv1beta1.Dataset{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: apiv1beta1.DatasetSpec{
+			Import: &apiv1beta1.ImportSource{
+				URLs: []apiv1beta1.URLSource{
+					{URL: "https://example.com/data.jsonl"},
+				},
+			},
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, dataset), "create a dataset without an image")
+	t.Cleanup(debugObject(t, dataset))
+
+	var loaderJob batchv1.Job
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: dataset.Namespace, Name: dataset.Name + "-data-loader"}, &loaderJob)
+		assert.NoError(t, err, "getting the data loader job")
+	}, timeout, interval, "waiting for the data loader job to be created")
+
+	container := loaderJob.Spec.Template.Spec.Containers[0]
+	require.Equal(t, testImporterImage, container.Image)
+	var sourceEnv string
+	for _, env := range container.Env {
+		if env.Name == importer.SourceEnvVar {
+			sourceEnv = env.Value
+		}
+	}
+	require.Contains(t, sourceEnv, "https://example.com/data.jsonl")
+
+	fakeJobComplete(t, &loaderJob)
+
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(dataset), dataset)
+		assert.NoError(t, err, "getting the dataset")
+		assert.True(t, dataset.Status.Ready)
+	}, timeout, interval, "waiting for the dataset to be ready")
+}
@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"context"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+	"github.com/substratusai/substratus/internal/cloud"
+	"github.com/substratusai/substratus/internal/sci"
+)
+
+func Test_refreshDue(t *testing.T) {
+	created := time.Date(2023, 8, 1, 10, 30, 0, 0, time.UTC)
+	refreshed := time.Date(2023, 8, 2, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name        string
+		annotation  string
+		request     string
+		schedule    string
+		lastRefresh *time.Time
+		now         time.Time
+		expDue      bool
+		expNext     time.Duration
+		expErr      bool
+	}{
+		{
+			name: "no policy",
+			now:  created.Add(time.Hour),
+		},
+		{
+			name:       "new refresh request",
+			annotation: "1",
+			now:        created.Add(time.Hour),
+			expDue:     true,
+		},
+		{
+			name:       "handled refresh request",
+			annotation: "1",
+			request:    "1",
+			now:        created.Add(time.Hour),
+		},
+		{
+			name:     "schedule not due since creation",
+			schedule: "0 0 * * *",
+			now:      created.Add(time.Hour),
+			expNext:  12*time.Hour + 30*time.Minute,
+		},
+		{
+			name:     "schedule due since creation",
+			schedule: "0 0 * * *",
+			now:      refreshed,
+			expDue:   true,
+		},
+		{
+			name:        "schedule not due since last refresh",
+			schedule:    "0 0 * * *",
+			lastRefresh: &refreshed,
+			now:         refreshed.Add(time.Hour),
+			expNext:     23 * time.Hour,
+		},
+		{
+			name:     "invalid schedule",
+			schedule: "every day",
+			now:      created.Add(time.Hour),
+			expErr:   true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			dataset := &apiv1beta1.Dataset{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.NewTime(created),
+				},
+				Status: apiv1beta1.DatasetStatus{
+					RefreshRequest: c.request,
+				},
+			}
+			if c.annotation != "" {
+				dataset.Annotations = map[string]string{apiv1beta1.RefreshAnnotation: c.annotation}
+			}
+			if c.schedule != "" {
+				dataset.Spec.Refresh = &apiv1beta1.RefreshPolicy{Schedule: c.schedule}
+			}
+			if c.lastRefresh != nil {
+				dataset.Status.LastRefreshTime = &metav1.Time{Time: *c.lastRefresh}
+			}
+
+			due, next, err := refreshDue(dataset, c.now)
+			if c.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.expDue, due)
+			require.Equal(t, c.expNext, next)
+		})
+	}
+}
+
+func Test_deleteDatasetVersion(t *testing.T) {
+	testCloud := &cloud.GCP{}
+	testCloud.ClusterName = "test-cluster-name"
+	testCloud.ArtifactBucketURL = &cloud.BucketURL{Scheme: "gs", Bucket: "test-artifact-bucket", Path: "/"}
+	sciClient := &sci.FakeSCIControllerClient{}
+	r := &DatasetReconciler{Cloud: testCloud, SCI: sciClient}
+
+	dataset := &apiv1beta1.Dataset{
+		TypeMeta:   metav1.TypeMeta{Kind: "Dataset"},
+		ObjectMeta: metav1.ObjectMeta{Name: "ds", Namespace: "default"},
+	}
+	u := testCloud.ObjectArtifactURL(dataset)
+	dir := strings.TrimPrefix(u.Path, "/")
+	for _, name := range []string{
+		"artifacts/train.jsonl",
+		"manifest.json",
+		"uploads/latest.tar.gz",
+		"v2/artifacts/train.jsonl",
+		"v3/artifacts/train.jsonl",
+	} {
+		sciClient.SetObject(u.Bucket, path.Join(dir, name), []byte("data"))
+	}
+	remaining := func() []string {
+		resp, err := sciClient.ListObjects(context.Background(), &sci.ListObjectsRequest{BucketName: u.Bucket, Prefix: dir + "/"})
+		require.NoError(t, err)
+		var names []string
+		for _, o := range resp.Objects {
+			names = append(names, strings.TrimPrefix(o.Name, dir+"/"))
+		}
+		return names
+	}
+
+	require.NoError(t, r.deleteDatasetVersion(context.Background(), dataset, 0))
+	require.Len(t, remaining(), 5, "versions that were never loaded should be ignored")
+
+	require.NoError(t, r.deleteDatasetVersion(context.Background(), dataset, 1))
+	require.Equal(t, []string{"uploads/latest.tar.gz", "v2/artifacts/train.jsonl", "v3/artifacts/train.jsonl"}, remaining())
+
+	require.NoError(t, r.deleteDatasetVersion(context.Background(), dataset, 2))
+	require.Equal(t, []string{"uploads/latest.tar.gz", "v3/artifacts/train.jsonl"}, remaining())
+}
@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+)
+
+const (
+	// deletionProtectionFinalizer blocks the deletion of Models and
+	// Datasets while other objects reference them.
+	deletionProtectionFinalizer = "substratus.ai/deletion-protection"
+
+	// deletionProtectionRecheckInterval is how often a blocked deletion is
+	// checked again.
+	deletionProtectionRecheckInterval = 30 * time.Second
+)
+
+// protectedObject is implemented by kinds that can not be deleted while they
+// are referenced.
+type protectedObject interface {
+	client.Object
+	GetConditions() *[]metav1.Condition
+}
+
+// reconcileDeletionProtection adds the deletion protection finalizer to an
+// object that is not being deleted. Once the object is being deleted, the
+// finalizer is only removed when no other objects reference it (or the
+// force annotation is set). The result is successful if the object is not
+// being deleted or when its deletion is no longer blocked.
+func reconcileDeletionProtection(ctx context.Context, c client.Client, obj protectedObject) (result, error) {
+	log := log.FromContext(ctx)
+
+	if obj.GetDeletionTimestamp().IsZero() {
+		if controllerutil.AddFinalizer(obj, deletionProtectionFinalizer) {
+			if err := updateObject(ctx, c, obj); err != nil {
+				return result{}, fmt.Errorf("adding finalizer: %w", err)
+			}
+		}
+		return result{success: true}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(obj, deletionProtectionFinalizer) {
+		return result{success: true}, nil
+	}
+
+	if force, _ := strconv.ParseBool(obj.GetAnnotations()[apiv1beta1.ForceDeleteAnnotation]); !force {
+		refs, err := referencingObjects(ctx, c, obj)
+		if err != nil {
+			return result{}, fmt.Errorf("listing referencing objects: %w", err)
+		}
+		if len(refs) > 0 {
+			log.Info("Deletion blocked", "referencedBy", refs)
+			meta.SetStatusCondition(obj.GetConditions(), metav1.Condition{
+				Type:               apiv1beta1.ConditionDeletionBlocked,
+				Status:             metav1.ConditionTrue,
+				Reason:             apiv1beta1.ReasonReferenced,
+				ObservedGeneration: obj.GetGeneration(),
+				Message: fmt.Sprintf("Referenced by %s (set the %s annotation to delete anyway)",
+					strings.Join(refs, ", "), apiv1beta1.ForceDeleteAnnotation),
+			})
+			if err := c.Status().Update(ctx, obj); err != nil {
+				return result{}, fmt.Errorf("updating status: %w", err)
+			}
+			return result{Result: ctrl.Result{RequeueAfter: deletionProtectionRecheckInterval}}, nil
+		}
+	}
+
+	controllerutil.RemoveFinalizer(obj, deletionProtectionFinalizer)
+	if err := updateObject(ctx, c, obj); err != nil {
+		return result{}, fmt.Errorf("removing finalizer: %w", err)
+	}
+
+	return result{success: true}, nil
+}
+
+// referencingObjects returns the objects (i.e. "servers/<name>") in the same
+// namespace that reference the given Model or Dataset. Objects that are being
+// deleted themselves are ignored.
+func referencingObjects(ctx context.Context, c client.Client, obj client.Object) ([]string, error) {
+	type query struct {
+		resource string
+		list     client.ObjectList
+		index    string
+	}
+	var (
+		queries []query
+		// references filters out objects that only match the index (i.e.
+		// that mount a BatchInference with the same name).
+		references func(o client.Object) bool
+	)
+	switch obj.(type) {
+	case *apiv1beta1.Model:
+		queries = []query{
+			{"models", &apiv1beta1.ModelList{}, modelModelIndex},
+			{"servers", &apiv1beta1.ServerList{}, modelServerModelIndex},
+			{"notebooks", &apiv1beta1.NotebookList{}, notebookModelIndex},
+			{"evaluations", &apiv1beta1.EvaluationList{}, evaluationModelIndex},
+			{"batchinferences", &apiv1beta1.BatchInferenceList{}, batchInferenceModelIndex},
+		}
+		references = func(client.Object) bool { return true }
+
+	case *apiv1beta1.Dataset:
+		queries = []query{
+			{"models", &apiv1beta1.ModelList{}, modelDatasetIndex},
+			{"datasets", &apiv1beta1.DatasetList{}, datasetDatasetIndex},
+			{"notebooks", &apiv1beta1.NotebookList{}, notebookDatasetIndex},
+			{"evaluations", &apiv1beta1.EvaluationList{}, evaluationDatasetIndex},
+			{"batchinferences", &apiv1beta1.BatchInferenceList{}, batchInferenceDatasetIndex},
+		}
+		key := datasetRefKey("Dataset", obj.GetName())
+		references = func(o client.Object) bool {
+			if d, ok := o.(*apiv1beta1.Dataset); ok && d.Name == obj.GetName() {
+				// A Dataset that mounts itself does not block its own deletion.
+				return false
+			}
+			for _, ref := range datasetRefsOf(o) {
+				if datasetRefKey(ref.Kind, ref.Name) == key {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	var refs []string
+	for _, q := range queries {
+		if err := c.List(ctx, q.list,
+			client.MatchingFields{q.index: obj.GetName()},
+			client.InNamespace(obj.GetNamespace()),
+		); err != nil {
+			return nil, fmt.Errorf("listing %v: %w", q.resource, err)
+		}
+		items, err := meta.ExtractList(q.list)
+		if err != nil {
+			return nil, fmt.Errorf("extracting %v: %w", q.resource, err)
+		}
+		for _, item := range items {
+			o := item.(client.Object)
+			if o.GetDeletionTimestamp().IsZero() && references(o) {
+				refs = append(refs, q.resource+"/"+o.GetName())
+			}
+		}
+	}
+
+	sort.Strings(refs)
+	return refs, nil
+}
@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+)
+
+func Test_referencingObjects(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1beta1.AddToScheme(scheme))
+
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: "default"}
+	}
+	modelRef := apiv1beta1.ObjectRef{Name: "base"}
+	datasetRef := apiv1beta1.DatasetRef{Name: "squad"}
+
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, idx := range fieldIndexes {
+		builder = builder.WithIndex(idx.obj, idx.field, idx.extract)
+	}
+	c := builder.WithObjects(
+		&apiv1beta1.Model{ObjectMeta: meta("base")},
+		&apiv1beta1.Dataset{ObjectMeta: meta("squad")},
+		&apiv1beta1.Model{ObjectMeta: meta("finetuned"), Spec: apiv1beta1.ModelSpec{
+			Model:   &modelRef,
+			Dataset: &datasetRef,
+		}},
+		&apiv1beta1.Server{ObjectMeta: meta("srv"), Spec: apiv1beta1.ServerSpec{Model: modelRef}},
+		&apiv1beta1.Notebook{ObjectMeta: meta("nb"), Spec: apiv1beta1.NotebookSpec{
+			Model:   &modelRef,
+			Dataset: &datasetRef,
+		}},
+		&apiv1beta1.Evaluation{ObjectMeta: meta("eval"), Spec: apiv1beta1.EvaluationSpec{
+			Model:   modelRef,
+			Dataset: datasetRef,
+		}},
+		&apiv1beta1.BatchInference{ObjectMeta: meta("bi"), Spec: apiv1beta1.BatchInferenceSpec{
+			Model:   modelRef,
+			Dataset: datasetRef,
+		}},
+		&apiv1beta1.Dataset{ObjectMeta: meta("squad-tokenized"), Spec: apiv1beta1.DatasetSpec{
+			Datasets: []apiv1beta1.DatasetMount{{Dataset: datasetRef}},
+		}},
+		// Mounts a BatchInference that has the same name as the Dataset.
+		&apiv1beta1.Dataset{ObjectMeta: meta("from-bi"), Spec: apiv1beta1.DatasetSpec{
+			Datasets: []apiv1beta1.DatasetMount{{Dataset: apiv1beta1.DatasetRef{Name: "squad", Kind: "BatchInference"}}},
+		}},
+	).Build()
+	ctx := context.Background()
+
+	cases := []struct {
+		name   string
+		obj    client.Object
+		expect []string
+	}{
+		{
+			name: "model",
+			obj:  &apiv1beta1.Model{ObjectMeta: meta("base")},
+			expect: []string{
+				"batchinferences/bi",
+				"evaluations/eval",
+				"models/finetuned",
+				"notebooks/nb",
+				"servers/srv",
+			},
+		},
+		{
+			name: "dataset",
+			obj:  &apiv1beta1.Dataset{ObjectMeta: meta("squad")},
+			expect: []string{
+				"batchinferences/bi",
+				"datasets/squad-tokenized",
+				"evaluations/eval",
+				"models/finetuned",
+				"notebooks/nb",
+			},
+		},
+		{
+			name: "unreferenced",
+			obj:  &apiv1beta1.Dataset{ObjectMeta: meta("squad-tokenized")},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			refs, err := referencingObjects(ctx, c, tc.obj)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, refs)
+		})
+	}
+}
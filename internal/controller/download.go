@@ -0,0 +1,173 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+	"github.com/substratusai/substratus/internal/cloud"
+	"github.com/substratusai/substratus/internal/importer"
+	"github.com/substratusai/substratus/internal/sci"
+)
+
+const (
+	// maxDownloadFiles limits the number of signed URLs that are stored in
+	// the status of an object. Each URL can be over 1KiB and every update of
+	// the status is sent to all watchers of the object.
+	maxDownloadFiles = 50
+	// maxManifestBytes limits the size of the manifest that is read by the
+	// controller.
+	maxManifestBytes = 8 << 20
+	// downloadExpirationSeconds is how long download URLs are valid for.
+	downloadExpirationSeconds = 3600
+)
+
+type downloadableObject interface {
+	artifactsObject
+	GetStatusDownload() *apiv1beta1.DownloadStatus
+	SetStatusDownload(*apiv1beta1.DownloadStatus)
+}
+
+// reconcileDownload populates .status.download with signed URLs of the
+// artifacts when a new download request ID is set with the
+// DownloadRequestAnnotation on a ready object.
+func reconcileDownload(ctx context.Context, c client.Client, sciClient sci.ControllerClient, obj downloadableObject) (result, error) {
+	log := log.FromContext(ctx)
+
+	requestID := obj.GetAnnotations()[apiv1beta1.DownloadRequestAnnotation]
+	if requestID == "" || !obj.GetStatusReady() {
+		return result{success: true}, nil
+	}
+	if download := obj.GetStatusDownload(); download != nil && download.RequestID == requestID {
+		return result{success: true}, nil
+	}
+
+	artifacts := obj.GetStatusArtifacts()
+	u, err := cloud.ParseBucketURL(artifacts.URL)
+	if err != nil {
+		log.Error(err, "unable to parse artifacts url")
+		// No use in retrying...
+		return result{}, nil
+	}
+
+	log.Info("Creating download URLs", "requestID", requestID)
+	download, err := downloadStatus(ctx, sciClient, u, artifacts.ManifestURL != "")
+	if err != nil {
+		return result{}, err
+	}
+	download.RequestID = requestID
+	obj.SetStatusDownload(download)
+	if err := c.Status().Update(ctx, obj); err != nil {
+		return result{}, fmt.Errorf("updating status: %w", err)
+	}
+
+	return result{success: true}, nil
+}
+
+// downloadStatus signs a download URL for every file under "artifacts/" at
+// the given URL. Checksums are taken from the manifest when it exists,
+// otherwise from the bucket.
+func downloadStatus(ctx context.Context, sciClient sci.ControllerClient, u *cloud.BucketURL, hasManifest bool) (*apiv1beta1.DownloadStatus, error) {
+	prefix := path.Join(u.Path, "artifacts") + "/"
+
+	var objects []*sci.ObjectInfo
+	req := &sci.ListObjectsRequest{
+		BucketName: u.Bucket,
+		Prefix:     prefix,
+	}
+	for {
+		resp, err := sciClient.ListObjects(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("calling the sci service to ListObjects: %w", err)
+		}
+		objects = append(objects, resp.Objects...)
+		if len(objects) > maxDownloadFiles {
+			return &apiv1beta1.DownloadStatus{
+				Message: fmt.Sprintf("Artifacts contain more than %d files, download them from %v directly", maxDownloadFiles, u),
+			}, nil
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+
+	var sums map[string]string
+	if hasManifest {
+		m, err := readManifest(ctx, sciClient, u)
+		if err != nil {
+			return nil, err
+		}
+		sums = make(map[string]string, len(m.Files))
+		for _, f := range m.Files {
+			sums[f.Path] = f.SHA256
+		}
+	}
+
+	// The expiration time is conservative: it is computed before the URLs
+	// are signed.
+	expiration := time.Now().Add(downloadExpirationSeconds * time.Second)
+	download := &apiv1beta1.DownloadStatus{
+		Expiration: metav1.NewTime(expiration),
+		Files:      make([]apiv1beta1.DownloadFile, 0, len(objects)),
+	}
+	for _, o := range objects {
+		file := apiv1beta1.DownloadFile{
+			Path: strings.TrimPrefix(o.Name, prefix),
+			Size: o.Size,
+		}
+		if sum, ok := sums[file.Path]; ok {
+			file.SHA256 = sum
+		} else {
+			stat, err := sciClient.StatObject(ctx, &sci.StatObjectRequest{
+				BucketName: u.Bucket,
+				ObjectName: o.Name,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("calling the sci service to StatObject: %w", err)
+			}
+			file.MD5Checksum = stat.Md5Checksum
+		}
+
+		resp, err := sciClient.CreateSignedDownloadURL(ctx, &sci.CreateSignedDownloadURLRequest{
+			BucketName:        u.Bucket,
+			ObjectName:        o.Name,
+			ExpirationSeconds: downloadExpirationSeconds,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("calling the sci service to CreateSignedDownloadURL: %w", err)
+		}
+		file.SignedURL = resp.Url
+
+		download.Files = append(download.Files, file)
+	}
+
+	return download, nil
+}
+
+// readManifest reads the manifest that is stored next to the "artifacts/"
+// directory at the given URL.
+func readManifest(ctx context.Context, sciClient sci.ControllerClient, u *cloud.BucketURL) (*importer.Manifest, error) {
+	resp, err := sciClient.GetObject(ctx, &sci.GetObjectRequest{
+		BucketName: u.Bucket,
+		ObjectName: path.Join(u.Path, manifestFile),
+		MaxBytes:   maxManifestBytes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("calling the sci service to GetObject: %w", err)
+	}
+
+	var m importer.Manifest
+	if err := json.Unmarshal(resp.Contents, &m); err != nil {
+		return nil, fmt.Errorf("decoding manifest: %w", err)
+	}
+	return &m, nil
+}
@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/substratusai/substratus/internal/cloud"
+	"github.com/substratusai/substratus/internal/sci"
+)
+
+func Test_downloadStatus(t *testing.T) {
+	ctx := context.Background()
+	u := &cloud.BucketURL{Scheme: "gs", Bucket: "test-bucket", Path: "abc123"}
+
+	sciClient := &sci.FakeSCIControllerClient{}
+	for i := 0; i < maxDownloadFiles; i++ {
+		sciClient.SetObject(u.Bucket, fmt.Sprintf("abc123/artifacts/shard-%03d.bin", i), []byte("weights"))
+	}
+	download, err := downloadStatus(ctx, sciClient, u, false)
+	require.NoError(t, err)
+	require.Empty(t, download.Message)
+	require.Len(t, download.Files, maxDownloadFiles)
+	require.Equal(t, "shard-000.bin", download.Files[0].Path)
+	require.Equal(t, int64(len("weights")), download.Files[0].Size)
+	require.NotEmpty(t, download.Files[0].SignedURL)
+
+	sciClient.SetObject(u.Bucket, "abc123/artifacts/one-too-many.bin", []byte("weights"))
+	download, err = downloadStatus(ctx, sciClient, u, false)
+	require.NoError(t, err)
+	require.Contains(t, download.Message, fmt.Sprintf("more than %d files", maxDownloadFiles))
+	require.Empty(t, download.Files, "no URLs should be signed for too many files")
+}
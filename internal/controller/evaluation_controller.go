@@ -0,0 +1,463 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+	"github.com/substratusai/substratus/internal/cloud"
+	"github.com/substratusai/substratus/internal/resources"
+	"github.com/substratusai/substratus/internal/sci"
+)
+
+const (
+	// evaluationResultsPath is where evaluation containers write their
+	// results (relative to /content/artifacts).
+	evaluationResultsPath = "results.json"
+
+	// maxEvaluationResultsBytes limits the size of the results file.
+	maxEvaluationResultsBytes = 1 << 20
+
+	// maxModelEvaluations is the number of Evaluations summarized in the
+	// status of a Model.
+	maxModelEvaluations = 10
+)
+
+// EvaluationReconciler reconciles an Evaluation object.
+type EvaluationReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	*ParamsReconciler
+
+	Cloud cloud.Cloud
+	SCI   sci.ControllerClient
+
+	// GPUCatalog describes how to schedule GPUs. If nil, the default
+	// catalog is used.
+	GPUCatalog *resources.GPUCatalog
+}
+
+func (r *EvaluationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	log.Info("Reconciling Evaluation")
+	defer log.Info("Done reconciling Evaluation")
+
+	var eval apiv1beta1.Evaluation
+	if err := r.Get(ctx, req.NamespacedName, &eval); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if eval.GetImage() == "" {
+		// Image must be building.
+		return ctrl.Result{}, nil
+	}
+
+	if result, err := r.ReconcileParamsConfigMap(ctx, &eval); !result.success {
+		return result.Result, err
+	}
+
+	if result, err := r.reconcileEvaluation(ctx, &eval); !result.success {
+		return result.Result, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+//+kubebuilder:rbac:groups=substratus.ai,resources=evaluations,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=substratus.ai,resources=evaluations/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=substratus.ai,resources=evaluations/finalizers,verbs=update
+//+kubebuilder:rbac:groups=substratus.ai,resources=models/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *EvaluationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&apiv1beta1.Evaluation{}).
+		Watches(&apiv1beta1.Model{}, handler.EnqueueRequestsFromMapFunc(handler.MapFunc(r.findEvaluationsForModel))).
+		Watches(&apiv1beta1.Dataset{}, handler.EnqueueRequestsFromMapFunc(handler.MapFunc(r.findEvaluationsForDataset))).
+		Watches(&apiv1beta1.BatchInference{}, handler.EnqueueRequestsFromMapFunc(handler.MapFunc(r.findEvaluationsForDataset))).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
+
+func (r *EvaluationReconciler) findEvaluationsForModel(ctx context.Context, obj client.Object) []reconcile.Request {
+	var evals apiv1beta1.EvaluationList
+	if err := r.List(ctx, &evals,
+		client.MatchingFields{evaluationModelIndex: obj.GetName()},
+		client.InNamespace(obj.GetNamespace()),
+	); err != nil {
+		log.Log.Error(err, "unable to list evaluations for model")
+		return nil
+	}
+	return evaluationRequests(evals)
+}
+
+func (r *EvaluationReconciler) findEvaluationsForDataset(ctx context.Context, obj client.Object) []reconcile.Request {
+	var evals apiv1beta1.EvaluationList
+	if err := r.List(ctx, &evals,
+		client.MatchingFields{evaluationDatasetIndex: obj.GetName()},
+		client.InNamespace(obj.GetNamespace()),
+	); err != nil {
+		log.Log.Error(err, "unable to list evaluations for dataset")
+		return nil
+	}
+	return evaluationRequests(evals)
+}
+
+func evaluationRequests(evals apiv1beta1.EvaluationList) []reconcile.Request {
+	reqs := []reconcile.Request{}
+	for _, eval := range evals.Items {
+		reqs = append(reqs, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      eval.Name,
+				Namespace: eval.Namespace,
+			},
+		})
+	}
+	return reqs
+}
+
+func (r *EvaluationReconciler) reconcileEvaluation(ctx context.Context, eval *apiv1beta1.Evaluation) (result, error) {
+	log := log.FromContext(ctx)
+
+	if eval.Status.Ready {
+		return result{success: true}, nil
+	}
+
+	eval.Status.Artifacts.URL = r.Cloud.ObjectArtifactURL(eval).String()
+
+	// ServiceAccount for the evaluation Job.
+	// Within the context of GCP, this ServiceAccount will need IAM permissions
+	// to read the GCS bucket containing the Model and Dataset and write to
+	// the bucket that contains the evaluation results.
+	if result, err := reconcileServiceAccount(ctx, r.Cloud, r.SCI, r.Client, &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      evaluatorServiceAccountName,
+			Namespace: eval.Namespace,
+		},
+	}); !result.success {
+		return result, err
+	}
+
+	notReady := func(reason string) (result, error) {
+		eval.Status.Ready = false
+		meta.SetStatusCondition(&eval.Status.Conditions, metav1.Condition{
+			Type:               apiv1beta1.ConditionComplete,
+			Status:             metav1.ConditionFalse,
+			Reason:             reason,
+			ObservedGeneration: eval.Generation,
+		})
+		if err := r.Status().Update(ctx, eval); err != nil {
+			return result{}, fmt.Errorf("failed to update evaluation status: %w", err)
+		}
+
+		// Allow for watch to requeue.
+		return result{}, nil
+	}
+
+	var model apiv1beta1.Model
+	if err := r.Get(ctx, types.NamespacedName{Namespace: eval.Namespace, Name: eval.Spec.Model.Name}, &model); err != nil {
+		if apierrors.IsNotFound(err) {
+			return notReady(apiv1beta1.ReasonModelNotFound)
+		}
+		return result{}, fmt.Errorf("getting model: %w", err)
+	}
+	if !model.Status.Ready {
+		return notReady(apiv1beta1.ReasonModelNotReady)
+	}
+
+	dataset, err := getDatasetObject(ctx, r.Client, eval.Namespace, eval.Spec.Dataset)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return notReady(apiv1beta1.ReasonDatasetNotFound)
+		}
+		if errors.Is(err, errUnsupportedDatasetKind) {
+			log.Error(err, "Unable to use dataset")
+			return notReady(apiv1beta1.ReasonDatasetKindUnsupported)
+		}
+		return result{}, fmt.Errorf("getting dataset: %w", err)
+	}
+	if !dataset.GetStatusReady() {
+		return notReady(apiv1beta1.ReasonDatasetNotReady)
+	}
+
+	evalJob, err := r.evaluationJob(eval, &model, dataset)
+	if err != nil {
+		log.Error(err, "unable to construct evaluation Job")
+		// No use in retrying...
+		return result{}, nil
+	}
+
+	if err := r.Status().Update(ctx, eval); err != nil {
+		return result{}, fmt.Errorf("updating status: %w", err)
+	}
+
+	if jobFailedBeforeCleanup(eval.Spec.RunPolicy, eval.Status.Conditions, eval.Generation) {
+		return result{failure: true}, nil
+	}
+
+	jobResult, err := reconcileJob(ctx, r.Client, evalJob)
+	if !jobResult.success {
+		eval.Status.Ready = false
+		if !jobResult.failure {
+			meta.SetStatusCondition(eval.GetConditions(), metav1.Condition{
+				Type:               apiv1beta1.ConditionComplete,
+				Status:             metav1.ConditionFalse,
+				Reason:             apiv1beta1.ReasonJobNotComplete,
+				ObservedGeneration: eval.Generation,
+				Message:            "Waiting for evaluation Job to complete",
+			})
+		} else {
+			meta.SetStatusCondition(eval.GetConditions(), metav1.Condition{
+				Type:               apiv1beta1.ConditionComplete,
+				Status:             metav1.ConditionFalse,
+				Reason:             apiv1beta1.ReasonJobFailed,
+				ObservedGeneration: eval.Generation,
+			})
+		}
+		if err := r.Status().Update(ctx, eval); err != nil {
+			return result{}, fmt.Errorf("updating status: %w", err)
+		}
+		return jobResult, err
+	}
+
+	metrics, err := r.evaluationResults(ctx, eval)
+	if err != nil {
+		if !errors.Is(err, errResultsInvalid) {
+			return result{}, fmt.Errorf("reading evaluation results: %w", err)
+		}
+		log.Info("Invalid evaluation results", "reason", err.Error())
+		meta.SetStatusCondition(eval.GetConditions(), metav1.Condition{
+			Type:               apiv1beta1.ConditionComplete,
+			Status:             metav1.ConditionFalse,
+			Reason:             apiv1beta1.ReasonResultsInvalid,
+			ObservedGeneration: eval.Generation,
+			Message:            err.Error(),
+		})
+		if err := r.Status().Update(ctx, eval); err != nil {
+			return result{}, fmt.Errorf("updating status: %w", err)
+		}
+		// No use in retrying...
+		return result{}, nil
+	}
+
+	// Summarize the results on the Model before marking the Evaluation as
+	// ready (ready Evaluations are not reconciled again).
+	if err := r.updateModelSummary(ctx, eval, &model, metrics); err != nil {
+		return result{}, fmt.Errorf("updating model evaluation summary: %w", err)
+	}
+
+	eval.Status.Ready = true
+	eval.Status.Metrics = metrics
+	meta.SetStatusCondition(eval.GetConditions(), metav1.Condition{
+		Type:               apiv1beta1.ConditionComplete,
+		Status:             metav1.ConditionTrue,
+		Reason:             apiv1beta1.ReasonJobComplete,
+		ObservedGeneration: eval.Generation,
+	})
+	if err := r.Status().Update(ctx, eval); err != nil {
+		return result{}, fmt.Errorf("updating status: %w", err)
+	}
+
+	return result{success: true}, nil
+}
+
+// errResultsInvalid is returned when the results file of a completed
+// evaluation is missing or can not be parsed. Retrying will not help.
+var errResultsInvalid = errors.New("invalid results")
+
+// evaluationResults reads the results file written by the evaluation
+// container.
+func (r *EvaluationReconciler) evaluationResults(ctx context.Context, eval *apiv1beta1.Evaluation) (map[string]string, error) {
+	u := r.Cloud.ObjectArtifactURL(eval)
+	resp, err := r.SCI.GetObject(ctx, &sci.GetObjectRequest{
+		BucketName: u.Bucket,
+		ObjectName: filepath.Join(u.Path, "artifacts", evaluationResultsPath),
+		MaxBytes:   maxEvaluationResultsBytes,
+	})
+	if err != nil {
+		if sci.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: artifacts/%v not found", errResultsInvalid, evaluationResultsPath)
+		}
+		return nil, fmt.Errorf("calling the sci service to GetObject: %w", err)
+	}
+
+	metrics, err := parseMetrics(resp.Contents)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errResultsInvalid, err)
+	}
+	return metrics, nil
+}
+
+// parseMetrics parses a flat JSON object of metrics. Numbers are kept in their
+// original representation.
+func parseMetrics(data []byte) (map[string]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var raw map[string]interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decoding metrics: %w", err)
+	}
+
+	metrics := make(map[string]string, len(raw))
+	for k, v := range raw {
+		switch v := v.(type) {
+		case string:
+			metrics[k] = v
+		case json.Number:
+			metrics[k] = v.String()
+		default:
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("encoding metric %q: %w", k, err)
+			}
+			metrics[k] = string(encoded)
+		}
+	}
+
+	return metrics, nil
+}
+
+func (r *EvaluationReconciler) updateModelSummary(ctx context.Context, eval *apiv1beta1.Evaluation, model *apiv1beta1.Model, metrics map[string]string) error {
+	summary := apiv1beta1.EvaluationSummary{
+		Name:           eval.Name,
+		Dataset:        eval.Spec.Dataset.Name,
+		Metrics:        metrics,
+		CompletionTime: metav1.Now(),
+	}
+
+	var summaries []apiv1beta1.EvaluationSummary
+	for _, s := range model.Status.Evaluations {
+		if s.Name != eval.Name {
+			summaries = append(summaries, s)
+		}
+	}
+	summaries = append(summaries, summary)
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].CompletionTime.Before(&summaries[j].CompletionTime)
+	})
+	if len(summaries) > maxModelEvaluations {
+		summaries = summaries[len(summaries)-maxModelEvaluations:]
+	}
+	model.Status.Evaluations = summaries
+
+	return r.Status().Update(ctx, model)
+}
+
+func (r *EvaluationReconciler) evaluationJob(eval *apiv1beta1.Evaluation, model *apiv1beta1.Model, dataset datasetObject) (*batchv1.Job, error) {
+	const containerName = "evaluate"
+	envVars, err := resolveEnv(eval.Spec.Env)
+	if err != nil {
+		return nil, fmt.Errorf("resolving env: %w", err)
+	}
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: eval.Name + "-evaluator",
+			// Cross-Namespace owners not allowed, must be same as evaluation:
+			Namespace: eval.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To(int32(0)),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"kubectl.kubernetes.io/default-container": containerName,
+					},
+					Labels: map[string]string{
+						"evaluation": eval.Name,
+						"role":       "run",
+					},
+				},
+				Spec: corev1.PodSpec{
+					SecurityContext: &corev1.PodSecurityContext{
+						FSGroup: ptr.To(int64(3003)),
+					},
+					ServiceAccountName: evaluatorServiceAccountName,
+					Containers: []corev1.Container{
+						{
+							Name:    containerName,
+							Image:   eval.GetImage(),
+							Command: eval.Spec.Command,
+							Env:     envVars,
+						},
+					},
+					RestartPolicy: "Never",
+				},
+			},
+		},
+	}
+
+	applyRunPolicy(job, eval.Spec.RunPolicy)
+
+	if err := mountParamsConfigMap(&job.Spec.Template.Spec, eval, containerName); err != nil {
+		return nil, fmt.Errorf("mounting params configmap: %w", err)
+	}
+
+	if err := r.Cloud.MountBucket(&job.Spec.Template.ObjectMeta, &job.Spec.Template.Spec, eval, cloud.MountBucketConfig{
+		Name: "artifacts",
+		Mounts: []cloud.BucketMount{
+			{BucketSubdir: "artifacts", ContentSubdir: "artifacts"},
+		},
+		Container: containerName,
+		ReadOnly:  false,
+	}); err != nil {
+		return nil, fmt.Errorf("mounting artifacts: %w", err)
+	}
+
+	if err := r.Cloud.MountBucket(&job.Spec.Template.ObjectMeta, &job.Spec.Template.Spec, model, cloud.MountBucketConfig{
+		Name: "model",
+		Mounts: []cloud.BucketMount{
+			{BucketSubdir: "artifacts", ContentSubdir: "model"},
+		},
+		Container: containerName,
+		ReadOnly:  true,
+	}); err != nil {
+		return nil, fmt.Errorf("mounting model: %w", err)
+	}
+
+	if err := r.Cloud.MountBucket(&job.Spec.Template.ObjectMeta, &job.Spec.Template.Spec, dataset, cloud.MountBucketConfig{
+		Name: "dataset",
+		Mounts: []cloud.BucketMount{
+			{BucketSubdir: "artifacts", ContentSubdir: "data"},
+		},
+		Container: containerName,
+		ReadOnly:  true,
+	}); err != nil {
+		return nil, fmt.Errorf("mounting dataset: %w", err)
+	}
+
+	if err := controllerutil.SetControllerReference(eval, job, r.Scheme); err != nil {
+		return nil, fmt.Errorf("setting owner reference: %w", err)
+	}
+
+	if err := resources.Apply(&job.Spec.Template.ObjectMeta, &job.Spec.Template.Spec, containerName,
+		r.Cloud.Name(), r.GPUCatalog, eval.Spec.Resources, eval.Spec.Scheduling); err != nil {
+		return nil, fmt.Errorf("applying resources: %w", err)
+	}
+
+	return job, nil
+}
@@ -0,0 +1,91 @@
+package controller_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+	"github.com/substratusai/substratus/internal/cloud"
+)
+
+func TestEvaluation(t *testing.T) {
+	name := strings.ToLower(t.Name())
+
+	model := &apiv1beta1.Model{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-mdl",
+			Namespace: "default",
+		},
+		Spec: apiv1beta1.ModelSpec{
+			Image: ptr.To("some-model-image"),
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, model), "create a model to be evaluated")
+	t.Cleanup(debugObject(t, model))
+	testModelLoad(t, model)
+
+	dataset := &apiv1beta1.Dataset{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-ds",
+			Namespace: "default",
+		},
+		Spec: apiv1beta1.DatasetSpec{
+			Image: ptr.To("some-dataset-image"),
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, dataset), "create a dataset to evaluate against")
+	t.Cleanup(debugObject(t, dataset))
+	testDatasetLoad(t, dataset)
+
+	eval := &apiv1beta1.Evaluation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-eval",
+			Namespace: "default",
+		},
+		Spec: apiv1beta1.EvaluationSpec{
+			Image:   ptr.To("some-eval-image"),
+			Model:   apiv1beta1.ObjectRef{Name: model.Name},
+			Dataset: apiv1beta1.DatasetRef{Name: dataset.Name},
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, eval), "create an evaluation")
+	t.Cleanup(debugObject(t, eval))
+
+	var job batchv1.Job
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: eval.Namespace, Name: eval.Name + "-evaluator"}, &job)
+		assert.NoError(t, err, "getting the evaluation job")
+	}, timeout, interval, "waiting for the evaluation job to be created")
+	require.Equal(t, "evaluate", job.Spec.Template.Spec.Containers[0].Name)
+
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(eval), eval))
+	u, err := cloud.ParseBucketURL(eval.Status.Artifacts.URL)
+	require.NoError(t, err)
+	sciClient.SetObject(u.Bucket, filepath.Join(u.Path, "artifacts/results.json"), []byte(`{"accuracy": 0.91, "split": "test"}`))
+
+	fakeJobComplete(t, &job)
+
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(eval), eval)
+		assert.NoError(t, err, "getting the evaluation")
+		assert.True(t, meta.IsStatusConditionTrue(eval.Status.Conditions, apiv1beta1.ConditionComplete))
+		assert.True(t, eval.Status.Ready)
+	}, timeout, interval, "waiting for the evaluation to be ready")
+	require.Equal(t, map[string]string{"accuracy": "0.91", "split": "test"}, eval.Status.Metrics)
+
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(model), model))
+	require.Len(t, model.Status.Evaluations, 1)
+	require.Equal(t, eval.Name, model.Status.Evaluations[0].Name)
+	require.Equal(t, dataset.Name, model.Status.Evaluations[0].Dataset)
+	require.Equal(t, "0.91", model.Status.Evaluations[0].Metrics["accuracy"])
+}
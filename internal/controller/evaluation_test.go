@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+	"github.com/substratusai/substratus/internal/cloud"
+	"github.com/substratusai/substratus/internal/sci"
+)
+
+func Test_evaluationResults(t *testing.T) {
+	testCloud := &cloud.GCP{}
+	testCloud.ClusterName = "test-cluster-name"
+	testCloud.ArtifactBucketURL = &cloud.BucketURL{Scheme: "gs", Bucket: "test-artifact-bucket", Path: "/"}
+	sciClient := &sci.FakeSCIControllerClient{}
+	r := &EvaluationReconciler{Cloud: testCloud, SCI: sciClient}
+
+	eval := &apiv1beta1.Evaluation{
+		TypeMeta:   metav1.TypeMeta{Kind: "Evaluation"},
+		ObjectMeta: metav1.ObjectMeta{Name: "eval", Namespace: "default"},
+	}
+	u := testCloud.ObjectArtifactURL(eval)
+	resultsObject := path.Join(u.Path, "artifacts", evaluationResultsPath)
+	ctx := context.Background()
+
+	_, err := r.evaluationResults(ctx, eval)
+	require.ErrorIs(t, err, errResultsInvalid, "missing results should not be retried")
+
+	sciClient.SetObject(u.Bucket, resultsObject, []byte(`not json`))
+	_, err = r.evaluationResults(ctx, eval)
+	require.ErrorIs(t, err, errResultsInvalid, "malformed results should not be retried")
+
+	sciClient.SetObject(u.Bucket, resultsObject, []byte(`{"accuracy": 0.91}`))
+	metrics, err := r.evaluationResults(ctx, eval)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"accuracy": "0.91"}, metrics)
+
+	r.SCI = failingSCIClient{sciClient}
+	_, err = r.evaluationResults(ctx, eval)
+	require.Error(t, err)
+	require.False(t, errors.Is(err, errResultsInvalid), "transient errors should be retried")
+}
+
+// failingSCIClient fails every GetObject call as if the SCI was unavailable.
+type failingSCIClient struct {
+	*sci.FakeSCIControllerClient
+}
+
+func (failingSCIClient) GetObject(context.Context, *sci.GetObjectRequest, ...grpc.CallOption) (*sci.GetObjectResponse, error) {
+	return nil, status.Error(codes.Unavailable, "connection refused")
+}
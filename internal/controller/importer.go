@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+	"github.com/substratusai/substratus/internal/cloud"
+	"github.com/substratusai/substratus/internal/importer"
+)
+
+// importSourceObject is a stand-in for an object whose artifacts are stored
+// at the URL of a bucket import source. It allows the source to be mounted
+// using the cloud.
+type importSourceObject struct {
+	client.Object
+	url string
+}
+
+func (o importSourceObject) GetStatusArtifacts() apiv1beta1.ArtifactsStatus {
+	return apiv1beta1.ArtifactsStatus{URL: o.url}
+}
+
+// applyImport configures a container to run the built-in importer instead
+// of the image of the object. Bucket sources are mounted read-only at
+// /content/source. Hugging Face repos default to the given repo type.
+func applyImport(cld cloud.Cloud, image string, podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, containerName string, obj client.Object, src *apiv1beta1.ImportSource, repoType string) error {
+	if image == "" {
+		return fmt.Errorf("no importer image configured (see --importer-image)")
+	}
+
+	src = src.DeepCopy()
+	if src.HuggingFace != nil && src.HuggingFace.RepoType == "" {
+		src.HuggingFace.RepoType = repoType
+	}
+	srcJSON, err := json.Marshal(src)
+	if err != nil {
+		return fmt.Errorf("marshalling import source: %w", err)
+	}
+
+	var found bool
+	for i := range podSpec.Containers {
+		c := &podSpec.Containers[i]
+		if c.Name != containerName {
+			continue
+		}
+		c.Image = image
+		c.Command = nil
+		c.Args = nil
+		c.Env = append(c.Env, corev1.EnvVar{Name: importer.SourceEnvVar, Value: string(srcJSON)})
+		found = true
+	}
+	if !found {
+		return fmt.Errorf("container not found: %s", containerName)
+	}
+
+	if src.Bucket != nil {
+		if _, err := cloud.ParseBucketURL(src.Bucket.URL); err != nil {
+			return fmt.Errorf("parsing import bucket url: %w", err)
+		}
+		if err := cld.MountBucket(podMetadata, podSpec, importSourceObject{Object: obj, url: src.Bucket.URL}, cloud.MountBucketConfig{
+			Name: "import-source",
+			Mounts: []cloud.BucketMount{
+				{BucketSubdir: "", ContentSubdir: "source"},
+			},
+			Container: containerName,
+			ReadOnly:  true,
+		}); err != nil {
+			return fmt.Errorf("mounting import source: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+	"github.com/substratusai/substratus/internal/cloud"
+	"github.com/substratusai/substratus/internal/importer"
+)
+
+func Test_applyImport(t *testing.T) {
+	cld := &cloud.GCP{}
+	cld.ArtifactBucketURL = &cloud.BucketURL{Scheme: "gs", Bucket: "test-artifact-bucket", Path: "/"}
+
+	model := &apiv1beta1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "m", Namespace: "default"},
+		Spec: apiv1beta1.ModelSpec{
+			Image:   ptr.To("some-image"),
+			Command: []string{"train.sh"},
+			Import: &apiv1beta1.ImportSource{
+				HuggingFace: &apiv1beta1.HuggingFaceSource{Repo: "facebook/opt-125m"},
+				Bucket:      &apiv1beta1.BucketSource{URL: "gs://other-bucket/some/path"},
+			},
+		},
+	}
+
+	var podMeta metav1.ObjectMeta
+	podSpec := corev1.PodSpec{
+		Containers: []corev1.Container{{Name: "model", Image: model.GetImage(), Command: model.Spec.Command}},
+	}
+	require.Error(t, applyImport(cld, "", &podMeta, &corev1.PodSpec{}, "model", model, model.Spec.Import, "model"), "an importer image is required")
+	require.NoError(t, applyImport(cld, "substratusai/importer:test", &podMeta, &podSpec, "model", model, model.Spec.Import, "model"))
+
+	c := podSpec.Containers[0]
+	require.Equal(t, "substratusai/importer:test", c.Image)
+	require.Nil(t, c.Command, "the importer entrypoint should be used")
+	require.Len(t, c.Env, 1)
+	require.Equal(t, importer.SourceEnvVar, c.Env[0].Name)
+	var src apiv1beta1.ImportSource
+	require.NoError(t, json.Unmarshal([]byte(c.Env[0].Value), &src))
+	require.Equal(t, "model", src.HuggingFace.RepoType, "repo type should default to the kind")
+	require.Empty(t, model.Spec.Import.HuggingFace.RepoType, "the spec should not be modified")
+
+	require.Len(t, podSpec.Volumes, 1)
+	require.Equal(t, "other-bucket", podSpec.Volumes[0].CSI.VolumeAttributes["bucketName"])
+	require.Equal(t, []corev1.VolumeMount{{
+		Name:      "import-source",
+		MountPath: "/content/source",
+		SubPath:   "some/path/",
+		ReadOnly:  true,
+	}}, c.VolumeMounts)
+}
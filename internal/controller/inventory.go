@@ -0,0 +1,214 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+	"github.com/substratusai/substratus/internal/cloud"
+	"github.com/substratusai/substratus/internal/sci"
+)
+
+// manifestFile is the name of the artifacts manifest (see
+// internal/importer), stored next to the "artifacts/" directory.
+const manifestFile = "manifest.json"
+
+// inventory configures how the artifacts of an object are inventoried.
+type inventory struct {
+	// kind of the object in lower case (used as a Pod label).
+	kind string
+	// manifest enables the Job that writes the manifest of the artifacts.
+	manifest           bool
+	jobName            string
+	serviceAccountName string
+	// image of the built-in importer.
+	image string
+}
+
+// reconcileInventory records the size and file count of the artifacts of a
+// ready object, as listed through the SCI. If enabled, a Job that runs the
+// built-in importer against the bucket then writes the manifest. The
+// inventory does not hold up the rest of the reconciliation: the result is
+// only unsuccessful on errors and a failed manifest Job is only reported in
+// the Inventoried condition.
+func reconcileInventory(ctx context.Context, c client.Client, scheme *runtime.Scheme, cld cloud.Cloud, sciClient sci.ControllerClient, obj artifactsObject, inv inventory) (result, error) {
+	log := log.FromContext(ctx)
+
+	artifacts := obj.GetStatusArtifacts()
+	u, err := cloud.ParseBucketURL(artifacts.URL)
+	if err != nil {
+		log.Error(err, "unable to parse artifacts url")
+		// No use in retrying...
+		return result{success: true}, nil
+	}
+
+	setInventoried := func(message string) error {
+		meta.SetStatusCondition(obj.GetConditions(), metav1.Condition{
+			Type:               apiv1beta1.ConditionInventoried,
+			Status:             metav1.ConditionTrue,
+			Reason:             apiv1beta1.ReasonArtifactsListed,
+			ObservedGeneration: obj.GetGeneration(),
+			Message:            message,
+		})
+		if err := c.Status().Update(ctx, obj); err != nil {
+			return fmt.Errorf("updating status: %w", err)
+		}
+		return nil
+	}
+
+	inventoried := meta.FindStatusCondition(*obj.GetConditions(), apiv1beta1.ConditionInventoried)
+	if inventoried == nil || inventoried.Status != metav1.ConditionTrue {
+		files, size, err := listArtifacts(ctx, sciClient, u)
+		if err != nil {
+			return result{}, err
+		}
+		artifacts.FileCount = files
+		artifacts.SizeBytes = size
+		obj.SetStatusArtifacts(artifacts)
+		if err := setInventoried(""); err != nil {
+			return result{}, err
+		}
+	}
+
+	manifestURL := *u
+	manifestURL.Path = path.Join(u.Path, manifestFile)
+	if !inv.manifest || artifacts.ManifestURL == manifestURL.String() {
+		return result{success: true}, nil
+	}
+
+	job, err := inventoryJob(scheme, cld, obj, inv)
+	if err != nil {
+		log.Error(err, "unable to construct inventory Job")
+		// No use in retrying...
+		return result{success: true}, nil
+	}
+
+	jobResult, err := reconcileJob(ctx, c, job)
+	if err != nil {
+		return result{}, err
+	}
+	if jobResult.failure {
+		msg := fmt.Sprintf("Manifest Job %v failed", job.Name)
+		if inventoried := meta.FindStatusCondition(*obj.GetConditions(), apiv1beta1.ConditionInventoried); inventoried.Message != msg {
+			log.Info("Inventory Job failed", "job", job.Name)
+			if err := setInventoried(msg); err != nil {
+				return result{}, err
+			}
+		}
+		return result{success: true}, nil
+	}
+	if !jobResult.success {
+		// Allow for watch to requeue.
+		return result{success: true}, nil
+	}
+
+	artifacts.ManifestURL = manifestURL.String()
+	obj.SetStatusArtifacts(artifacts)
+	if err := setInventoried(""); err != nil {
+		return result{}, err
+	}
+
+	return result{success: true}, nil
+}
+
+// inventoryJob returns a Job that mounts the bucket directory of the
+// object's artifacts and writes a manifest of the "artifacts/" directory
+// next to it.
+func inventoryJob(scheme *runtime.Scheme, cld cloud.Cloud, obj artifactsObject, inv inventory) (*batchv1.Job, error) {
+	const containerName = "inventory"
+	image := inv.image
+	if image == "" {
+		return nil, fmt.Errorf("no importer image configured (see --importer-image)")
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: inv.jobName,
+			// Cross-Namespace owners not allowed, must be same as obj.
+			Namespace: obj.GetNamespace(),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To(int32(2)), // TotalRetries = BackoffLimit + 1
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"kubectl.kubernetes.io/default-container": containerName,
+					},
+					Labels: map[string]string{
+						inv.kind: obj.GetName(),
+						"role":   "inventory",
+					},
+				},
+				Spec: corev1.PodSpec{
+					SecurityContext: &corev1.PodSecurityContext{
+						FSGroup: ptr.To(int64(3003)),
+					},
+					ServiceAccountName: inv.serviceAccountName,
+					Containers: []corev1.Container{
+						{
+							Name:  containerName,
+							Image: image,
+							Args: []string{
+								"-dest=/content/inventory/artifacts",
+								"-manifest=/content/inventory/" + manifestFile,
+							},
+						},
+					},
+					RestartPolicy: "Never",
+				},
+			},
+		},
+	}
+
+	if err := cld.MountBucket(&job.Spec.Template.ObjectMeta, &job.Spec.Template.Spec, obj, cloud.MountBucketConfig{
+		Name: "inventory",
+		Mounts: []cloud.BucketMount{
+			{BucketSubdir: "", ContentSubdir: "inventory"},
+		},
+		Container: containerName,
+		ReadOnly:  false,
+	}); err != nil {
+		return nil, fmt.Errorf("mounting bucket: %w", err)
+	}
+
+	if err := controllerutil.SetControllerReference(obj, job, scheme); err != nil {
+		return nil, fmt.Errorf("setting owner reference: %w", err)
+	}
+
+	return job, nil
+}
+
+// listArtifacts returns the number and total size of the files under the
+// "artifacts/" directory at the given URL.
+func listArtifacts(ctx context.Context, sciClient sci.ControllerClient, u *cloud.BucketURL) (int64, int64, error) {
+	var files, size int64
+	req := &sci.ListObjectsRequest{
+		BucketName: u.Bucket,
+		Prefix:     path.Join(u.Path, "artifacts") + "/",
+	}
+	for {
+		resp, err := sciClient.ListObjects(ctx, req)
+		if err != nil {
+			return 0, 0, fmt.Errorf("calling the sci service to ListObjects: %w", err)
+		}
+		for _, o := range resp.Objects {
+			files++
+			size += o.Size
+		}
+		if resp.NextPageToken == "" {
+			return files, size, nil
+		}
+		req.PageToken = resp.NextPageToken
+	}
+}
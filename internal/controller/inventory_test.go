@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+	"github.com/substratusai/substratus/internal/cloud"
+	"github.com/substratusai/substratus/internal/sci"
+)
+
+func Test_reconcileInventory(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1beta1.AddToScheme(scheme))
+	require.NoError(t, batchv1.AddToScheme(scheme))
+
+	model := &apiv1beta1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Status: apiv1beta1.ModelStatus{
+			Ready:     true,
+			Artifacts: apiv1beta1.ArtifactsStatus{URL: "gs://test-bucket/abc123"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(model).WithStatusSubresource(model).Build()
+	sciClient := &sci.FakeSCIControllerClient{}
+	sciClient.SetObject("test-bucket", "abc123/artifacts/model.bin", []byte("weights"))
+	sciClient.SetObject("test-bucket", "abc123/artifacts/config.json", []byte("{}"))
+	ctx := context.Background()
+
+	result, err := reconcileInventory(ctx, c, scheme, &cloud.GCP{}, sciClient, model, inventory{
+		kind:    "model",
+		jobName: "test-model-inventory",
+	})
+	require.NoError(t, err)
+	require.True(t, result.success)
+	require.True(t, meta.IsStatusConditionTrue(model.Status.Conditions, apiv1beta1.ConditionInventoried))
+	require.Equal(t, int64(2), model.Status.Artifacts.FileCount)
+	require.Equal(t, int64(len("weights")+len("{}")), model.Status.Artifacts.SizeBytes)
+	require.Empty(t, model.Status.Artifacts.ManifestURL)
+
+	var jobs batchv1.JobList
+	require.NoError(t, c.List(ctx, &jobs))
+	require.Empty(t, jobs.Items, "no manifest Job should be run unless enabled")
+}
@@ -25,7 +25,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
-	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 	"github.com/substratusai/substratus/internal/cloud"
 	"github.com/substratusai/substratus/internal/controller"
 	"github.com/substratusai/substratus/internal/sci"
@@ -34,13 +34,18 @@ import (
 const (
 	timeout  = time.Second * 5
 	interval = time.Second / 10
+
+	testImporterImage = "substratusai/importer:test"
 )
 
 var (
 	k8sClient client.Client
-	testEnv   *envtest.Environment
-	ctx       context.Context
-	cancel    context.CancelFunc
+	sciClient *sci.FakeSCIControllerClient
+
+	artifactsCollector *controller.ArtifactsCollector
+	testEnv            *envtest.Environment
+	ctx                context.Context
+	cancel             context.CancelFunc
 )
 
 func TestMain(m *testing.M) {
@@ -61,7 +66,7 @@ func TestMain(m *testing.M) {
 	cfg, err := testEnv.Start()
 	requireNoError(err)
 
-	requireNoError(apiv1.AddToScheme(scheme.Scheme))
+	requireNoError(apiv1beta1.AddToScheme(scheme.Scheme))
 
 	//+kubebuilder:scaffold:scheme
 
@@ -83,7 +88,14 @@ func TestMain(m *testing.M) {
 	testCloud.RegistryURL = "registry.test"
 	testCloud.Principal = "substratus@test-project-id.iam.gserviceaccount.com"
 
-	sciClient := &sci.FakeSCIControllerClient{}
+	sciClient = &sci.FakeSCIControllerClient{}
+
+	artifactsCollector = &controller.ArtifactsCollector{
+		Client:    mgr.GetClient(),
+		Cloud:     testCloud,
+		SCI:       sciClient,
+		Namespace: "default",
+	}
 
 	// runtimeMgr, err := controller.NewRuntimeManager(controller.GPUTypeNvidiaL4)
 	// requireNoError(err)
@@ -97,6 +109,8 @@ func TestMain(m *testing.M) {
 			Scheme: mgr.GetScheme(),
 			Client: mgr.GetClient(),
 		},
+		Artifacts:     artifactsCollector,
+		ImporterImage: testImporterImage,
 	}).SetupWithManager(mgr)
 	requireNoError(err)
 	err = (&controller.BuildReconciler{
@@ -104,7 +118,7 @@ func TestMain(m *testing.M) {
 		Client:    mgr.GetClient(),
 		Cloud:     testCloud,
 		SCI:       sciClient,
-		NewObject: func() controller.BuildableObject { return &apiv1.Model{} },
+		NewObject: func() controller.BuildableObject { return &apiv1beta1.Model{} },
 		Kind:      "Model",
 	}).SetupWithManager(mgr)
 	requireNoError(err)
@@ -124,7 +138,7 @@ func TestMain(m *testing.M) {
 		Client:    mgr.GetClient(),
 		Cloud:     testCloud,
 		SCI:       sciClient,
-		NewObject: func() controller.BuildableObject { return &apiv1.Server{} },
+		NewObject: func() controller.BuildableObject { return &apiv1beta1.Server{} },
 		Kind:      "Server",
 	}).SetupWithManager(mgr)
 	requireNoError(err)
@@ -137,6 +151,7 @@ func TestMain(m *testing.M) {
 			Scheme: mgr.GetScheme(),
 			Client: mgr.GetClient(),
 		},
+		Artifacts: artifactsCollector,
 	}).SetupWithManager(mgr)
 	requireNoError(err)
 	err = (&controller.BuildReconciler{
@@ -144,7 +159,7 @@ func TestMain(m *testing.M) {
 		Client:    mgr.GetClient(),
 		Cloud:     testCloud,
 		SCI:       sciClient,
-		NewObject: func() controller.BuildableObject { return &apiv1.Notebook{} },
+		NewObject: func() controller.BuildableObject { return &apiv1beta1.Notebook{} },
 		Kind:      "Notebook",
 	}).SetupWithManager(mgr)
 	requireNoError(err)
@@ -157,6 +172,9 @@ func TestMain(m *testing.M) {
 			Scheme: mgr.GetScheme(),
 			Client: mgr.GetClient(),
 		},
+		Artifacts:          artifactsCollector,
+		ImporterImage:      testImporterImage,
+		ArtifactsManifests: true,
 	}).SetupWithManager(mgr)
 	requireNoError(err)
 	err = (&controller.BuildReconciler{
@@ -164,10 +182,60 @@ func TestMain(m *testing.M) {
 		Client:    mgr.GetClient(),
 		Cloud:     testCloud,
 		SCI:       sciClient,
-		NewObject: func() controller.BuildableObject { return &apiv1.Dataset{} },
+		NewObject: func() controller.BuildableObject { return &apiv1beta1.Dataset{} },
 		Kind:      "Dataset",
 	}).SetupWithManager(mgr)
 	requireNoError(err)
+	err = (&controller.EvaluationReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Cloud:  testCloud,
+		SCI:    sciClient,
+		ParamsReconciler: &controller.ParamsReconciler{
+			Scheme: mgr.GetScheme(),
+			Client: mgr.GetClient(),
+		},
+	}).SetupWithManager(mgr)
+	requireNoError(err)
+	err = (&controller.BuildReconciler{
+		Scheme:    mgr.GetScheme(),
+		Client:    mgr.GetClient(),
+		Cloud:     testCloud,
+		SCI:       sciClient,
+		NewObject: func() controller.BuildableObject { return &apiv1beta1.Evaluation{} },
+		Kind:      "Evaluation",
+	}).SetupWithManager(mgr)
+	requireNoError(err)
+	err = (&controller.BatchInferenceReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Cloud:  testCloud,
+		SCI:    sciClient,
+		ParamsReconciler: &controller.ParamsReconciler{
+			Scheme: mgr.GetScheme(),
+			Client: mgr.GetClient(),
+		},
+	}).SetupWithManager(mgr)
+	requireNoError(err)
+	err = (&controller.BuildReconciler{
+		Scheme:    mgr.GetScheme(),
+		Client:    mgr.GetClient(),
+		Cloud:     testCloud,
+		SCI:       sciClient,
+		NewObject: func() controller.BuildableObject { return &apiv1beta1.BatchInference{} },
+		Kind:      "BatchInference",
+	}).SetupWithManager(mgr)
+	requireNoError(err)
+	err = (&controller.ModelSweepReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr)
+	requireNoError(err)
+	err = (&controller.PipelineReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr)
+	requireNoError(err)
 	ctx, cancel := context.WithCancel(ctx)
 
 	go func() {
@@ -201,7 +269,7 @@ type testObject interface {
 	GetConditions() *[]metav1.Condition
 	GetStatusReady() bool
 	SetStatusReady(bool)
-	GetBuild() *apiv1.Build
+	GetBuild() *apiv1beta1.Build
 }
 
 func testContainerBuild(t *testing.T, obj testObject, kind string) {
@@ -227,7 +295,7 @@ func testContainerBuild(t *testing.T, obj testObject, kind string) {
 		assert.NoError(t, err, "getting object")
 		// The following assertion only fails on Github actions for unknown reason, so skip on CI only
 		if os.Getenv("CI") != "true" {
-			assert.True(t, meta.IsStatusConditionTrue(*obj.GetConditions(), apiv1.ConditionBuilt))
+			assert.True(t, meta.IsStatusConditionTrue(*obj.GetConditions(), apiv1beta1.ConditionBuilt))
 		}
 	}, timeout, interval, "waiting for the container to be ready")
 }
@@ -254,6 +322,20 @@ func fakeJobComplete(t *testing.T, job *batchv1.Job) {
 	require.NoError(t, k8sClient.Status().Patch(ctx, updated, client.MergeFrom(job)), "patching the job with completed count")
 }
 
+func fakeJobPreempted(t *testing.T, job *batchv1.Job) {
+	updated := job.DeepCopy()
+	updated.Status.Failed = 1
+	updated.Status.Conditions = []batchv1.JobCondition{
+		{
+			Type:    batchv1.JobFailed,
+			Status:  corev1.ConditionTrue,
+			Reason:  "PodFailurePolicy",
+			Message: "Pod default/test has condition DisruptionTarget matching FailJob rule at index 0",
+		},
+	}
+	require.NoError(t, k8sClient.Status().Patch(ctx, updated, client.MergeFrom(job)), "patching the job with failed status")
+}
+
 func fakePodReady(t *testing.T, pod *corev1.Pod) {
 	updated := pod.DeepCopy()
 	updated.Status.Phase = corev1.PodRunning
@@ -7,7 +7,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
-	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 )
 
 const (
@@ -18,55 +18,89 @@ const (
 	modelDatasetIndex = "spec.dataset.name"
 
 	modelServerModelIndex = "spec.model.name"
+
+	datasetDatasetIndex = "spec.datasets.dataset.name"
+
+	evaluationModelIndex   = "spec.model.name"
+	evaluationDatasetIndex = "spec.dataset.name"
+
+	batchInferenceModelIndex   = "spec.model.name"
+	batchInferenceDatasetIndex = "spec.dataset.name"
 )
 
-func SetupIndexes(mgr manager.Manager) error {
-	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &apiv1.Notebook{}, notebookModelIndex, func(rawObj client.Object) []string {
-		notebook := rawObj.(*apiv1.Notebook)
+// fieldIndex is a field that objects are listed by (with
+// client.MatchingFields).
+type fieldIndex struct {
+	obj     client.Object
+	field   string
+	extract client.IndexerFunc
+}
+
+var fieldIndexes = []fieldIndex{
+	{&apiv1beta1.Notebook{}, notebookModelIndex, func(rawObj client.Object) []string {
+		notebook := rawObj.(*apiv1beta1.Notebook)
 		if notebook.Spec.Model == nil {
 			return []string{}
 		}
 		return []string{notebook.Spec.Model.Name}
-	}); err != nil {
-		return fmt.Errorf("notebook: %w", err)
-	}
-
-	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &apiv1.Notebook{}, notebookDatasetIndex, func(rawObj client.Object) []string {
-		notebook := rawObj.(*apiv1.Notebook)
+	}},
+	{&apiv1beta1.Notebook{}, notebookDatasetIndex, func(rawObj client.Object) []string {
+		notebook := rawObj.(*apiv1beta1.Notebook)
 		if notebook.Spec.Dataset == nil {
 			return []string{}
 		}
 		return []string{notebook.Spec.Dataset.Name}
-	}); err != nil {
-		return fmt.Errorf("notebook: %w", err)
-	}
-
-	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &apiv1.Model{}, modelModelIndex, func(rawObj client.Object) []string {
-		model := rawObj.(*apiv1.Model)
+	}},
+	{&apiv1beta1.Model{}, modelModelIndex, func(rawObj client.Object) []string {
+		model := rawObj.(*apiv1beta1.Model)
 		if model.Spec.Model == nil {
 			return []string{}
 		}
 		return []string{model.Spec.Model.Name}
-	}); err != nil {
-		return fmt.Errorf("model: %w", err)
-	}
-
-	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &apiv1.Model{}, modelDatasetIndex, func(rawObj client.Object) []string {
-		model := rawObj.(*apiv1.Model)
-		if model.Spec.Dataset == nil {
-			return []string{}
+	}},
+	{&apiv1beta1.Model{}, modelDatasetIndex, func(rawObj client.Object) []string {
+		model := rawObj.(*apiv1beta1.Model)
+		names := []string{}
+		for _, d := range model.GetDatasetMounts() {
+			names = append(names, d.Dataset.Name)
 		}
-		return []string{model.Spec.Dataset.Name}
-	}); err != nil {
-		return fmt.Errorf("model: %w", err)
-	}
-
-	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &apiv1.Server{}, modelServerModelIndex, func(rawObj client.Object) []string {
-		server := rawObj.(*apiv1.Server)
+		return names
+	}},
+	{&apiv1beta1.Server{}, modelServerModelIndex, func(rawObj client.Object) []string {
+		server := rawObj.(*apiv1beta1.Server)
 		return []string{server.Spec.Model.Name}
-	}); err != nil {
-		return fmt.Errorf("server: %w", err)
-	}
+	}},
+	{&apiv1beta1.Dataset{}, datasetDatasetIndex, func(rawObj client.Object) []string {
+		dataset := rawObj.(*apiv1beta1.Dataset)
+		names := []string{}
+		for _, d := range dataset.Spec.Datasets {
+			names = append(names, d.Dataset.Name)
+		}
+		return names
+	}},
+	{&apiv1beta1.Evaluation{}, evaluationModelIndex, func(rawObj client.Object) []string {
+		eval := rawObj.(*apiv1beta1.Evaluation)
+		return []string{eval.Spec.Model.Name}
+	}},
+	{&apiv1beta1.Evaluation{}, evaluationDatasetIndex, func(rawObj client.Object) []string {
+		eval := rawObj.(*apiv1beta1.Evaluation)
+		return []string{eval.Spec.Dataset.Name}
+	}},
+	{&apiv1beta1.BatchInference{}, batchInferenceModelIndex, func(rawObj client.Object) []string {
+		bi := rawObj.(*apiv1beta1.BatchInference)
+		return []string{bi.Spec.Model.Name}
+	}},
+	{&apiv1beta1.BatchInference{}, batchInferenceDatasetIndex, func(rawObj client.Object) []string {
+		bi := rawObj.(*apiv1beta1.BatchInference)
+		return []string{bi.Spec.Dataset.Name}
+	}},
+}
 
+func SetupIndexes(mgr manager.Manager) error {
+	for _, idx := range fieldIndexes {
+		if err := mgr.GetFieldIndexer().IndexField(context.Background(), idx.obj, idx.field, idx.extract); err != nil {
+			return fmt.Errorf("%T %v: %w", idx.obj, idx.field, err)
+		}
+	}
 	return nil
 }
@@ -3,11 +3,13 @@ package controller
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -19,7 +21,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 	"github.com/substratusai/substratus/internal/cloud"
 	"github.com/substratusai/substratus/internal/resources"
 	"github.com/substratusai/substratus/internal/sci"
@@ -34,6 +36,22 @@ type ModelReconciler struct {
 
 	Cloud cloud.Cloud
 	SCI   sci.ControllerClient
+
+	// GPUCatalog describes how to schedule GPUs. If nil, the default
+	// catalog is used.
+	GPUCatalog *resources.GPUCatalog
+
+	// ImporterImage is used for Models that are imported using the
+	// built-in importer (see cmd/importer).
+	ImporterImage string
+
+	// ArtifactsManifests enables a Job (running the ImporterImage) that
+	// writes a manifest of sha256 checksums next to the artifacts of ready
+	// Models.
+	ArtifactsManifests bool
+
+	// Artifacts deletes the artifacts of deleted Models.
+	Artifacts *ArtifactsCollector
 }
 
 type ModelReconcilerConfig struct {
@@ -46,12 +64,29 @@ func (r *ModelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	log.Info("Reconciling Model")
 	defer log.Info("Done reconciling Model")
 
-	var model apiv1.Model
+	var model apiv1beta1.Model
 	if err := r.Get(ctx, req.NamespacedName, &model); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	if model.GetImage() == "" {
+	if result, err := reconcileDeletionProtection(ctx, r.Client, &model); !result.success {
+		return result.Result, err
+	}
+	if result, err := r.Artifacts.ReconcileArtifactsFinalizer(ctx, &model); !result.success {
+		return result.Result, err
+	}
+
+	if model.Spec.Artifacts != nil {
+		if result, err := reconcileAdoptedArtifacts(ctx, r.Client, r.SCI, &model, model.Spec.Artifacts.URL); !result.success {
+			return result.Result, err
+		}
+		if result, err := reconcileDownload(ctx, r.Client, r.SCI, &model); !result.success {
+			return result.Result, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if model.Spec.Import == nil && model.GetImage() == "" {
 		// Image must be building.
 		return ctrl.Result{}, nil
 	}
@@ -64,10 +99,24 @@ func (r *ModelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return result.Result, err
 	}
 
+	if result, err := reconcileInventory(ctx, r.Client, r.Scheme, r.Cloud, r.SCI, &model, inventory{
+		kind:               "model",
+		jobName:            model.Name + "-model-inventory",
+		serviceAccountName: modellerServiceAccountName,
+		image:              r.ImporterImage,
+		manifest:           r.ArtifactsManifests,
+	}); !result.success {
+		return result.Result, err
+	}
+
+	if result, err := reconcileDownload(ctx, r.Client, r.SCI, &model); !result.success {
+		return result.Result, err
+	}
+
 	return ctrl.Result{}, nil
 }
 
-func (r *ModelReconciler) reconcileModel(ctx context.Context, model *apiv1.Model) (result, error) {
+func (r *ModelReconciler) reconcileModel(ctx context.Context, model *apiv1beta1.Model) (result, error) {
 	log := log.FromContext(ctx)
 
 	if model.Status.Ready {
@@ -89,17 +138,17 @@ func (r *ModelReconciler) reconcileModel(ctx context.Context, model *apiv1.Model
 		return result, err
 	}
 
-	var baseModel *apiv1.Model
+	var baseModel *apiv1beta1.Model
 	if model.Spec.Model != nil {
-		baseModel = &apiv1.Model{}
+		baseModel = &apiv1beta1.Model{}
 		if err := r.Client.Get(ctx, types.NamespacedName{Namespace: model.Namespace, Name: model.Spec.Model.Name}, baseModel); err != nil {
 			if apierrors.IsNotFound(err) {
 				// Update this Model's status.
 				model.Status.Ready = false
 				meta.SetStatusCondition(&model.Status.Conditions, metav1.Condition{
-					Type:               apiv1.ConditionComplete,
+					Type:               apiv1beta1.ConditionComplete,
 					Status:             metav1.ConditionFalse,
-					Reason:             apiv1.ReasonBaseModelNotFound,
+					Reason:             apiv1beta1.ReasonBaseModelNotFound,
 					ObservedGeneration: model.Generation,
 				})
 				if err := r.Status().Update(ctx, model); err != nil {
@@ -116,9 +165,9 @@ func (r *ModelReconciler) reconcileModel(ctx context.Context, model *apiv1.Model
 			// Update this Model's status.
 			model.Status.Ready = false
 			meta.SetStatusCondition(&model.Status.Conditions, metav1.Condition{
-				Type:               apiv1.ConditionComplete,
+				Type:               apiv1beta1.ConditionComplete,
 				Status:             metav1.ConditionFalse,
-				Reason:             apiv1.ReasonBaseModelNotReady,
+				Reason:             apiv1beta1.ReasonBaseModelNotReady,
 				ObservedGeneration: model.Generation,
 			})
 			if err := r.Status().Update(ctx, model); err != nil {
@@ -130,70 +179,121 @@ func (r *ModelReconciler) reconcileModel(ctx context.Context, model *apiv1.Model
 		}
 	}
 
-	var dataset *apiv1.Dataset
-	if model.Spec.Dataset != nil {
-		dataset = &apiv1.Dataset{}
-		if err := r.Client.Get(ctx, types.NamespacedName{Namespace: model.Namespace, Name: model.Spec.Dataset.Name}, dataset); err != nil {
-			if apierrors.IsNotFound(err) {
-				// Update this Model's status.
-				model.Status.Ready = false
-				meta.SetStatusCondition(&model.Status.Conditions, metav1.Condition{
-					Type:               apiv1.ConditionComplete,
-					Status:             metav1.ConditionFalse,
-					Reason:             apiv1.ReasonDatasetNotFound,
-					ObservedGeneration: model.Generation,
-				})
-				if err := r.Status().Update(ctx, model); err != nil {
-					return result{}, fmt.Errorf("failed to update model status: %w", err)
-				}
+	if msg, err := invalidDatasetMounts(ctx, r.Client, model, model.GetDatasetMounts()); err != nil {
+		return result{}, err
+	} else if msg != "" {
+		log.Info("Invalid dataset mounts", "reason", msg)
+		model.Status.Ready = false
+		meta.SetStatusCondition(&model.Status.Conditions, metav1.Condition{
+			Type:               apiv1beta1.ConditionComplete,
+			Status:             metav1.ConditionFalse,
+			Reason:             apiv1beta1.ReasonDatasetMountInvalid,
+			Message:            msg,
+			ObservedGeneration: model.Generation,
+		})
+		if err := r.Status().Update(ctx, model); err != nil {
+			return result{}, fmt.Errorf("failed to update model status: %w", err)
+		}
 
-				// Allow for watch to requeue.
-				return result{}, nil
-			}
+		// No use in retrying...
+		return result{}, nil
+	}
 
-			return result{}, fmt.Errorf("getting source model: %w", err)
+	datasets, reason, err := getDatasetInputs(ctx, r.Client, model.Namespace, model.GetDatasetMounts())
+	if err != nil {
+		return result{}, err
+	}
+	if reason != "" {
+		// Update this Model's status.
+		model.Status.Ready = false
+		meta.SetStatusCondition(&model.Status.Conditions, metav1.Condition{
+			Type:               apiv1beta1.ConditionComplete,
+			Status:             metav1.ConditionFalse,
+			Reason:             reason,
+			ObservedGeneration: model.Generation,
+		})
+		if err := r.Status().Update(ctx, model); err != nil {
+			return result{}, fmt.Errorf("failed to update model status: %w", err)
 		}
-		if !dataset.Status.Ready {
-			// Update this Model's status.
-			model.Status.Ready = false
-			meta.SetStatusCondition(&model.Status.Conditions, metav1.Condition{
-				Type:               apiv1.ConditionComplete,
-				Status:             metav1.ConditionFalse,
-				Reason:             apiv1.ReasonDatasetNotReady,
-				ObservedGeneration: model.Generation,
-			})
-			if err := r.Status().Update(ctx, model); err != nil {
-				return result{}, fmt.Errorf("failed to update model status: %w", err)
-			}
 
-			// Allow for watch to requeue.
+		// Allow for watch to requeue.
+		return result{}, nil
+	}
+
+	attempt := model.Status.Attempts
+	if attempt == 0 {
+		attempt = 1
+	}
+
+	var modellerJobs []*batchv1.Job
+	for rank := int32(0); rank < model.GetWorkers(); rank++ {
+		job, err := r.modellerJob(ctx, model, baseModel, datasets, attempt, rank)
+		if err != nil {
+			log.Error(err, "unable to construct modeller Job")
+			// No use in retrying...
 			return result{}, nil
 		}
+		modellerJobs = append(modellerJobs, job)
 	}
 
-	modellerJob, err := r.modellerJob(ctx, model, baseModel, dataset)
-	if err != nil {
-		log.Error(err, "unable to construct modeller Job")
-		// No use in retrying...
-		return result{}, nil
+	if jobFailedBeforeCleanup(model.Spec.RunPolicy, model.Status.Conditions, model.Generation) {
+		return result{failure: true}, nil
+	}
+
+	if model.GetWorkers() > 1 {
+		// Headless Service that gives distributed workers stable DNS names
+		// to rendezvous on.
+		service, err := r.modellerService(model)
+		if err != nil {
+			return result{}, fmt.Errorf("constructing modeller service: %w", err)
+		}
+		if err := r.Patch(ctx, service, client.Apply, client.FieldOwner("model-controller")); err != nil {
+			return result{}, fmt.Errorf("applying modeller service: %w", err)
+		}
 	}
 
-	jobResult, err := reconcileJob(ctx, r.Client, modellerJob)
+	model.Status.Attempts = attempt
+
+	jobResult, err := reconcileJobGroup(ctx, r.Client, modellerJobs)
 	if !jobResult.success {
 		model.Status.Ready = false
+		if jobResult.failure && model.Spec.Resume != nil {
+			if msg, preempted := jobPreempted(modellerJobs...); preempted && attempt < maxAttempts(model.Spec.Resume) {
+				log.Info("Modeller Job was preempted, starting a new attempt", "attempt", attempt+1)
+				model.Status.Attempts = attempt + 1
+				model.Status.Preemptions = append(model.Status.Preemptions, apiv1beta1.Preemption{
+					Attempt: attempt,
+					Time:    metav1.Now(),
+					Message: msg,
+				})
+				meta.SetStatusCondition(model.GetConditions(), metav1.Condition{
+					Type:               apiv1beta1.ConditionComplete,
+					Status:             metav1.ConditionFalse,
+					Reason:             apiv1beta1.ReasonJobPreempted,
+					ObservedGeneration: model.Generation,
+					Message:            fmt.Sprintf("Attempt %d was preempted, resuming from checkpoint", attempt),
+				})
+				if err := r.Status().Update(ctx, model); err != nil {
+					return result{}, fmt.Errorf("updating status: %w", err)
+				}
+				return result{Result: ctrl.Result{Requeue: true}}, nil
+			}
+		}
 		if !jobResult.failure {
+			r.reconcileProgress(ctx, model)
+			jobResult.RequeueAfter = progressInterval
 			meta.SetStatusCondition(model.GetConditions(), metav1.Condition{
-				Type:               apiv1.ConditionComplete,
+				Type:               apiv1beta1.ConditionComplete,
 				Status:             metav1.ConditionFalse,
-				Reason:             apiv1.ReasonJobNotComplete,
+				Reason:             apiv1beta1.ReasonJobNotComplete,
 				ObservedGeneration: model.Generation,
 				Message:            "Waiting for modeller Job to complete",
 			})
 		} else {
 			meta.SetStatusCondition(model.GetConditions(), metav1.Condition{
-				Type:               apiv1.ConditionComplete,
+				Type:               apiv1beta1.ConditionComplete,
 				Status:             metav1.ConditionFalse,
-				Reason:             apiv1.ReasonJobFailed,
+				Reason:             apiv1beta1.ReasonJobFailed,
 				ObservedGeneration: model.Generation,
 			})
 		}
@@ -205,9 +305,9 @@ func (r *ModelReconciler) reconcileModel(ctx context.Context, model *apiv1.Model
 
 	model.Status.Ready = true
 	meta.SetStatusCondition(model.GetConditions(), metav1.Condition{
-		Type:               apiv1.ConditionComplete,
+		Type:               apiv1beta1.ConditionComplete,
 		Status:             metav1.ConditionTrue,
-		Reason:             apiv1.ReasonJobComplete,
+		Reason:             apiv1beta1.ReasonJobComplete,
 		ObservedGeneration: model.Generation,
 	})
 	if err := r.Status().Update(ctx, model); err != nil {
@@ -223,21 +323,25 @@ func (r *ModelReconciler) reconcileModel(ctx context.Context, model *apiv1.Model
 //+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch
 //+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ModelReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&apiv1.Model{}).
-		Watches(&apiv1.Model{}, handler.EnqueueRequestsFromMapFunc(handler.MapFunc(r.findModelsForBaseModel))).
-		Watches(&apiv1.Dataset{}, handler.EnqueueRequestsFromMapFunc(handler.MapFunc(r.findModelsForDataset))).
+		For(&apiv1beta1.Model{}).
+		Watches(&apiv1beta1.Model{}, handler.EnqueueRequestsFromMapFunc(handler.MapFunc(r.findModelsForBaseModel))).
+		Watches(&apiv1beta1.Dataset{}, handler.EnqueueRequestsFromMapFunc(handler.MapFunc(r.findModelsForDataset))).
+		Watches(&apiv1beta1.BatchInference{}, handler.EnqueueRequestsFromMapFunc(handler.MapFunc(r.findModelsForDataset))).
 		Owns(&batchv1.Job{}).
+		Owns(&corev1.Service{}).
 		Complete(r)
 }
 
 func (r *ModelReconciler) findModelsForBaseModel(ctx context.Context, obj client.Object) []reconcile.Request {
-	model := obj.(*apiv1.Model)
+	model := obj.(*apiv1beta1.Model)
 
-	var models apiv1.ModelList
+	var models apiv1beta1.ModelList
 	if err := r.List(ctx, &models,
 		client.MatchingFields{modelModelIndex: model.Name},
 		client.InNamespace(obj.GetNamespace()),
@@ -259,11 +363,9 @@ func (r *ModelReconciler) findModelsForBaseModel(ctx context.Context, obj client
 }
 
 func (r *ModelReconciler) findModelsForDataset(ctx context.Context, obj client.Object) []reconcile.Request {
-	dataset := obj.(*apiv1.Dataset)
-
-	var models apiv1.ModelList
+	var models apiv1beta1.ModelList
 	if err := r.List(ctx, &models,
-		client.MatchingFields{modelDatasetIndex: dataset.Name},
+		client.MatchingFields{modelDatasetIndex: obj.GetName()},
 		client.InNamespace(obj.GetNamespace()),
 	); err != nil {
 		log.Log.Error(err, "unable to list models for dataset")
@@ -282,19 +384,41 @@ func (r *ModelReconciler) findModelsForDataset(ctx context.Context, obj client.O
 	return reqs
 }
 
-// modellerJob returns a Job that will train or load the Model.
-func (r *ModelReconciler) modellerJob(ctx context.Context, model, baseModel *apiv1.Model, dataset *apiv1.Dataset) (*batchv1.Job, error) {
+// modellerJob returns a Job that will train or load the Model. When the Model
+// is trained by multiple workers, a Job is created for every rank.
+func (r *ModelReconciler) modellerJob(ctx context.Context, model, baseModel *apiv1beta1.Model, datasets []datasetInput, attempt, rank int32) (*batchv1.Job, error) {
 	var job *batchv1.Job
 
 	envVars, err := resolveEnv(model.Spec.Env)
 	if err != nil {
 		return nil, fmt.Errorf("resolving env: %w", err)
 	}
+	if model.Spec.Resume != nil {
+		envVars = append(envVars,
+			corev1.EnvVar{Name: "ATTEMPT", Value: fmt.Sprint(attempt)},
+			corev1.EnvVar{Name: "RESUME_FROM_CHECKPOINT", Value: fmt.Sprint(attempt > 1)},
+		)
+	}
+	workers := model.GetWorkers()
+	if workers > 1 {
+		// Compatible with torchrun and accelerate.
+		envVars = append(envVars,
+			corev1.EnvVar{Name: "MASTER_ADDR", Value: modellerWorkerName(model, attempt, 0) + "." + modellerServiceName(model)},
+			corev1.EnvVar{Name: "MASTER_PORT", Value: fmt.Sprint(modellerRendezvousPort)},
+			corev1.EnvVar{Name: "WORLD_SIZE", Value: fmt.Sprint(workers)},
+			corev1.EnvVar{Name: "RANK", Value: fmt.Sprint(rank)},
+			corev1.EnvVar{Name: "NODE_RANK", Value: fmt.Sprint(rank)},
+		)
+	}
 
-	// Don't retry expensive Jobs by default.
+	// Don't retry expensive Jobs by default (can be overridden using
+	// .spec.runPolicy.retries).
 	var backoffLimit int32
-	if model.Spec.Resources != nil &&
-		model.Spec.Resources.CPU <= 3 &&
+	if model.Spec.Import != nil {
+		// Built-in imports resume where they left off.
+		backoffLimit = 2
+	} else if model.Spec.Resources != nil &&
+		(model.Spec.Resources.CPU == nil || model.Spec.Resources.CPU.Cmp(resource.MustParse("3")) <= 0) &&
 		model.Spec.Resources.GPU != nil &&
 		model.Spec.Resources.GPU.Count == 0 {
 		// If the Job is not super expensive (No GPUs, low CPUs), then assume
@@ -305,12 +429,11 @@ func (r *ModelReconciler) modellerJob(ctx context.Context, model, baseModel *api
 	const containerName = "model"
 	job = &batchv1.Job{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: model.Name + "-modeller",
+			Name: modellerWorkerName(model, attempt, rank),
 			// Cross-Namespace owners not allowed, must be same as model:
 			Namespace: model.Namespace,
 		},
 		Spec: batchv1.JobSpec{
-			// TODO: Allow for configurable retries for Jobs that import models...
 			BackoffLimit: ptr.To(backoffLimit),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
@@ -341,6 +464,31 @@ func (r *ModelReconciler) modellerJob(ctx context.Context, model, baseModel *api
 		},
 	}
 
+	if workers > 1 {
+		job.Spec.Template.Spec.Hostname = job.Name
+		job.Spec.Template.Spec.Subdomain = modellerServiceName(model)
+	}
+
+	applyRunPolicy(job, model.Spec.RunPolicy)
+
+	if model.Spec.Resume != nil {
+		// Fail the Job as soon as the Pod is disrupted (instead of using
+		// up retries) so that a new attempt can be started. User-specified
+		// rules come first and take precedence.
+		var rules []batchv1.PodFailurePolicyRule
+		if job.Spec.PodFailurePolicy != nil {
+			rules = append(rules, job.Spec.PodFailurePolicy.Rules...)
+		}
+		job.Spec.PodFailurePolicy = &batchv1.PodFailurePolicy{
+			Rules: append(rules, batchv1.PodFailurePolicyRule{
+				Action: batchv1.PodFailurePolicyActionFailJob,
+				OnPodConditions: []batchv1.PodFailurePolicyOnPodConditionsPattern{
+					{Type: corev1.DisruptionTarget, Status: corev1.ConditionTrue},
+				},
+			}),
+		}
+	}
+
 	if err := mountParamsConfigMap(&job.Spec.Template.Spec, model, containerName); err != nil {
 		return nil, fmt.Errorf("mounting params configmap: %w", err)
 	}
@@ -351,21 +499,19 @@ func (r *ModelReconciler) modellerJob(ctx context.Context, model, baseModel *api
 			{BucketSubdir: "artifacts", ContentSubdir: "artifacts"},
 		},
 		Container: containerName,
-		ReadOnly:  false,
+		// Only the first rank writes artifacts.
+		ReadOnly: rank > 0,
 	}); err != nil {
 		return nil, fmt.Errorf("mounting model: %w", err)
 	}
 
-	if dataset != nil {
-		if err := r.Cloud.MountBucket(&job.Spec.Template.ObjectMeta, &job.Spec.Template.Spec, dataset, cloud.MountBucketConfig{
-			Name: "dataset",
-			Mounts: []cloud.BucketMount{
-				{BucketSubdir: "artifacts", ContentSubdir: "data"},
-			},
-			Container: containerName,
-			ReadOnly:  true,
-		}); err != nil {
-			return nil, fmt.Errorf("mounting dataset: %w", err)
+	if err := mountDatasetInputs(r.Cloud, &job.Spec.Template.ObjectMeta, &job.Spec.Template.Spec, containerName, datasets); err != nil {
+		return nil, err
+	}
+
+	if model.Spec.Import != nil {
+		if err := applyImport(r.Cloud, r.ImporterImage, &job.Spec.Template.ObjectMeta, &job.Spec.Template.Spec, containerName, model, model.Spec.Import, "model"); err != nil {
+			return nil, fmt.Errorf("applying import: %w", err)
 		}
 	}
 
@@ -387,9 +533,89 @@ func (r *ModelReconciler) modellerJob(ctx context.Context, model, baseModel *api
 	}
 
 	if err := resources.Apply(&job.Spec.Template.ObjectMeta, &job.Spec.Template.Spec, containerName,
-		r.Cloud.Name(), model.Spec.Resources); err != nil {
+		r.Cloud.Name(), r.GPUCatalog, model.Spec.Resources, model.Spec.Scheduling); err != nil {
 		return nil, fmt.Errorf("applying resources: %w", err)
 	}
 
 	return job, nil
 }
+
+// modellerJobName returns the name of the modeller Job for a given attempt.
+// The first attempt keeps the original name.
+func modellerJobName(model *apiv1beta1.Model, attempt int32) string {
+	if attempt <= 1 {
+		return model.Name + "-modeller"
+	}
+	return fmt.Sprintf("%s-modeller-%d", model.Name, attempt)
+}
+
+// modellerWorkerName returns the name of the modeller Job (and Pod hostname)
+// for a given rank.
+func modellerWorkerName(model *apiv1beta1.Model, attempt, rank int32) string {
+	if rank == 0 {
+		return modellerJobName(model, attempt)
+	}
+	return fmt.Sprintf("%s-worker-%d", modellerJobName(model, attempt), rank)
+}
+
+const modellerRendezvousPort = 29500
+
+func modellerServiceName(model *apiv1beta1.Model) string {
+	return model.Name + "-modeller"
+}
+
+func (r *ModelReconciler) modellerService(model *apiv1beta1.Model) (*corev1.Service, error) {
+	s := &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      modellerServiceName(model),
+			Namespace: model.Namespace,
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: corev1.ClusterIPNone,
+			Selector: map[string]string{
+				"model": model.Name,
+				"role":  "run",
+			},
+			// Workers need to resolve each other before they are ready.
+			PublishNotReadyAddresses: true,
+			Ports: []corev1.ServicePort{
+				{
+					Name:     "rendezvous",
+					Protocol: corev1.ProtocolTCP,
+					Port:     modellerRendezvousPort,
+				},
+			},
+		},
+	}
+
+	if err := ctrl.SetControllerReference(model, s, r.Scheme); err != nil {
+		return nil, fmt.Errorf("failed to set controller reference: %w", err)
+	}
+
+	return s, nil
+}
+
+func maxAttempts(policy *apiv1beta1.ResumePolicy) int32 {
+	if policy.MaxAttempts == 0 {
+		return 5
+	}
+	return policy.MaxAttempts
+}
+
+// jobPreempted reports whether any of the given Jobs failed because its Pod
+// was disrupted (for example by spot node preemption).
+func jobPreempted(jobs ...*batchv1.Job) (string, bool) {
+	for _, job := range jobs {
+		for _, c := range job.Status.Conditions {
+			if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue &&
+				c.Reason == "PodFailurePolicy" && strings.Contains(c.Message, string(corev1.DisruptionTarget)) {
+				return c.Message, true
+			}
+		}
+	}
+	return "", false
+}
@@ -1,6 +1,8 @@
 package controller_test
 
 import (
+	"crypto/md5"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -8,26 +10,29 @@ import (
 	"github.com/stretchr/testify/require"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+	"github.com/substratusai/substratus/internal/sci"
 )
 
 func TestModelLoaderFromGit(t *testing.T) {
 	name := strings.ToLower(t.Name())
 
-	model := &apiv1.Model{
+	model := &apiv1beta1.Model{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name + "-mdl",
 			Namespace: "default",
 		},
-		Spec: apiv1.ModelSpec{
-			Build: &apiv1.Build{
-				Git: &apiv1.BuildGit{
+		Spec: apiv1beta1.ModelSpec{
+			Build: &apiv1beta1.Build{
+				Git: &apiv1beta1.BuildGit{
 					URL: "https://test.internal/test/model-loader.git",
 				},
 			},
@@ -45,7 +50,7 @@ func TestModelLoaderFromGit(t *testing.T) {
 	testModelLoad(t, model)
 }
 
-func testModelLoad(t *testing.T, model *apiv1.Model) {
+func testModelLoad(t *testing.T, model *apiv1beta1.Model) {
 	// Test that a container loader Job gets created by the controller.
 	var loaderJob batchv1.Job
 	require.EventuallyWithT(t, func(t *assert.CollectT) {
@@ -59,7 +64,7 @@ func testModelLoad(t *testing.T, model *apiv1.Model) {
 	require.EventuallyWithT(t, func(t *assert.CollectT) {
 		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: model.GetNamespace(), Name: model.GetName()}, model)
 		assert.NoError(t, err, "getting model")
-		assert.True(t, meta.IsStatusConditionTrue(model.Status.Conditions, apiv1.ConditionComplete))
+		assert.True(t, meta.IsStatusConditionTrue(model.Status.Conditions, apiv1beta1.ConditionComplete))
 		assert.True(t, model.Status.Ready)
 	}, timeout, interval, "waiting for the model to be ready")
 	require.Contains(t, model.Status.Artifacts.URL, "gs://test-artifact-bucket")
@@ -68,12 +73,12 @@ func testModelLoad(t *testing.T, model *apiv1.Model) {
 func TestModelTrainerFromGit(t *testing.T) {
 	name := strings.ToLower(t.Name())
 
-	baseModel := &apiv1.Model{
+	baseModel := &apiv1beta1.Model{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name + "-base-mdl",
 			Namespace: "default",
 		},
-		Spec: apiv1.ModelSpec{
+		Spec: apiv1beta1.ModelSpec{
 			Image: ptr.To("some-test-image"),
 		},
 	}
@@ -83,12 +88,12 @@ func TestModelTrainerFromGit(t *testing.T) {
 
 	testModelLoad(t, baseModel)
 
-	dataset := &apiv1.Dataset{
+	dataset := &apiv1beta1.Dataset{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name + "-ds",
 			Namespace: "default",
 		},
-		Spec: apiv1.DatasetSpec{
+		Spec: apiv1beta1.DatasetSpec{
 			Image: ptr.To("some-image"),
 		},
 	}
@@ -98,22 +103,22 @@ func TestModelTrainerFromGit(t *testing.T) {
 
 	testDatasetLoad(t, dataset)
 
-	trainedModel := &apiv1.Model{
+	trainedModel := &apiv1beta1.Model{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name + "-trained-mdl",
 			Namespace: baseModel.Namespace,
 		},
-		Spec: apiv1.ModelSpec{
+		Spec: apiv1beta1.ModelSpec{
 			Command: []string{"model.sh"},
-			Build: &apiv1.Build{
-				Git: &apiv1.BuildGit{
+			Build: &apiv1beta1.Build{
+				Git: &apiv1beta1.BuildGit{
 					URL: "https://test.com/test/test",
 				},
 			},
-			Model: &apiv1.ObjectRef{
+			Model: &apiv1beta1.ObjectRef{
 				Name: baseModel.Name,
 			},
-			Dataset: &apiv1.ObjectRef{
+			Dataset: &apiv1beta1.DatasetRef{
 				Name: dataset.Name,
 			},
 		},
@@ -129,7 +134,7 @@ func TestModelTrainerFromGit(t *testing.T) {
 	testModelTrain(t, trainedModel)
 }
 
-func testModelTrain(t *testing.T, model *apiv1.Model) {
+func testModelTrain(t *testing.T, model *apiv1beta1.Model) {
 	// Test that a model trainer ServiceAccount gets created by the controller.
 	var sa corev1.ServiceAccount
 	require.EventuallyWithT(t, func(t *assert.CollectT) {
@@ -152,8 +157,362 @@ func testModelTrain(t *testing.T, model *apiv1.Model) {
 	require.EventuallyWithT(t, func(t *assert.CollectT) {
 		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: model.GetNamespace(), Name: model.GetName()}, model)
 		assert.NoError(t, err, "getting model")
-		assert.True(t, meta.IsStatusConditionTrue(model.Status.Conditions, apiv1.ConditionComplete))
+		assert.True(t, meta.IsStatusConditionTrue(model.Status.Conditions, apiv1beta1.ConditionComplete))
 		assert.True(t, model.Status.Ready)
 	}, timeout, interval, "waiting for the model to be ready")
 	require.Contains(t, model.Status.Artifacts.URL, "gs://test-artifact-bucket")
 }
+
+func TestModelResumeAfterPreemption(t *testing.T) {
+	name := strings.ToLower(t.Name())
+
+	model := &apiv1beta1.Model{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-mdl",
+			Namespace: "default",
+		},
+		Spec: apiv1beta1.ModelSpec{
+			Image:  ptr.To("some-test-image"),
+			Resume: &apiv1beta1.ResumePolicy{MaxAttempts: 2},
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, model), "create a model with resume enabled")
+	t.Cleanup(debugObject(t, model))
+
+	var firstJob batchv1.Job
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: model.Namespace, Name: model.Name + "-modeller"}, &firstJob)
+		assert.NoError(t, err, "getting the first modeller job")
+	}, timeout, interval, "waiting for the first modeller job to be created")
+	require.Contains(t, firstJob.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "RESUME_FROM_CHECKPOINT", Value: "false"})
+
+	fakeJobPreempted(t, &firstJob)
+
+	var secondJob batchv1.Job
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: model.Namespace, Name: model.Name + "-modeller-2"}, &secondJob)
+		assert.NoError(t, err, "getting the second modeller job")
+	}, timeout, interval, "waiting for the second modeller job to be created")
+	require.Contains(t, secondJob.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "RESUME_FROM_CHECKPOINT", Value: "true"})
+	require.Contains(t, secondJob.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "ATTEMPT", Value: "2"})
+
+	fakeJobComplete(t, &secondJob)
+
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(model), model)
+		assert.NoError(t, err, "getting model")
+		assert.True(t, model.Status.Ready)
+	}, timeout, interval, "waiting for the model to be ready")
+	require.Equal(t, int32(2), model.Status.Attempts)
+	require.Len(t, model.Status.Preemptions, 1)
+	require.Equal(t, int32(1), model.Status.Preemptions[0].Attempt)
+}
+
+func TestModelDistributedTraining(t *testing.T) {
+	name := strings.ToLower(t.Name())
+
+	model := &apiv1beta1.Model{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-mdl",
+			Namespace: "default",
+		},
+		Spec: apiv1beta1.ModelSpec{
+			Image:   ptr.To("some-test-image"),
+			Workers: ptr.To(int32(2)),
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, model), "create a model with multiple workers")
+	t.Cleanup(debugObject(t, model))
+
+	var svc corev1.Service
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: model.Namespace, Name: model.Name + "-modeller"}, &svc)
+		assert.NoError(t, err, "getting the modeller service")
+	}, timeout, interval, "waiting for the modeller service to be created")
+	require.Equal(t, corev1.ClusterIPNone, svc.Spec.ClusterIP)
+
+	jobs := make([]batchv1.Job, 2)
+	for rank, jobName := range []string{model.Name + "-modeller", model.Name + "-modeller-worker-1"} {
+		require.EventuallyWithT(t, func(t *assert.CollectT) {
+			err := k8sClient.Get(ctx, types.NamespacedName{Namespace: model.Namespace, Name: jobName}, &jobs[rank])
+			assert.NoError(t, err, "getting the modeller job")
+		}, timeout, interval, "waiting for the modeller job to be created")
+
+		podSpec := jobs[rank].Spec.Template.Spec
+		require.Equal(t, jobName, podSpec.Hostname)
+		require.Equal(t, svc.Name, podSpec.Subdomain)
+		require.Contains(t, podSpec.Containers[0].Env, corev1.EnvVar{Name: "MASTER_ADDR", Value: model.Name + "-modeller." + svc.Name})
+		require.Contains(t, podSpec.Containers[0].Env, corev1.EnvVar{Name: "WORLD_SIZE", Value: "2"})
+		require.Contains(t, podSpec.Containers[0].Env, corev1.EnvVar{Name: "NODE_RANK", Value: fmt.Sprint(rank)})
+	}
+
+	for i := range jobs {
+		fakeJobComplete(t, &jobs[i])
+	}
+
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(model), model)
+		assert.NoError(t, err, "getting model")
+		assert.True(t, model.Status.Ready)
+	}, timeout, interval, "waiting for the model to be ready")
+}
+
+func TestModelMultipleDatasets(t *testing.T) {
+	name := strings.ToLower(t.Name())
+
+	var datasets []*apiv1beta1.Dataset
+	for _, split := range []string{"train", "validation"} {
+		dataset := &apiv1beta1.Dataset{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name + "-" + split,
+				Namespace: "default",
+			},
+			Spec: apiv1beta1.DatasetSpec{
+				Image: ptr.To("some-dataset-image"),
+			},
+		}
+		require.NoError(t, k8sClient.Create(ctx, dataset), "create a dataset")
+		t.Cleanup(debugObject(t, dataset))
+		datasets = append(datasets, dataset)
+	}
+
+	model := &apiv1beta1.Model{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-mdl",
+			Namespace: "default",
+		},
+		Spec: apiv1beta1.ModelSpec{
+			Image: ptr.To("some-image"),
+			Datasets: []apiv1beta1.DatasetMount{
+				{Name: "train", Dataset: apiv1beta1.DatasetRef{Name: datasets[0].Name}},
+				{Name: "validation", Dataset: apiv1beta1.DatasetRef{Name: datasets[1].Name}},
+			},
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, model), "create a model with multiple datasets")
+	t.Cleanup(debugObject(t, model))
+
+	// The model should wait for every dataset.
+	testDatasetLoad(t, datasets[0])
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(model), model)
+		assert.NoError(t, err, "getting the model")
+		cond := meta.FindStatusCondition(model.Status.Conditions, apiv1beta1.ConditionComplete)
+		if assert.NotNil(t, cond) {
+			assert.Equal(t, apiv1beta1.ReasonDatasetNotReady, cond.Reason)
+		}
+	}, timeout, interval, "waiting for the model to wait for the validation dataset")
+	testDatasetLoad(t, datasets[1])
+
+	var job batchv1.Job
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: model.Namespace, Name: model.Name + "-modeller"}, &job)
+		assert.NoError(t, err, "getting the model training job")
+	}, timeout, interval, "waiting for the model training job to be created")
+
+	mountPaths := map[string]bool{}
+	for _, m := range job.Spec.Template.Spec.Containers[0].VolumeMounts {
+		mountPaths[m.MountPath] = true
+	}
+	require.True(t, mountPaths["/content/data/train"], "train dataset should be mounted")
+	require.True(t, mountPaths["/content/data/validation"], "validation dataset should be mounted")
+}
+
+func TestModelAdoptArtifacts(t *testing.T) {
+	name := strings.ToLower(t.Name())
+
+	missing := &apiv1beta1.Model{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-missing",
+			Namespace: "default",
+		},
+		Spec: apiv1beta1.ModelSpec{
+			Artifacts: &apiv1beta1.ArtifactsSpec{URL: "gs://existing-bucket/" + name + "/missing"},
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, missing), "create a model with missing artifacts")
+	t.Cleanup(debugObject(t, missing))
+
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(missing), missing)
+		assert.NoError(t, err, "getting the model")
+		cond := meta.FindStatusCondition(missing.Status.Conditions, apiv1beta1.ConditionComplete)
+		if assert.NotNil(t, cond) {
+			assert.Equal(t, apiv1beta1.ReasonArtifactsNotFound, cond.Reason)
+		}
+	}, timeout, interval, "waiting for the model to report missing artifacts")
+	require.False(t, missing.Status.Ready)
+
+	sciClient.SetObject("existing-bucket", name+"/weights/artifacts/model.bin", []byte("weights"))
+	model := &apiv1beta1.Model{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: apiv1beta1.ModelSpec{
+			Artifacts: &apiv1beta1.ArtifactsSpec{URL: "gs://existing-bucket/" + name + "/weights"},
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, model), "create a model with existing artifacts")
+	t.Cleanup(debugObject(t, model))
+
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(model), model)
+		assert.NoError(t, err, "getting the model")
+		assert.True(t, model.Status.Ready)
+	}, timeout, interval, "waiting for the model to be ready")
+	require.Equal(t, "gs://existing-bucket/"+name+"/weights", model.Status.Artifacts.URL)
+	require.Equal(t, int64(len("weights")), model.Status.Artifacts.SizeBytes)
+	require.Equal(t, int64(1), model.Status.Artifacts.FileCount)
+	require.Empty(t, model.Status.Artifacts.ManifestURL)
+	require.True(t, meta.IsStatusConditionTrue(model.Status.Conditions, apiv1beta1.ConditionComplete))
+
+	// No Job should be run for adopted artifacts.
+	var jobs batchv1.JobList
+	require.NoError(t, k8sClient.List(ctx, &jobs, client.InNamespace(model.Namespace), client.MatchingLabels{"model": model.Name}))
+	require.Empty(t, jobs.Items)
+}
+
+func TestModelArtifactsGarbageCollection(t *testing.T) {
+	name := strings.ToLower(t.Name())
+	const bucket = "test-artifact-bucket"
+	// Mirrors the hash that the cloud uses for the artifact URL of a Model.
+	prefix := func(model string) string {
+		return fmt.Sprintf("%x/", md5.Sum([]byte("clusters/test-cluster-name/namespaces/default/models/"+model)))
+	}
+	objects := func(prefix string) []*sci.ObjectInfo {
+		resp, err := sciClient.ListObjects(ctx, &sci.ListObjectsRequest{BucketName: bucket, Prefix: prefix})
+		require.NoError(t, err)
+		return resp.Objects
+	}
+
+	sciClient.SetObject("existing-bucket", name+"/artifacts/model.bin", []byte("weights"))
+	newModel := func(name string) *apiv1beta1.Model {
+		return &apiv1beta1.Model{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: apiv1beta1.ModelSpec{
+				Artifacts: &apiv1beta1.ArtifactsSpec{URL: "gs://existing-bucket/" + strings.TrimSuffix(name, "-retained")},
+			},
+		}
+	}
+	deleteModel := func(model *apiv1beta1.Model) {
+		require.EventuallyWithT(t, func(t *assert.CollectT) {
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(model), model)
+			assert.NoError(t, err, "getting the model")
+			assert.Contains(t, model.Finalizers, "substratus.ai/artifacts")
+		}, timeout, interval, "waiting for the finalizer to be added")
+		require.NoError(t, k8sClient.Delete(ctx, model))
+		require.EventuallyWithT(t, func(t *assert.CollectT) {
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(model), model)
+			assert.True(t, apierrors.IsNotFound(err), "model should be deleted")
+		}, timeout, interval, "waiting for the model to be deleted")
+	}
+
+	model := newModel(name)
+	sciClient.SetObject(bucket, prefix(model.Name)+"uploads/latest.tar.gz", []byte("upload"))
+	require.NoError(t, k8sClient.Create(ctx, model), "create a model")
+	t.Cleanup(debugObject(t, model))
+	deleteModel(model)
+	require.Empty(t, objects(prefix(model.Name)), "artifacts should be deleted along with the model")
+	require.NotEmpty(t, objects(name+"/"), "adopted artifacts should never be deleted")
+
+	retained := newModel(name + "-retained")
+	retained.Annotations = map[string]string{apiv1beta1.RetainArtifactsAnnotation: "true"}
+	sciClient.SetObject(bucket, prefix(retained.Name)+"uploads/latest.tar.gz", []byte("upload"))
+	require.NoError(t, k8sClient.Create(ctx, retained), "create a model that retains its artifacts")
+	t.Cleanup(debugObject(t, retained))
+	deleteModel(retained)
+	require.NotEmpty(t, objects(prefix(retained.Name)), "retained artifacts should be kept")
+
+	// Removing the finalizer by hand leaves the artifacts behind.
+	orphan := newModel(name + "-orphan")
+	sciClient.SetObject(bucket, prefix(orphan.Name)+"artifacts/model.bin", []byte("weights"))
+	require.NoError(t, k8sClient.Create(ctx, orphan), "create a model that is orphaned")
+	t.Cleanup(debugObject(t, orphan))
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(orphan), orphan)
+		assert.NoError(t, err, "getting the model")
+		assert.Contains(t, orphan.Finalizers, "substratus.ai/artifacts")
+	}, timeout, interval, "waiting for the finalizer to be added")
+	orphan.Finalizers = nil
+	require.NoError(t, k8sClient.Update(ctx, orphan), "remove the finalizers")
+	require.NoError(t, k8sClient.Delete(ctx, orphan))
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(orphan), orphan)
+		assert.True(t, apierrors.IsNotFound(err), "model should be deleted")
+	}, timeout, interval, "waiting for the model to be deleted")
+	require.NotEmpty(t, objects(prefix(orphan.Name)))
+
+	// Prefixes that were not recorded by this cluster are never swept.
+	foreign := prefix(name + "-other-cluster")
+	sciClient.SetObject(bucket, foreign+"artifacts/model.bin", []byte("weights"))
+
+	require.NoError(t, artifactsCollector.Sweep(ctx))
+	require.NotEmpty(t, objects(prefix(orphan.Name)), "orphans should survive the first sweep")
+	// The cache might still see the deleted model during the first sweep.
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		assert.NoError(t, artifactsCollector.Sweep(ctx))
+		assert.Empty(t, objects(prefix(orphan.Name)))
+	}, timeout, interval, "waiting for the orphaned artifacts to be swept")
+	require.NotEmpty(t, objects(prefix(retained.Name)), "retained artifacts should never be swept")
+	require.NotEmpty(t, objects(foreign), "unrecorded prefixes should never be swept")
+}
+
+func TestModelDeletionProtection(t *testing.T) {
+	name := strings.ToLower(t.Name())
+
+	sciClient.SetObject("existing-bucket", name+"/artifacts/model.bin", []byte("weights"))
+	model := &apiv1beta1.Model{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: apiv1beta1.ModelSpec{
+			Artifacts: &apiv1beta1.ArtifactsSpec{URL: "gs://existing-bucket/" + name},
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, model), "create a model")
+	t.Cleanup(debugObject(t, model))
+
+	server := &apiv1beta1.Server{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-srv",
+			Namespace: "default",
+		},
+		Spec: apiv1beta1.ServerSpec{
+			Image: ptr.To("some-image"),
+			Model: apiv1beta1.ObjectRef{
+				Name: model.Name,
+			},
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, server), "create a server that references the model")
+	t.Cleanup(debugObject(t, server))
+
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(model), model)
+		assert.NoError(t, err, "getting the model")
+		assert.Contains(t, model.Finalizers, "substratus.ai/deletion-protection")
+	}, timeout, interval, "waiting for the finalizer to be added")
+	require.NoError(t, k8sClient.Delete(ctx, model))
+
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(model), model)
+		assert.NoError(t, err, "getting the model")
+		cond := meta.FindStatusCondition(model.Status.Conditions, apiv1beta1.ConditionDeletionBlocked)
+		if assert.NotNil(t, cond) {
+			assert.Equal(t, apiv1beta1.ReasonReferenced, cond.Reason)
+			assert.Contains(t, cond.Message, "servers/"+server.Name)
+		}
+	}, timeout, interval, "waiting for the deletion to be blocked")
+
+	model.Annotations = map[string]string{apiv1beta1.ForceDeleteAnnotation: "true"}
+	require.NoError(t, k8sClient.Update(ctx, model), "force the deletion")
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(model), model)
+		assert.True(t, apierrors.IsNotFound(err), "model should be deleted")
+	}, timeout, interval, "waiting for the model to be deleted")
+}
@@ -0,0 +1,324 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+)
+
+// modelSweepLabel is set on the Models created by a ModelSweep.
+const modelSweepLabel = "modelsweep"
+
+// maxSweepTrials bounds the number of trials of a ModelSweep (see the
+// validation of .spec.maxTrials).
+const maxSweepTrials = 1000
+
+// ModelSweepReconciler reconciles a ModelSweep object.
+type ModelSweepReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=substratus.ai,resources=modelsweeps,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=substratus.ai,resources=modelsweeps/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=substratus.ai,resources=modelsweeps/finalizers,verbs=update
+//+kubebuilder:rbac:groups=substratus.ai,resources=models,verbs=get;list;watch;create;update;patch;delete
+
+func (r *ModelSweepReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	log.Info("Reconciling ModelSweep")
+	defer log.Info("Done reconciling ModelSweep")
+
+	var sweep apiv1beta1.ModelSweep
+	if err := r.Get(ctx, req.NamespacedName, &sweep); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if result, err := r.reconcileSweep(ctx, &sweep); !result.success {
+		return result.Result, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ModelSweepReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&apiv1beta1.ModelSweep{}).
+		Owns(&apiv1beta1.Model{}).
+		Complete(r)
+}
+
+func (r *ModelSweepReconciler) reconcileSweep(ctx context.Context, sweep *apiv1beta1.ModelSweep) (result, error) {
+	log := log.FromContext(ctx)
+
+	var models apiv1beta1.ModelList
+	if err := r.List(ctx, &models,
+		client.InNamespace(sweep.Namespace),
+		client.MatchingLabels{modelSweepLabel: sweep.Name},
+	); err != nil {
+		return result{}, fmt.Errorf("listing models: %w", err)
+	}
+	existing := make(map[string]*apiv1beta1.Model, len(models.Items))
+	for i := range models.Items {
+		existing[models.Items[i].Name] = &models.Items[i]
+	}
+
+	parallelism := 1
+	if sweep.Spec.Parallelism != nil {
+		parallelism = int(*sweep.Spec.Parallelism)
+	}
+
+	trials, ok := sweepTrialCount(sweep)
+	if !ok {
+		sweep.Status.Ready = false
+		meta.SetStatusCondition(sweep.GetConditions(), metav1.Condition{
+			Type:               apiv1beta1.ConditionComplete,
+			Status:             metav1.ConditionFalse,
+			Reason:             apiv1beta1.ReasonTooManyTrials,
+			ObservedGeneration: sweep.Generation,
+			Message:            fmt.Sprintf("More than %v combinations of parameter values, set .spec.maxTrials", maxSweepTrials),
+		})
+		if err := r.Status().Update(ctx, sweep); err != nil {
+			return result{}, fmt.Errorf("updating status: %w", err)
+		}
+		// No use in retrying...
+		return result{}, nil
+	}
+
+	// Count the Models that are still training before creating new ones
+	// so that trials are started in order.
+	var running int
+	for i := 0; i < trials; i++ {
+		if m, ok := existing[sweepTrialName(sweep, i)]; ok && !m.Status.Ready && !modelFailed(m) {
+			running++
+		}
+	}
+
+	var (
+		statuses          = make([]apiv1beta1.SweepTrial, 0, trials)
+		finished, failed  int
+		best              string
+		bestValue         float64
+		bestObjective     string
+		maximizeObjective = sweep.Spec.Objective.Goal == apiv1beta1.ObjectiveGoalMaximize
+	)
+	for i := 0; i < trials; i++ {
+		trial := sweepTrial(sweep, i)
+		m, ok := existing[trial.Name]
+		if !ok {
+			if running >= parallelism {
+				// Trials that have not been started are not reported.
+				continue
+			}
+			m = sweepModel(sweep, trial)
+			if err := controllerutil.SetControllerReference(sweep, m, r.Scheme); err != nil {
+				return result{}, fmt.Errorf("setting owner reference: %w", err)
+			}
+			log.Info("Creating trial Model", "model", m.Name)
+			if err := r.Create(ctx, m); err != nil && !apierrors.IsAlreadyExists(err) {
+				return result{}, fmt.Errorf("creating model: %w", err)
+			}
+			running++
+		}
+
+		trial.Ready = m.Status.Ready
+		trial.Failed = modelFailed(m)
+		if trial.Ready || trial.Failed {
+			finished++
+		}
+		if trial.Failed {
+			failed++
+		}
+
+		if trial.Ready {
+			if objective, ok := modelMetric(m, sweep.Spec.Objective.Metric); ok {
+				trial.Objective = objective
+				value, err := strconv.ParseFloat(objective, 64)
+				if err != nil {
+					log.Info("Ignoring non-numeric objective", "model", m.Name, "objective", objective)
+				} else if best == "" ||
+					(maximizeObjective && value > bestValue) ||
+					(!maximizeObjective && value < bestValue) {
+					best, bestValue, bestObjective = m.Name, value, objective
+				}
+			}
+		}
+
+		statuses = append(statuses, trial)
+	}
+
+	sweep.Status.Trials = statuses
+	sweep.Status.BestTrial = best
+	sweep.Status.BestObjective = bestObjective
+
+	switch {
+	case finished < trials:
+		sweep.Status.Ready = false
+		meta.SetStatusCondition(sweep.GetConditions(), metav1.Condition{
+			Type:               apiv1beta1.ConditionComplete,
+			Status:             metav1.ConditionFalse,
+			Reason:             apiv1beta1.ReasonTrialsNotComplete,
+			ObservedGeneration: sweep.Generation,
+			Message:            fmt.Sprintf("%v/%v trials finished", finished, trials),
+		})
+	case failed == trials:
+		sweep.Status.Ready = false
+		meta.SetStatusCondition(sweep.GetConditions(), metav1.Condition{
+			Type:               apiv1beta1.ConditionComplete,
+			Status:             metav1.ConditionFalse,
+			Reason:             apiv1beta1.ReasonAllTrialsFailed,
+			ObservedGeneration: sweep.Generation,
+		})
+	default:
+		sweep.Status.Ready = true
+		meta.SetStatusCondition(sweep.GetConditions(), metav1.Condition{
+			Type:               apiv1beta1.ConditionComplete,
+			Status:             metav1.ConditionTrue,
+			Reason:             apiv1beta1.ReasonTrialsComplete,
+			ObservedGeneration: sweep.Generation,
+			Message:            fmt.Sprintf("%v/%v trials failed", failed, trials),
+		})
+	}
+
+	if err := r.Status().Update(ctx, sweep); err != nil {
+		return result{}, fmt.Errorf("updating status: %w", err)
+	}
+
+	return result{success: sweep.Status.Ready}, nil
+}
+
+// sweepTrialCount returns the number of trials of a sweep. False is returned
+// if there are more than maxSweepTrials.
+func sweepTrialCount(sweep *apiv1beta1.ModelSweep) (int, bool) {
+	combinations := 1
+	for _, p := range sweep.Spec.Parameters {
+		combinations *= len(p.Values)
+		if combinations > maxSweepTrials {
+			// Any larger number is rejected, stop before it overflows.
+			combinations = maxSweepTrials + 1
+		}
+	}
+	n := combinations
+	if max := sweep.Spec.MaxTrials; max != nil {
+		// Random trials may repeat combinations so they are not capped.
+		if int(*max) < n || sweep.Spec.Strategy == apiv1beta1.SweepStrategyRandom {
+			n = int(*max)
+		}
+	}
+	return n, n <= maxSweepTrials
+}
+
+// sweepTrialName returns the name of the Model of the i-th trial.
+func sweepTrialName(sweep *apiv1beta1.ModelSweep, i int) string {
+	return fmt.Sprintf("%v-%v", sweep.Name, i)
+}
+
+// sweepTrial returns the i-th trial of a sweep, in the order they are
+// started. The result is deterministic for a given sweep so that it can be
+// recomputed on every reconcile.
+func sweepTrial(sweep *apiv1beta1.ModelSweep, i int) apiv1beta1.SweepTrial {
+	params := sweep.Spec.Parameters
+
+	var rng *rand.Rand
+	if sweep.Spec.Strategy == apiv1beta1.SweepStrategyRandom {
+		h := fnv.New64a()
+		h.Write([]byte(sweep.Namespace + "/" + sweep.Name + "/" + string(sweep.UID) + "/" + strconv.Itoa(i)))
+		rng = rand.New(rand.NewSource(int64(h.Sum64())))
+	}
+
+	values := make(map[string]intstr.IntOrString, len(params))
+	// Grid trials vary the last parameter fastest.
+	index := i
+	for j := len(params) - 1; j >= 0; j-- {
+		p := params[j]
+		if len(p.Values) == 0 {
+			continue
+		}
+		if rng != nil {
+			values[p.Name] = p.Values[rng.Intn(len(p.Values))]
+		} else {
+			values[p.Name] = p.Values[index%len(p.Values)]
+			index /= len(p.Values)
+		}
+	}
+
+	return apiv1beta1.SweepTrial{
+		Name:   sweepTrialName(sweep, i),
+		Params: values,
+	}
+}
+
+// sweepModel returns the Model for a trial of a sweep.
+func sweepModel(sweep *apiv1beta1.ModelSweep, trial apiv1beta1.SweepTrial) *apiv1beta1.Model {
+	spec := *sweep.Spec.Template.DeepCopy()
+	params := make(map[string]intstr.IntOrString, len(spec.Params)+len(trial.Params))
+	for k, v := range spec.Params {
+		params[k] = v
+	}
+	for k, v := range trial.Params {
+		params[k] = v
+	}
+	spec.Params = params
+
+	return &apiv1beta1.Model{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      trial.Name,
+			Namespace: sweep.Namespace,
+			Labels: map[string]string{
+				modelSweepLabel: sweep.Name,
+			},
+		},
+		Spec: spec,
+	}
+}
+
+// modelFailed returns true if the Model will not become ready without
+// intervention.
+func modelFailed(model *apiv1beta1.Model) bool {
+	return terminallyFailed(model.Status.Conditions)
+}
+
+// modelMetric looks up a metric reported by a Model: first in its training
+// progress and then in its latest Evaluation that reported the metric.
+func modelMetric(model *apiv1beta1.Model, metric string) (string, bool) {
+	if p := model.Status.Progress; p != nil {
+		if metric == "loss" && p.Loss != "" {
+			return p.Loss, true
+		}
+		if v, ok := p.Metrics[metric]; ok {
+			return v, true
+		}
+	}
+
+	var (
+		value  string
+		latest metav1.Time
+		found  bool
+	)
+	for _, eval := range model.Status.Evaluations {
+		v, ok := eval.Metrics[metric]
+		if !ok {
+			continue
+		}
+		if !found || latest.Before(&eval.CompletionTime) {
+			value, latest, found = v, eval.CompletionTime, true
+		}
+	}
+	return value, found
+}
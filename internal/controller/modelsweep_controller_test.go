@@ -0,0 +1,77 @@
+package controller_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+)
+
+func TestModelSweep(t *testing.T) {
+	name := strings.ToLower(t.Name())
+
+	sweep := &apiv1beta1.ModelSweep{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: apiv1beta1.ModelSweepSpec{
+			Template: apiv1beta1.ModelSpec{
+				Image: ptr.To("some-image"),
+				Params: map[string]intstr.IntOrString{
+					"epochs": intstr.FromInt(1),
+				},
+			},
+			Parameters: []apiv1beta1.SweepParameter{
+				{Name: "batch_size", Values: []intstr.IntOrString{intstr.FromInt(8), intstr.FromInt(16)}},
+			},
+			Objective: apiv1beta1.SweepObjective{Metric: "loss"},
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, sweep), "create a model sweep")
+	t.Cleanup(debugObject(t, sweep))
+
+	for i, batchSize := range []int{8, 16} {
+		var model apiv1beta1.Model
+		require.EventuallyWithT(t, func(t *assert.CollectT) {
+			err := k8sClient.Get(ctx, types.NamespacedName{Namespace: sweep.Namespace, Name: fmt.Sprintf("%v-%v", sweep.Name, i)}, &model)
+			assert.NoError(t, err, "getting the trial model")
+		}, timeout, interval, "waiting for the trial model to be created")
+		require.Equal(t, intstr.FromInt(batchSize), model.Spec.Params["batch_size"])
+		require.Equal(t, intstr.FromInt(1), model.Spec.Params["epochs"])
+
+		if i == 0 {
+			// Parallelism defaults to 1.
+			err := k8sClient.Get(ctx, types.NamespacedName{Namespace: sweep.Namespace, Name: sweep.Name + "-1"}, &apiv1beta1.Model{})
+			require.True(t, apierrors.IsNotFound(err), "second trial should not be started before the first one finishes")
+		}
+
+		var job batchv1.Job
+		require.EventuallyWithT(t, func(t *assert.CollectT) {
+			err := k8sClient.Get(ctx, types.NamespacedName{Namespace: model.Namespace, Name: model.Name + "-modeller"}, &job)
+			assert.NoError(t, err, "getting the trial training job")
+		}, timeout, interval, "waiting for the trial training job to be created")
+		fakeJobComplete(t, &job)
+	}
+
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(sweep), sweep)
+		assert.NoError(t, err, "getting the model sweep")
+		assert.True(t, meta.IsStatusConditionTrue(sweep.Status.Conditions, apiv1beta1.ConditionComplete))
+		assert.True(t, sweep.Status.Ready)
+	}, timeout, interval, "waiting for the model sweep to be ready")
+	require.Len(t, sweep.Status.Trials, 2)
+	require.True(t, sweep.Status.Trials[1].Ready)
+}
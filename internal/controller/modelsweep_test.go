@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+)
+
+func Test_sweepTrial(t *testing.T) {
+	params := []apiv1beta1.SweepParameter{
+		{Name: "lr", Values: []intstr.IntOrString{intstr.FromString("0.1"), intstr.FromString("0.01")}},
+		{Name: "batch_size", Values: []intstr.IntOrString{intstr.FromInt(8), intstr.FromInt(16), intstr.FromInt(32)}},
+	}
+	sweepTrials := func(t *testing.T, sweep *apiv1beta1.ModelSweep) []apiv1beta1.SweepTrial {
+		n, ok := sweepTrialCount(sweep)
+		require.True(t, ok)
+		var trials []apiv1beta1.SweepTrial
+		for i := 0; i < n; i++ {
+			trials = append(trials, sweepTrial(sweep, i))
+		}
+		return trials
+	}
+
+	t.Run("grid", func(t *testing.T) {
+		sweep := &apiv1beta1.ModelSweep{
+			ObjectMeta: metav1.ObjectMeta{Name: "s"},
+			Spec:       apiv1beta1.ModelSweepSpec{Parameters: params, Strategy: apiv1beta1.SweepStrategyGrid},
+		}
+		trials := sweepTrials(t, sweep)
+		require.Len(t, trials, 6)
+		require.Equal(t, "s-0", trials[0].Name)
+		require.Equal(t, intstr.FromString("0.1"), trials[0].Params["lr"])
+		require.Equal(t, intstr.FromInt(8), trials[0].Params["batch_size"])
+		require.Equal(t, intstr.FromString("0.1"), trials[1].Params["lr"])
+		require.Equal(t, intstr.FromInt(16), trials[1].Params["batch_size"])
+		require.Equal(t, intstr.FromString("0.01"), trials[5].Params["lr"])
+		require.Equal(t, intstr.FromInt(32), trials[5].Params["batch_size"])
+	})
+
+	t.Run("grid-max-trials", func(t *testing.T) {
+		sweep := &apiv1beta1.ModelSweep{
+			ObjectMeta: metav1.ObjectMeta{Name: "s"},
+			Spec:       apiv1beta1.ModelSweepSpec{Parameters: params, MaxTrials: ptr.To[int32](2)},
+		}
+		require.Len(t, sweepTrials(t, sweep), 2)
+	})
+
+	t.Run("random", func(t *testing.T) {
+		sweep := &apiv1beta1.ModelSweep{
+			ObjectMeta: metav1.ObjectMeta{Name: "s", UID: "abc"},
+			Spec: apiv1beta1.ModelSweepSpec{
+				Parameters: params,
+				Strategy:   apiv1beta1.SweepStrategyRandom,
+				MaxTrials:  ptr.To[int32](10),
+			},
+		}
+		trials := sweepTrials(t, sweep)
+		require.Len(t, trials, 10)
+		require.Equal(t, trials, sweepTrials(t, sweep), "trials should be deterministic")
+		for _, trial := range trials {
+			require.Len(t, trial.Params, 2)
+		}
+	})
+
+	t.Run("too-many-trials", func(t *testing.T) {
+		values := make([]intstr.IntOrString, 100)
+		for i := range values {
+			values[i] = intstr.FromInt(i)
+		}
+		var large []apiv1beta1.SweepParameter
+		for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"} {
+			large = append(large, apiv1beta1.SweepParameter{Name: name, Values: values})
+		}
+		sweep := &apiv1beta1.ModelSweep{
+			ObjectMeta: metav1.ObjectMeta{Name: "s"},
+			Spec:       apiv1beta1.ModelSweepSpec{Parameters: large},
+		}
+		_, ok := sweepTrialCount(sweep)
+		require.False(t, ok, "100^11 combinations should be rejected without overflowing")
+
+		sweep.Spec.MaxTrials = ptr.To[int32](3)
+		trials := sweepTrials(t, sweep)
+		require.Len(t, trials, 3)
+		require.Equal(t, intstr.FromInt(2), trials[2].Params["k"])
+	})
+}
+
+func Test_modelFailed(t *testing.T) {
+	failed := func(reason string) bool {
+		return modelFailed(&apiv1beta1.Model{Status: apiv1beta1.ModelStatus{Conditions: []metav1.Condition{{
+			Type:   apiv1beta1.ConditionComplete,
+			Status: metav1.ConditionFalse,
+			Reason: reason,
+		}}}})
+	}
+	require.True(t, failed(apiv1beta1.ReasonJobFailed))
+	require.True(t, failed(apiv1beta1.ReasonBaseModelNotFound))
+	require.True(t, failed(apiv1beta1.ReasonDatasetMountInvalid))
+	require.True(t, failed(apiv1beta1.ReasonArtifactsNotFound))
+	require.True(t, failed(apiv1beta1.ReasonArtifactsInvalid))
+	require.False(t, failed(apiv1beta1.ReasonJobNotComplete))
+	require.False(t, modelFailed(&apiv1beta1.Model{}))
+}
+
+func Test_modelMetric(t *testing.T) {
+	earlier := metav1.NewTime(metav1.Now().Add(-time.Hour))
+	later := metav1.Now()
+	model := &apiv1beta1.Model{
+		Status: apiv1beta1.ModelStatus{
+			Progress: &apiv1beta1.TrainingProgress{
+				Loss:    "1.5",
+				Metrics: map[string]string{"eval_loss": "1.25"},
+			},
+			Evaluations: []apiv1beta1.EvaluationSummary{
+				{Name: "b", Metrics: map[string]string{"accuracy": "0.9"}, CompletionTime: later},
+				{Name: "a", Metrics: map[string]string{"accuracy": "0.8"}, CompletionTime: earlier},
+			},
+		},
+	}
+
+	cases := map[string]string{
+		"loss":      "1.5",
+		"eval_loss": "1.25",
+		"accuracy":  "0.9",
+	}
+	for metric, expect := range cases {
+		v, ok := modelMetric(model, metric)
+		require.True(t, ok, metric)
+		require.Equal(t, expect, v, metric)
+	}
+
+	_, ok := modelMetric(model, "missing")
+	require.False(t, ok)
+}
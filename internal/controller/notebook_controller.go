@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	batchv1 "k8s.io/api/batch/v1"
@@ -18,7 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 	"github.com/substratusai/substratus/internal/cloud"
 	"github.com/substratusai/substratus/internal/resources"
 	"github.com/substratusai/substratus/internal/sci"
@@ -32,7 +33,14 @@ type NotebookReconciler struct {
 	Cloud cloud.Cloud
 	SCI   sci.ControllerClient
 
+	// GPUCatalog describes how to schedule GPUs. If nil, the default
+	// catalog is used.
+	GPUCatalog *resources.GPUCatalog
+
 	*ParamsReconciler
+
+	// Artifacts deletes the uploads of deleted Notebooks.
+	Artifacts *ArtifactsCollector
 }
 
 func (r *NotebookReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -41,11 +49,15 @@ func (r *NotebookReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	log.Info("Reconciling Notebook")
 	defer log.Info("Done reconciling Notebook")
 
-	var notebook apiv1.Notebook
+	var notebook apiv1beta1.Notebook
 	if err := r.Get(ctx, req.NamespacedName, &notebook); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	if result, err := r.Artifacts.ReconcileArtifactsFinalizer(ctx, &notebook); !result.success {
+		return result.Result, err
+	}
+
 	if notebook.GetImage() == "" {
 		// Image must be building.
 		return ctrl.Result{}, nil
@@ -72,18 +84,19 @@ func (r *NotebookReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 // SetupWithManager sets up the controller with the Manager.
 func (r *NotebookReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&apiv1.Notebook{}).
+		For(&apiv1beta1.Notebook{}).
 		Owns(&batchv1.Job{}).
 		Owns(&corev1.Pod{}).
-		Watches(&apiv1.Model{}, handler.EnqueueRequestsFromMapFunc(handler.MapFunc(r.findNotebooksForModel))).
-		Watches(&apiv1.Dataset{}, handler.EnqueueRequestsFromMapFunc(handler.MapFunc(r.findNotebooksForDataset))).
+		Watches(&apiv1beta1.Model{}, handler.EnqueueRequestsFromMapFunc(handler.MapFunc(r.findNotebooksForModel))).
+		Watches(&apiv1beta1.Dataset{}, handler.EnqueueRequestsFromMapFunc(handler.MapFunc(r.findNotebooksForDataset))).
+		Watches(&apiv1beta1.BatchInference{}, handler.EnqueueRequestsFromMapFunc(handler.MapFunc(r.findNotebooksForDataset))).
 		Complete(r)
 }
 
 func (r *NotebookReconciler) findNotebooksForModel(ctx context.Context, obj client.Object) []reconcile.Request {
-	model := obj.(*apiv1.Model)
+	model := obj.(*apiv1beta1.Model)
 
-	var notebooks apiv1.NotebookList
+	var notebooks apiv1beta1.NotebookList
 	if err := r.List(ctx, &notebooks,
 		client.MatchingFields{notebookModelIndex: model.Name},
 		client.InNamespace(obj.GetNamespace()),
@@ -105,11 +118,9 @@ func (r *NotebookReconciler) findNotebooksForModel(ctx context.Context, obj clie
 }
 
 func (r *NotebookReconciler) findNotebooksForDataset(ctx context.Context, obj client.Object) []reconcile.Request {
-	dataset := obj.(*apiv1.Dataset)
-
-	var notebooks apiv1.NotebookList
+	var notebooks apiv1beta1.NotebookList
 	if err := r.List(ctx, &notebooks,
-		client.MatchingFields{notebookDatasetIndex: dataset.Name},
+		client.MatchingFields{notebookDatasetIndex: obj.GetName()},
 		client.InNamespace(obj.GetNamespace()),
 	); err != nil {
 		log.Log.Error(err, "unable to list notebooks for dataset")
@@ -128,15 +139,15 @@ func (r *NotebookReconciler) findNotebooksForDataset(ctx context.Context, obj cl
 	return reqs
 }
 
-func (r *NotebookReconciler) reconcileNotebook(ctx context.Context, notebook *apiv1.Notebook) (result, error) {
+func (r *NotebookReconciler) reconcileNotebook(ctx context.Context, notebook *apiv1beta1.Notebook) (result, error) {
 	log := log.FromContext(ctx)
 
 	if notebook.IsSuspended() {
 		notebook.Status.Ready = false
 		meta.SetStatusCondition(&notebook.Status.Conditions, metav1.Condition{
-			Type:               apiv1.ConditionServing,
+			Type:               apiv1beta1.ConditionServing,
 			Status:             metav1.ConditionFalse,
-			Reason:             apiv1.ReasonSuspended,
+			Reason:             apiv1beta1.ReasonSuspended,
 			ObservedGeneration: notebook.Generation,
 		})
 		if err := r.Status().Update(ctx, notebook); err != nil {
@@ -166,18 +177,18 @@ func (r *NotebookReconciler) reconcileNotebook(ctx context.Context, notebook *ap
 		return result, err
 	}
 
-	var model *apiv1.Model
+	var model *apiv1beta1.Model
 	if notebook.Spec.Model != nil && notebook.Spec.Model.Name != "" {
-		model = &apiv1.Model{}
+		model = &apiv1beta1.Model{}
 		if err := r.Get(ctx, client.ObjectKey{Name: notebook.Spec.Model.Name, Namespace: notebook.Namespace}, model); err != nil {
 			if apierrors.IsNotFound(err) {
 				log.Error(err, "Model not found")
 				// Update this Model's status.
 				notebook.Status.Ready = false
 				meta.SetStatusCondition(&notebook.Status.Conditions, metav1.Condition{
-					Type:               apiv1.ConditionServing,
+					Type:               apiv1beta1.ConditionServing,
 					Status:             metav1.ConditionFalse,
-					Reason:             apiv1.ReasonModelNotFound,
+					Reason:             apiv1beta1.ReasonModelNotFound,
 					ObservedGeneration: notebook.Generation,
 				})
 				if err := r.Status().Update(ctx, notebook); err != nil {
@@ -195,9 +206,9 @@ func (r *NotebookReconciler) reconcileNotebook(ctx context.Context, notebook *ap
 
 			notebook.Status.Ready = false
 			meta.SetStatusCondition(&notebook.Status.Conditions, metav1.Condition{
-				Type:               apiv1.ConditionServing,
+				Type:               apiv1beta1.ConditionServing,
 				Status:             metav1.ConditionFalse,
-				Reason:             apiv1.ReasonModelNotReady,
+				Reason:             apiv1beta1.ReasonModelNotReady,
 				ObservedGeneration: notebook.Generation,
 			})
 			if err := r.Status().Update(ctx, notebook); err != nil {
@@ -209,17 +220,18 @@ func (r *NotebookReconciler) reconcileNotebook(ctx context.Context, notebook *ap
 
 	}
 
-	var dataset *apiv1.Dataset
+	var dataset datasetObject
 	if notebook.Spec.Dataset != nil && notebook.Spec.Dataset.Name != "" {
-		dataset = &apiv1.Dataset{}
-		if err := r.Get(ctx, client.ObjectKey{Name: notebook.Spec.Dataset.Name, Namespace: notebook.Namespace}, dataset); err != nil {
+		var err error
+		dataset, err = getDatasetObject(ctx, r.Client, notebook.Namespace, *notebook.Spec.Dataset)
+		if err != nil {
 			if apierrors.IsNotFound(err) {
 				log.Error(err, "Dataset not found")
 				notebook.Status.Ready = false
 				meta.SetStatusCondition(&notebook.Status.Conditions, metav1.Condition{
-					Type:               apiv1.ConditionServing,
+					Type:               apiv1beta1.ConditionServing,
 					Status:             metav1.ConditionFalse,
-					Reason:             apiv1.ReasonDatasetNotFound,
+					Reason:             apiv1beta1.ReasonDatasetNotFound,
 					ObservedGeneration: notebook.Generation,
 				})
 				if err := r.Status().Update(ctx, notebook); err != nil {
@@ -229,16 +241,33 @@ func (r *NotebookReconciler) reconcileNotebook(ctx context.Context, notebook *ap
 				// TODO: Implement watch on source Model.
 				return result{}, nil
 			}
+			if errors.Is(err, errUnsupportedDatasetKind) {
+				log.Error(err, "Unable to use dataset")
+				notebook.Status.Ready = false
+				meta.SetStatusCondition(&notebook.Status.Conditions, metav1.Condition{
+					Type:               apiv1beta1.ConditionServing,
+					Status:             metav1.ConditionFalse,
+					Reason:             apiv1beta1.ReasonDatasetKindUnsupported,
+					Message:            err.Error(),
+					ObservedGeneration: notebook.Generation,
+				})
+				if err := r.Status().Update(ctx, notebook); err != nil {
+					return result{}, fmt.Errorf("failed to update notebook status: %w", err)
+				}
+
+				// No use in retrying...
+				return result{}, nil
+			}
 			return result{}, fmt.Errorf("getting dataset: %w", err)
 		}
 
-		if !dataset.Status.Ready {
-			log.Info("Dataset not ready", "dataset", dataset.Name)
+		if !dataset.GetStatusReady() {
+			log.Info("Dataset not ready", "dataset", dataset.GetName())
 			notebook.Status.Ready = false
 			meta.SetStatusCondition(&notebook.Status.Conditions, metav1.Condition{
-				Type:               apiv1.ConditionServing,
+				Type:               apiv1beta1.ConditionServing,
 				Status:             metav1.ConditionFalse,
-				Reason:             apiv1.ReasonDatasetNotReady,
+				Reason:             apiv1beta1.ReasonDatasetNotReady,
 				ObservedGeneration: notebook.Generation,
 			})
 			if err := r.Status().Update(ctx, notebook); err != nil {
@@ -288,17 +317,17 @@ func (r *NotebookReconciler) reconcileNotebook(ctx context.Context, notebook *ap
 	if isPodReady(pod) {
 		notebook.Status.Ready = true
 		meta.SetStatusCondition(&notebook.Status.Conditions, metav1.Condition{
-			Type:               apiv1.ConditionServing,
+			Type:               apiv1beta1.ConditionServing,
 			Status:             metav1.ConditionTrue,
-			Reason:             apiv1.ReasonPodReady,
+			Reason:             apiv1beta1.ReasonPodReady,
 			ObservedGeneration: notebook.Generation,
 		})
 	} else {
 		notebook.Status.Ready = false
 		meta.SetStatusCondition(&notebook.Status.Conditions, metav1.Condition{
-			Type:               apiv1.ConditionServing,
+			Type:               apiv1beta1.ConditionServing,
 			Status:             metav1.ConditionFalse,
-			Reason:             apiv1.ReasonPodNotReady,
+			Reason:             apiv1beta1.ReasonPodNotReady,
 			ObservedGeneration: notebook.Generation,
 		})
 	}
@@ -309,12 +338,12 @@ func (r *NotebookReconciler) reconcileNotebook(ctx context.Context, notebook *ap
 	return result{success: true}, nil
 }
 
-func nbPodName(nb *apiv1.Notebook) string {
+func nbPodName(nb *apiv1beta1.Notebook) string {
 	return nb.Name + "-notebook"
 }
 
 // notebookPod constructs a Pod for the given Notebook.
-func (r *NotebookReconciler) notebookPod(notebook *apiv1.Notebook, model *apiv1.Model, dataset *apiv1.Dataset) (*corev1.Pod, error) {
+func (r *NotebookReconciler) notebookPod(notebook *apiv1beta1.Notebook, model *apiv1beta1.Model, dataset datasetObject) (*corev1.Pod, error) {
 	const containerName = "notebook"
 
 	cmd := notebook.Spec.Command
@@ -446,18 +475,18 @@ func (r *NotebookReconciler) notebookPod(notebook *apiv1.Notebook, model *apiv1.
 	}
 
 	if err := resources.Apply(&pod.ObjectMeta, &pod.Spec, containerName,
-		r.Cloud.Name(), notebook.Spec.Resources); err != nil {
+		r.Cloud.Name(), r.GPUCatalog, notebook.Spec.Resources, notebook.Spec.Scheduling); err != nil {
 		return nil, fmt.Errorf("applying resources: %w", err)
 	}
 
 	return pod, nil
 }
 
-//func notebookPVCName(nb *apiv1.Notebook) string {
+//func notebookPVCName(nb *apiv1beta1.Notebook) string {
 //	return nb.Name + "-notebook"
 //}
 
-//func (r *NotebookReconciler) notebookPVC(nb *apiv1.Notebook) (*corev1.PersistentVolumeClaim, error) {
+//func (r *NotebookReconciler) notebookPVC(nb *apiv1beta1.Notebook) (*corev1.PersistentVolumeClaim, error) {
 //	pvc := &corev1.PersistentVolumeClaim{
 //		TypeMeta: metav1.TypeMeta{
 //			APIVersion: "v1",
@@ -14,18 +14,18 @@ import (
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 )
 
 func TestNotebookFromGitWithModelOnly(t *testing.T) {
 	name := strings.ToLower(t.Name())
 
-	model := &apiv1.Model{
+	model := &apiv1beta1.Model{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name + "-mdl",
 			Namespace: "default",
 		},
-		Spec: apiv1.ModelSpec{
+		Spec: apiv1beta1.ModelSpec{
 			Image: ptr.To("some-image"),
 		},
 	}
@@ -34,19 +34,19 @@ func TestNotebookFromGitWithModelOnly(t *testing.T) {
 
 	testModelLoad(t, model)
 
-	notebook := &apiv1.Notebook{
+	notebook := &apiv1beta1.Notebook{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name + "-nb",
 			Namespace: "default",
 		},
-		Spec: apiv1.NotebookSpec{
+		Spec: apiv1beta1.NotebookSpec{
 			Command: []string{"notebook.sh"},
-			Build: &apiv1.Build{
-				Git: &apiv1.BuildGit{
+			Build: &apiv1beta1.Build{
+				Git: &apiv1beta1.BuildGit{
 					URL: "https://github.com/substratusai/notebook-test-test",
 				},
 			},
-			Model: &apiv1.ObjectRef{
+			Model: &apiv1beta1.ObjectRef{
 				Name: model.Name,
 			},
 			Params: map[string]intstr.IntOrString{
@@ -76,7 +76,7 @@ func TestNotebookFromGitWithModelOnly(t *testing.T) {
 	require.EventuallyWithT(t, func(t *assert.CollectT) {
 		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(notebook), notebook)
 		assert.NoError(t, err, "getting the notebook")
-		assert.True(t, meta.IsStatusConditionTrue(notebook.Status.Conditions, apiv1.ConditionServing))
+		assert.True(t, meta.IsStatusConditionTrue(notebook.Status.Conditions, apiv1beta1.ConditionServing))
 		assert.True(t, notebook.Status.Ready)
 	}, timeout, interval, "waiting for the notebook to be ready")
 }
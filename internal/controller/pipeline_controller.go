@@ -0,0 +1,296 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+)
+
+const (
+	// Labels that are set on the objects created by a Pipeline.
+	pipelineLabel        = "pipeline"
+	pipelineStageLabel   = "pipeline-stage"
+	pipelineVersionLabel = "pipeline-version"
+)
+
+// PipelineReconciler reconciles a Pipeline object.
+type PipelineReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=substratus.ai,resources=pipelines,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=substratus.ai,resources=pipelines/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=substratus.ai,resources=pipelines/finalizers,verbs=update
+//+kubebuilder:rbac:groups=substratus.ai,resources=datasets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=substratus.ai,resources=models,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=substratus.ai,resources=evaluations,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=substratus.ai,resources=servers,verbs=get;list;watch;create;update;patch;delete
+
+func (r *PipelineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	log.Info("Reconciling Pipeline")
+	defer log.Info("Done reconciling Pipeline")
+
+	var pipeline apiv1beta1.Pipeline
+	if err := r.Get(ctx, req.NamespacedName, &pipeline); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if result, err := r.reconcilePipeline(ctx, &pipeline); !result.success {
+		return result.Result, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PipelineReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&apiv1beta1.Pipeline{}).
+		Owns(&apiv1beta1.Dataset{}).
+		Owns(&apiv1beta1.Model{}).
+		Owns(&apiv1beta1.Evaluation{}).
+		Owns(&apiv1beta1.Server{}).
+		Complete(r)
+}
+
+// stageObject is implemented by every kind that can be created by a
+// Pipeline stage.
+type stageObject interface {
+	client.Object
+	GetConditions() *[]metav1.Condition
+	GetStatusReady() bool
+}
+
+func (r *PipelineReconciler) reconcilePipeline(ctx context.Context, pipeline *apiv1beta1.Pipeline) (result, error) {
+	log := log.FromContext(ctx)
+
+	// Every change to the spec starts a new run.
+	if pipeline.Status.VersionGeneration != pipeline.Generation {
+		pipeline.Status.Version++
+		pipeline.Status.VersionGeneration = pipeline.Generation
+		pipeline.Status.Stages = nil
+	}
+
+	if err := validatePipelineStages(pipeline.Spec.Stages); err != nil {
+		pipeline.Status.Ready = false
+		meta.SetStatusCondition(pipeline.GetConditions(), metav1.Condition{
+			Type:               apiv1beta1.ConditionComplete,
+			Status:             metav1.ConditionFalse,
+			Reason:             apiv1beta1.ReasonStageInvalid,
+			ObservedGeneration: pipeline.Generation,
+			Message:            err.Error(),
+		})
+		if err := r.Status().Update(ctx, pipeline); err != nil {
+			return result{}, fmt.Errorf("updating status: %w", err)
+		}
+		// No use in retrying...
+		return result{}, nil
+	}
+
+	var (
+		objectNames = map[string]string{}
+		ready       = map[string]bool{}
+		statuses    = make([]apiv1beta1.PipelineStageStatus, 0, len(pipeline.Spec.Stages))
+		failed      string
+	)
+	for _, stage := range pipeline.Spec.Stages {
+		obj, refs := pipelineStageObject(pipeline, stage)
+		status := apiv1beta1.PipelineStageStatus{
+			Name: stage.Name,
+			Kind: stageKind(stage),
+		}
+
+		// Resolve references to earlier stages.
+		var waitingFor string
+		for _, ref := range refs {
+			name, ok := objectNames[*ref]
+			if !ok {
+				continue
+			}
+			if !ready[*ref] && waitingFor == "" {
+				waitingFor = *ref
+			}
+			*ref = name
+		}
+		objectNames[stage.Name] = obj.GetName()
+
+		if waitingFor != "" {
+			status.Reason = apiv1beta1.ReasonWaitingForStage
+			statuses = append(statuses, status)
+			continue
+		}
+
+		if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return result{}, fmt.Errorf("getting %v: %w", status.Kind, err)
+			}
+			if err := controllerutil.SetControllerReference(pipeline, obj, r.Scheme); err != nil {
+				return result{}, fmt.Errorf("setting owner reference: %w", err)
+			}
+			log.Info("Creating stage object", "stage", stage.Name, "kind", status.Kind, "name", obj.GetName())
+			if err := r.Create(ctx, obj); err != nil && !apierrors.IsAlreadyExists(err) {
+				return result{}, fmt.Errorf("creating %v: %w", status.Kind, err)
+			}
+		}
+
+		status.Object = obj.GetName()
+		status.Ready = obj.GetStatusReady()
+		if cond := latestCondition(*obj.GetConditions()); cond != nil {
+			status.Reason = cond.Reason
+		}
+		if terminallyFailed(*obj.GetConditions()) && failed == "" {
+			failed = stage.Name
+		}
+		ready[stage.Name] = status.Ready
+
+		statuses = append(statuses, status)
+	}
+
+	pipeline.Status.Stages = statuses
+
+	var readyStages int
+	for _, s := range statuses {
+		if s.Ready {
+			readyStages++
+		}
+	}
+
+	switch {
+	case failed != "":
+		pipeline.Status.Ready = false
+		meta.SetStatusCondition(pipeline.GetConditions(), metav1.Condition{
+			Type:               apiv1beta1.ConditionComplete,
+			Status:             metav1.ConditionFalse,
+			Reason:             apiv1beta1.ReasonStageFailed,
+			ObservedGeneration: pipeline.Generation,
+			Message:            fmt.Sprintf("Stage %q failed", failed),
+		})
+	case readyStages < len(statuses):
+		pipeline.Status.Ready = false
+		meta.SetStatusCondition(pipeline.GetConditions(), metav1.Condition{
+			Type:               apiv1beta1.ConditionComplete,
+			Status:             metav1.ConditionFalse,
+			Reason:             apiv1beta1.ReasonStagesNotReady,
+			ObservedGeneration: pipeline.Generation,
+			Message:            fmt.Sprintf("%v/%v stages ready", readyStages, len(statuses)),
+		})
+	default:
+		pipeline.Status.Ready = true
+		meta.SetStatusCondition(pipeline.GetConditions(), metav1.Condition{
+			Type:               apiv1beta1.ConditionComplete,
+			Status:             metav1.ConditionTrue,
+			Reason:             apiv1beta1.ReasonStagesReady,
+			ObservedGeneration: pipeline.Generation,
+		})
+	}
+
+	if err := r.Status().Update(ctx, pipeline); err != nil {
+		return result{}, fmt.Errorf("updating status: %w", err)
+	}
+
+	return result{success: pipeline.Status.Ready}, nil
+}
+
+func validatePipelineStages(stages []apiv1beta1.PipelineStage) error {
+	names := map[string]bool{}
+	for _, stage := range stages {
+		if names[stage.Name] {
+			return fmt.Errorf("stage %q: duplicate name", stage.Name)
+		}
+		names[stage.Name] = true
+
+		var specs int
+		for _, set := range []bool{stage.Dataset != nil, stage.Model != nil, stage.Evaluation != nil, stage.Server != nil} {
+			if set {
+				specs++
+			}
+		}
+		if specs != 1 {
+			return fmt.Errorf("stage %q: exactly one of dataset, model, evaluation or server must be set", stage.Name)
+		}
+	}
+	return nil
+}
+
+func stageKind(stage apiv1beta1.PipelineStage) string {
+	switch {
+	case stage.Dataset != nil:
+		return "Dataset"
+	case stage.Model != nil:
+		return "Model"
+	case stage.Evaluation != nil:
+		return "Evaluation"
+	case stage.Server != nil:
+		return "Server"
+	}
+	return ""
+}
+
+// pipelineStageObject returns the object for a stage of the current version
+// of a Pipeline along with pointers to the names of the references in its
+// spec (which can be resolved by the caller).
+func pipelineStageObject(pipeline *apiv1beta1.Pipeline, stage apiv1beta1.PipelineStage) (stageObject, []*string) {
+	objMeta := metav1.ObjectMeta{
+		Name:      fmt.Sprintf("%v-%v-v%v", pipeline.Name, stage.Name, pipeline.Status.Version),
+		Namespace: pipeline.Namespace,
+		Labels: map[string]string{
+			pipelineLabel:        pipeline.Name,
+			pipelineStageLabel:   stage.Name,
+			pipelineVersionLabel: fmt.Sprint(pipeline.Status.Version),
+		},
+	}
+
+	switch {
+	case stage.Dataset != nil:
+		obj := &apiv1beta1.Dataset{ObjectMeta: objMeta, Spec: *stage.Dataset.DeepCopy()}
+		var refs []*string
+		for i := range obj.Spec.Datasets {
+			refs = append(refs, &obj.Spec.Datasets[i].Dataset.Name)
+		}
+		return obj, refs
+	case stage.Model != nil:
+		obj := &apiv1beta1.Model{ObjectMeta: objMeta, Spec: *stage.Model.DeepCopy()}
+		var refs []*string
+		if obj.Spec.Model != nil {
+			refs = append(refs, &obj.Spec.Model.Name)
+		}
+		if obj.Spec.Dataset != nil {
+			refs = append(refs, &obj.Spec.Dataset.Name)
+		}
+		for i := range obj.Spec.Datasets {
+			refs = append(refs, &obj.Spec.Datasets[i].Dataset.Name)
+		}
+		return obj, refs
+	case stage.Evaluation != nil:
+		obj := &apiv1beta1.Evaluation{ObjectMeta: objMeta, Spec: *stage.Evaluation.DeepCopy()}
+		return obj, []*string{&obj.Spec.Model.Name, &obj.Spec.Dataset.Name}
+	case stage.Server != nil:
+		obj := &apiv1beta1.Server{ObjectMeta: objMeta, Spec: *stage.Server.DeepCopy()}
+		return obj, []*string{&obj.Spec.Model.Name}
+	}
+	return nil, nil
+}
+
+// latestCondition returns the condition that changed last.
+func latestCondition(conditions []metav1.Condition) *metav1.Condition {
+	var latest *metav1.Condition
+	for i := range conditions {
+		if latest == nil || latest.LastTransitionTime.Before(&conditions[i].LastTransitionTime) {
+			latest = &conditions[i]
+		}
+	}
+	return latest
+}
@@ -0,0 +1,127 @@
+package controller_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+)
+
+func TestPipeline(t *testing.T) {
+	name := strings.ToLower(t.Name())
+
+	pipeline := &apiv1beta1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: apiv1beta1.PipelineSpec{
+			Stages: []apiv1beta1.PipelineStage{
+				{
+					Name:    "data",
+					Dataset: &apiv1beta1.DatasetSpec{Image: ptr.To("some-dataset-image")},
+				},
+				{
+					Name: "train",
+					Model: &apiv1beta1.ModelSpec{
+						Image:   ptr.To("some-model-image"),
+						Dataset: &apiv1beta1.DatasetRef{Name: "data"},
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, pipeline), "create a pipeline")
+	t.Cleanup(debugObject(t, pipeline))
+
+	var dataset apiv1beta1.Dataset
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: pipeline.Namespace, Name: name + "-data-v1"}, &dataset)
+		assert.NoError(t, err, "getting the dataset stage object")
+	}, timeout, interval, "waiting for the dataset to be created")
+
+	// The model stage should wait for the dataset.
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(pipeline), pipeline)
+		assert.NoError(t, err, "getting the pipeline")
+		if assert.Len(t, pipeline.Status.Stages, 2) {
+			assert.Equal(t, apiv1beta1.ReasonWaitingForStage, pipeline.Status.Stages[1].Reason)
+		}
+	}, timeout, interval, "waiting for the pipeline to report stage status")
+
+	testDatasetLoad(t, &dataset)
+
+	var model apiv1beta1.Model
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: pipeline.Namespace, Name: name + "-train-v1"}, &model)
+		assert.NoError(t, err, "getting the model stage object")
+	}, timeout, interval, "waiting for the model to be created")
+	require.Equal(t, dataset.Name, model.Spec.Dataset.Name)
+
+	var job batchv1.Job
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: model.Namespace, Name: model.Name + "-modeller"}, &job)
+		assert.NoError(t, err, "getting the model training job")
+	}, timeout, interval, "waiting for the model training job to be created")
+	fakeJobComplete(t, &job)
+
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(pipeline), pipeline)
+		assert.NoError(t, err, "getting the pipeline")
+		assert.True(t, meta.IsStatusConditionTrue(pipeline.Status.Conditions, apiv1beta1.ConditionComplete))
+		assert.True(t, pipeline.Status.Ready)
+	}, timeout, interval, "waiting for the pipeline to be ready")
+	require.Equal(t, int32(1), pipeline.Status.Version)
+
+	// Re-running the pipeline should create a new version of every object.
+	pipeline.Spec.RunID = "rerun"
+	require.NoError(t, k8sClient.Update(ctx, pipeline), "re-run the pipeline")
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, types.NamespacedName{Namespace: pipeline.Namespace, Name: name + "-data-v2"}, &apiv1beta1.Dataset{})
+		assert.NoError(t, err, "getting the second version of the dataset")
+	}, timeout, interval, "waiting for the second version of the dataset to be created")
+}
+
+func TestPipelineStageFailed(t *testing.T) {
+	name := strings.ToLower(t.Name())
+
+	pipeline := &apiv1beta1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: apiv1beta1.PipelineSpec{
+			Stages: []apiv1beta1.PipelineStage{
+				{
+					Name: "train",
+					Model: &apiv1beta1.ModelSpec{
+						Image: ptr.To("some-model-image"),
+						Model: &apiv1beta1.ObjectRef{Name: name + "-does-not-exist"},
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, pipeline), "create a pipeline")
+	t.Cleanup(debugObject(t, pipeline))
+
+	// A missing base model fails the stage without a Job ever running.
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(pipeline), pipeline)
+		assert.NoError(t, err, "getting the pipeline")
+		cond := meta.FindStatusCondition(pipeline.Status.Conditions, apiv1beta1.ConditionComplete)
+		if assert.NotNil(t, cond) {
+			assert.Equal(t, apiv1beta1.ReasonStageFailed, cond.Reason)
+		}
+	}, timeout, interval, "waiting for the pipeline to report the failed stage")
+	require.False(t, pipeline.Status.Ready)
+}
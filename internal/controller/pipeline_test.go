@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+)
+
+func Test_validatePipelineStages(t *testing.T) {
+	cases := []struct {
+		name   string
+		stages []apiv1beta1.PipelineStage
+		error  bool
+	}{
+		{
+			name: "valid",
+			stages: []apiv1beta1.PipelineStage{
+				{Name: "data", Dataset: &apiv1beta1.DatasetSpec{}},
+				{Name: "train", Model: &apiv1beta1.ModelSpec{}},
+			},
+		},
+		{
+			name: "duplicate-name",
+			stages: []apiv1beta1.PipelineStage{
+				{Name: "data", Dataset: &apiv1beta1.DatasetSpec{}},
+				{Name: "data", Model: &apiv1beta1.ModelSpec{}},
+			},
+			error: true,
+		},
+		{
+			name: "no-spec",
+			stages: []apiv1beta1.PipelineStage{
+				{Name: "data"},
+			},
+			error: true,
+		},
+		{
+			name: "multiple-specs",
+			stages: []apiv1beta1.PipelineStage{
+				{Name: "data", Dataset: &apiv1beta1.DatasetSpec{}, Model: &apiv1beta1.ModelSpec{}},
+			},
+			error: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := validatePipelineStages(c.stages)
+			if c.error {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_pipelineStageObject(t *testing.T) {
+	pipeline := &apiv1beta1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: "ns"},
+		Status:     apiv1beta1.PipelineStatus{Version: 3},
+	}
+	stage := apiv1beta1.PipelineStage{
+		Name: "eval",
+		Evaluation: &apiv1beta1.EvaluationSpec{
+			Model:   apiv1beta1.ObjectRef{Name: "train"},
+			Dataset: apiv1beta1.DatasetRef{Name: "data"},
+		},
+	}
+
+	obj, refs := pipelineStageObject(pipeline, stage)
+	require.Equal(t, "p-eval-v3", obj.GetName())
+	require.Equal(t, "ns", obj.GetNamespace())
+	require.Equal(t, "3", obj.GetLabels()["pipeline-version"])
+	require.Len(t, refs, 2)
+
+	// Resolving references should not modify the Pipeline spec.
+	*refs[0] = "p-train-v3"
+	require.Equal(t, "p-train-v3", obj.(*apiv1beta1.Evaluation).Spec.Model.Name)
+	require.Equal(t, "train", stage.Evaluation.Model.Name)
+}
@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+	"github.com/substratusai/substratus/internal/cloud"
+	"github.com/substratusai/substratus/internal/sci"
+)
+
+const (
+	// progressFile is where training containers report their progress
+	// (relative to the "artifacts/" directory).
+	progressFile = "status.json"
+
+	// progressInterval is how often the progress of a running Model is read.
+	progressInterval = 30 * time.Second
+
+	// maxProgressBytes limits the size of the progress file.
+	maxProgressBytes = 64 << 10
+)
+
+// reportedProgress is the format of the progress file.
+type reportedProgress struct {
+	Step       int64              `json:"step"`
+	TotalSteps int64              `json:"totalSteps"`
+	Epoch      *float64           `json:"epoch"`
+	Loss       *float64           `json:"loss"`
+	Metrics    map[string]float64 `json:"metrics"`
+}
+
+func parseProgress(data []byte) (*apiv1beta1.TrainingProgress, error) {
+	var reported reportedProgress
+	if err := json.Unmarshal(data, &reported); err != nil {
+		return nil, fmt.Errorf("decoding progress: %w", err)
+	}
+
+	formatFloat := func(f *float64) string {
+		if f == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*f, 'g', -1, 64)
+	}
+
+	progress := &apiv1beta1.TrainingProgress{
+		Step:       reported.Step,
+		TotalSteps: reported.TotalSteps,
+		Epoch:      formatFloat(reported.Epoch),
+		Loss:       formatFloat(reported.Loss),
+	}
+	if len(reported.Metrics) > 0 {
+		progress.Metrics = make(map[string]string, len(reported.Metrics))
+		for k, v := range reported.Metrics {
+			v := v
+			progress.Metrics[k] = formatFloat(&v)
+		}
+	}
+
+	return progress, nil
+}
+
+// reconcileProgress copies the latest progress reported by the modeller
+// container into the Model status. The progress file is read from the
+// artifacts bucket through the SCI. Failures are logged but otherwise ignored
+// as progress reporting is optional for containers.
+func (r *ModelReconciler) reconcileProgress(ctx context.Context, model *apiv1beta1.Model) {
+	log := log.FromContext(ctx)
+
+	u, err := cloud.ParseBucketURL(model.Status.Artifacts.URL)
+	if err != nil {
+		log.Error(err, "unable to parse artifacts url")
+		return
+	}
+	resp, err := r.SCI.GetObject(ctx, &sci.GetObjectRequest{
+		BucketName: u.Bucket,
+		ObjectName: path.Join(u.Path, "artifacts", progressFile),
+		MaxBytes:   maxProgressBytes,
+	})
+	if err != nil {
+		// The file might not have been written yet.
+		log.V(1).Info("Unable to read progress file", "error", err.Error())
+		return
+	}
+
+	progress, err := parseProgress(resp.Contents)
+	if err != nil {
+		log.Info("Invalid progress file", "error", err.Error())
+		return
+	}
+
+	if model.Status.Progress != nil {
+		progress.UpdateTime = model.Status.Progress.UpdateTime
+		if equality.Semantic.DeepEqual(progress, model.Status.Progress) {
+			return
+		}
+	}
+	progress.UpdateTime = metav1.Now()
+	model.Status.Progress = progress
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+	"github.com/substratusai/substratus/internal/sci"
+)
+
+func Test_parseProgress(t *testing.T) {
+	cases := []struct {
+		name   string
+		input  string
+		expect *apiv1beta1.TrainingProgress
+		error  bool
+	}{
+		{
+			name:  "full",
+			input: `{"step": 100, "totalSteps": 1000, "epoch": 0.25, "loss": 1.5, "metrics": {"eval_loss": 1.25}}`,
+			expect: &apiv1beta1.TrainingProgress{
+				Step:       100,
+				TotalSteps: 1000,
+				Epoch:      "0.25",
+				Loss:       "1.5",
+				Metrics:    map[string]string{"eval_loss": "1.25"},
+			},
+		},
+		{
+			name:   "step-only",
+			input:  `{"step": 3}`,
+			expect: &apiv1beta1.TrainingProgress{Step: 3},
+		},
+		{
+			name:  "invalid",
+			input: `{"step": "abc"}`,
+			error: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			progress, err := parseProgress([]byte(c.input))
+			if c.error {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.expect, progress)
+		})
+	}
+}
+
+func Test_reconcileProgress(t *testing.T) {
+	sciClient := &sci.FakeSCIControllerClient{}
+	r := &ModelReconciler{SCI: sciClient}
+	model := &apiv1beta1.Model{
+		Status: apiv1beta1.ModelStatus{
+			Artifacts: apiv1beta1.ArtifactsStatus{URL: "gs://test-bucket/abc123"},
+		},
+	}
+	ctx := context.Background()
+
+	r.reconcileProgress(ctx, model)
+	require.Nil(t, model.Status.Progress, "no progress should be reported before the file is written")
+
+	sciClient.SetObject("test-bucket", "abc123/artifacts/status.json", []byte(`{"step": 3, "totalSteps": 10}`))
+	r.reconcileProgress(ctx, model)
+	require.NotNil(t, model.Status.Progress)
+	require.Equal(t, int64(3), model.Status.Progress.Step)
+	require.Equal(t, int64(10), model.Status.Progress.TotalSteps)
+	require.False(t, model.Status.Progress.UpdateTime.IsZero())
+
+	sciClient.SetObject("test-bucket", "abc123/artifacts/status.json", []byte(`not json`))
+	r.reconcileProgress(ctx, model)
+	require.Equal(t, int64(3), model.Status.Progress.Step, "invalid files should be ignored")
+}
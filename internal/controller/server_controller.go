@@ -20,7 +20,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 	"github.com/substratusai/substratus/internal/cloud"
 	"github.com/substratusai/substratus/internal/resources"
 	"github.com/substratusai/substratus/internal/sci"
@@ -34,6 +34,10 @@ type ServerReconciler struct {
 	Cloud cloud.Cloud
 	SCI   sci.ControllerClient
 
+	// GPUCatalog describes how to schedule GPUs. If nil, the default
+	// catalog is used.
+	GPUCatalog *resources.GPUCatalog
+
 	*ParamsReconciler
 
 	// log should be used outside the context of Reconcile()
@@ -53,7 +57,7 @@ func (r *ServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	log.Info("Reconciling Server")
 	defer log.Info("Done reconciling Server")
 
-	var server apiv1.Server
+	var server apiv1beta1.Server
 	if err := r.Get(ctx, req.NamespacedName, &server); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
@@ -79,8 +83,8 @@ func (r *ServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.log = mgr.GetLogger()
 
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&apiv1.Server{}).
-		Watches(&apiv1.Model{}, handler.EnqueueRequestsFromMapFunc(handler.MapFunc(r.findServersForModel))).
+		For(&apiv1beta1.Server{}).
+		Watches(&apiv1beta1.Model{}, handler.EnqueueRequestsFromMapFunc(handler.MapFunc(r.findServersForModel))).
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.Service{}).
 		Owns(&batchv1.Job{}).
@@ -88,9 +92,9 @@ func (r *ServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 }
 
 func (r *ServerReconciler) findServersForModel(ctx context.Context, obj client.Object) []reconcile.Request {
-	model := obj.(*apiv1.Model)
+	model := obj.(*apiv1beta1.Model)
 
-	var servers apiv1.ServerList
+	var servers apiv1beta1.ServerList
 	if err := r.List(ctx, &servers,
 		client.MatchingFields{modelServerModelIndex: model.Name},
 		client.InNamespace(obj.GetNamespace()),
@@ -111,7 +115,7 @@ func (r *ServerReconciler) findServersForModel(ctx context.Context, obj client.O
 	return reqs
 }
 
-func (r *ServerReconciler) serverDeployment(server *apiv1.Server, model *apiv1.Model) (*appsv1.Deployment, error) {
+func (r *ServerReconciler) serverDeployment(server *apiv1beta1.Server, model *apiv1beta1.Model) (*appsv1.Deployment, error) {
 	replicas := int32(1)
 
 	envVars, err := resolveEnv(server.Spec.Env)
@@ -197,25 +201,29 @@ func (r *ServerReconciler) serverDeployment(server *apiv1.Server, model *apiv1.M
 	}
 
 	if err := resources.Apply(&deploy.Spec.Template.ObjectMeta, &deploy.Spec.Template.Spec, containerName,
-		r.Cloud.Name(), server.Spec.Resources); err != nil {
+		r.Cloud.Name(), r.GPUCatalog, server.Spec.Resources, server.Spec.Scheduling); err != nil {
 		return nil, fmt.Errorf("applying resources: %w", err)
 	}
+	// Make room for the model on local disk (i.e. for caching).
+	if err := resources.EnsureDisk(&deploy.Spec.Template.Spec, containerName, model.Status.Artifacts.SizeBytes); err != nil {
+		return nil, fmt.Errorf("sizing disk for model: %w", err)
+	}
 
 	return deploy, nil
 }
 
-func (r *ServerReconciler) reconcileServer(ctx context.Context, server *apiv1.Server) (result, error) {
+func (r *ServerReconciler) reconcileServer(ctx context.Context, server *apiv1beta1.Server) (result, error) {
 	log := log.FromContext(ctx)
 
-	var model apiv1.Model
+	var model apiv1beta1.Model
 	if err := r.Get(ctx, client.ObjectKey{Name: server.Spec.Model.Name, Namespace: server.Namespace}, &model); err != nil {
 		if apierrors.IsNotFound(err) {
 			// Update this Model's status.
 			server.Status.Ready = false
 			meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
-				Type:               apiv1.ConditionServing,
+				Type:               apiv1beta1.ConditionServing,
 				Status:             metav1.ConditionFalse,
-				Reason:             apiv1.ReasonModelNotFound,
+				Reason:             apiv1beta1.ReasonModelNotFound,
 				ObservedGeneration: server.Generation,
 			})
 			if err := r.Status().Update(ctx, server); err != nil {
@@ -233,9 +241,9 @@ func (r *ServerReconciler) reconcileServer(ctx context.Context, server *apiv1.Se
 
 		server.Status.Ready = false
 		meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
-			Type:               apiv1.ConditionServing,
+			Type:               apiv1beta1.ConditionServing,
 			Status:             metav1.ConditionFalse,
-			Reason:             apiv1.ReasonModelNotReady,
+			Reason:             apiv1beta1.ReasonModelNotReady,
 			ObservedGeneration: server.Generation,
 		})
 		if err := r.Status().Update(ctx, server); err != nil {
@@ -280,17 +288,17 @@ func (r *ServerReconciler) reconcileServer(ctx context.Context, server *apiv1.Se
 	if deploy.Status.ReadyReplicas == 0 {
 		server.Status.Ready = false
 		meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
-			Type:               apiv1.ConditionServing,
+			Type:               apiv1beta1.ConditionServing,
 			Status:             metav1.ConditionFalse,
-			Reason:             apiv1.ReasonDeploymentNotReady,
+			Reason:             apiv1beta1.ReasonDeploymentNotReady,
 			ObservedGeneration: server.Generation,
 		})
 	} else {
 		server.Status.Ready = true
 		meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
-			Type:               apiv1.ConditionServing,
+			Type:               apiv1beta1.ConditionServing,
 			Status:             metav1.ConditionTrue,
-			Reason:             apiv1.ReasonDeploymentReady,
+			Reason:             apiv1beta1.ReasonDeploymentReady,
 			ObservedGeneration: server.Generation,
 		})
 	}
@@ -304,7 +312,7 @@ func (r *ServerReconciler) reconcileServer(ctx context.Context, server *apiv1.Se
 
 const modelServerHTTPServePortName = "http-serve"
 
-func (r *ServerReconciler) serverService(server *apiv1.Server, model *apiv1.Model) (*corev1.Service, error) {
+func (r *ServerReconciler) serverService(server *apiv1beta1.Server, model *apiv1beta1.Model) (*corev1.Service, error) {
 	s := &corev1.Service{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "v1",
@@ -334,7 +342,7 @@ func (r *ServerReconciler) serverService(server *apiv1.Server, model *apiv1.Mode
 	return s, nil
 }
 
-func withServerSelector(server *apiv1.Server, labels map[string]string) map[string]string {
+func withServerSelector(server *apiv1beta1.Server, labels map[string]string) map[string]string {
 	labels["role"] = "run"
 	labels["server"] = server.Name
 	return labels
@@ -6,7 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -17,12 +17,12 @@ import (
 func TestServerFromGit(t *testing.T) {
 	name := strings.ToLower(t.Name())
 
-	model := &apiv1.Model{
+	model := &apiv1beta1.Model{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name + "-mdl",
 			Namespace: "default",
 		},
-		Spec: apiv1.ModelSpec{
+		Spec: apiv1beta1.ModelSpec{
 			Image: ptr.To("some-image"),
 		},
 	}
@@ -31,19 +31,19 @@ func TestServerFromGit(t *testing.T) {
 
 	testModelLoad(t, model)
 
-	modelServer := &apiv1.Server{
+	modelServer := &apiv1beta1.Server{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name + "-nb",
 			Namespace: "default",
 		},
-		Spec: apiv1.ServerSpec{
+		Spec: apiv1beta1.ServerSpec{
 			Command: []string{"serve.sh"},
-			Build: &apiv1.Build{
-				Git: &apiv1.BuildGit{
+			Build: &apiv1beta1.Build{
+				Git: &apiv1beta1.BuildGit{
 					URL: "https://github.com/substratusai/some-server",
 				},
 			},
-			Model: apiv1.ObjectRef{
+			Model: apiv1beta1.ObjectRef{
 				Name: model.Name,
 			},
 		},
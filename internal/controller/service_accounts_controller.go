@@ -19,6 +19,8 @@ const (
 	modelServerServiceAccountName      = "model-server"
 	notebookServiceAccountName         = "notebook"
 	dataLoaderServiceAccountName       = "data-loader"
+	evaluatorServiceAccountName        = "evaluator"
+	batchInferenceServiceAccountName   = "batch-inference"
 )
 
 func AssociatePrincipalSCIServiceAccount(ctx context.Context, client *kubernetes.Clientset, cloud cloud.Cloud) error {
@@ -2,14 +2,23 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+	"github.com/substratusai/substratus/internal/cloud"
 )
 
 // result allows for propogating controller reconcile information up the call stack.
@@ -34,6 +43,57 @@ func reconcileJob(ctx context.Context, c client.Client, job *batchv1.Job) (resul
 	return result{success: complete, failure: failed}, nil
 }
 
+// reconcileJobGroup reconciles Jobs that only succeed together (for example
+// the workers of a distributed training run). When one of the Jobs fails, the
+// remaining Jobs are deleted as they can not make progress on their own.
+func reconcileJobGroup(ctx context.Context, c client.Client, jobs []*batchv1.Job) (result, error) {
+	found := make([]bool, len(jobs))
+	var failed bool
+	for i, job := range jobs {
+		if err := c.Get(ctx, client.ObjectKeyFromObject(job), job); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return result{}, fmt.Errorf("getting Job: %w", err)
+		}
+		found[i] = true
+		if _, f := jobResult(job); f {
+			failed = true
+		}
+	}
+
+	if failed {
+		for i, job := range jobs {
+			if !found[i] {
+				continue
+			}
+			if complete, f := jobResult(job); complete || f {
+				continue
+			}
+			if err := c.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+				return result{}, fmt.Errorf("deleting Job: %w", err)
+			}
+		}
+		return result{failure: true}, nil
+	}
+
+	success := true
+	for i, job := range jobs {
+		if !found[i] {
+			if err := c.Create(ctx, job); client.IgnoreAlreadyExists(err) != nil {
+				return result{}, fmt.Errorf("creating Job: %w", err)
+			}
+			success = false
+			continue
+		}
+		if complete, _ := jobResult(job); !complete {
+			success = false
+		}
+	}
+
+	return result{success: success}, nil
+}
+
 func jobResult(job *batchv1.Job) (complete bool, failed bool) {
 	for _, c := range job.Status.Conditions {
 		if c.Type == batchv1.JobComplete && c.Status == corev1.ConditionTrue {
@@ -48,6 +108,53 @@ func jobResult(job *batchv1.Job) (complete bool, failed bool) {
 	return
 }
 
+// applyRunPolicy overrides the defaults of a Job with a user-specified RunPolicy.
+func applyRunPolicy(job *batchv1.Job, policy *apiv1beta1.RunPolicy) {
+	if policy == nil {
+		return
+	}
+	if policy.Retries != nil {
+		job.Spec.BackoffLimit = ptr.To(*policy.Retries)
+	}
+	job.Spec.ActiveDeadlineSeconds = policy.MaxDurationSeconds
+	job.Spec.TTLSecondsAfterFinished = policy.TTLSecondsAfterFinished
+	if len(policy.PodFailurePolicy) > 0 {
+		job.Spec.PodFailurePolicy = &batchv1.PodFailurePolicy{
+			Rules: policy.PodFailurePolicy,
+		}
+	}
+}
+
+// jobFailedBeforeCleanup reports whether the Job for the current generation
+// already failed and might have been deleted after its TTL expired. Failed
+// Jobs should not be recreated in that case.
+func jobFailedBeforeCleanup(policy *apiv1beta1.RunPolicy, conditions []metav1.Condition, generation int64) bool {
+	if policy == nil || policy.TTLSecondsAfterFinished == nil {
+		return false
+	}
+	c := meta.FindStatusCondition(conditions, apiv1beta1.ConditionComplete)
+	return c != nil && c.Reason == apiv1beta1.ReasonJobFailed && c.ObservedGeneration == generation
+}
+
+// terminalFailureReasons are the reasons of a False Complete condition that
+// will not change without intervention (i.e. an update to the object).
+var terminalFailureReasons = map[string]bool{
+	apiv1beta1.ReasonJobFailed:              true,
+	apiv1beta1.ReasonBaseModelNotFound:      true,
+	apiv1beta1.ReasonDatasetMountInvalid:    true,
+	apiv1beta1.ReasonDatasetKindUnsupported: true,
+	apiv1beta1.ReasonArtifactsNotFound:      true,
+	apiv1beta1.ReasonArtifactsInvalid:       true,
+	apiv1beta1.ReasonResultsInvalid:         true,
+}
+
+// terminallyFailed returns true if the Complete condition reports a failure
+// that will not resolve without intervention.
+func terminallyFailed(conditions []metav1.Condition) bool {
+	cond := meta.FindStatusCondition(conditions, apiv1beta1.ConditionComplete)
+	return cond != nil && cond.Status == metav1.ConditionFalse && terminalFailureReasons[cond.Reason]
+}
+
 func isPodReady(pod *corev1.Pod) bool {
 	if pod.Status.Phase != corev1.PodRunning {
 		return false
@@ -91,3 +198,197 @@ func resolveEnv(env map[string]string) ([]corev1.EnvVar, error) {
 	}
 	return envs, nil
 }
+
+// datasetObject is an object whose artifacts can be mounted as a Dataset.
+type datasetObject interface {
+	cloud.ArtifactObject
+	GetStatusReady() bool
+}
+
+// errUnsupportedDatasetKind is returned for Dataset references of a kind
+// that can not be used as a Dataset. Retrying will not help, so callers
+// should report apiv1beta1.ReasonDatasetKindUnsupported instead.
+var errUnsupportedDatasetKind = errors.New("unsupported dataset kind")
+
+// getDatasetObject gets the object referenced by a Dataset reference: either
+// a Dataset or a BatchInference.
+func getDatasetObject(ctx context.Context, c client.Client, namespace string, ref apiv1beta1.DatasetRef) (datasetObject, error) {
+	var obj datasetObject
+	switch ref.Kind {
+	case "", "Dataset":
+		obj = &apiv1beta1.Dataset{}
+	case "BatchInference":
+		obj = &apiv1beta1.BatchInference{}
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnsupportedDatasetKind, ref.Kind)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// datasetInput is a resolved DatasetMount.
+type datasetInput struct {
+	// dir is relative to /content.
+	dir    string
+	object datasetObject
+}
+
+// getDatasetInputs gets the objects referenced by a list of DatasetMounts.
+// If an input is not found or not ready, the reason is returned instead.
+func getDatasetInputs(ctx context.Context, c client.Client, namespace string, mounts []apiv1beta1.DatasetMount) ([]datasetInput, string, error) {
+	inputs := make([]datasetInput, 0, len(mounts))
+	for _, m := range mounts {
+		obj, err := getDatasetObject(ctx, c, namespace, m.Dataset)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, apiv1beta1.ReasonDatasetNotFound, nil
+			}
+			if errors.Is(err, errUnsupportedDatasetKind) {
+				return nil, apiv1beta1.ReasonDatasetKindUnsupported, nil
+			}
+			return nil, "", fmt.Errorf("getting dataset %q: %w", m.Dataset.Name, err)
+		}
+		if !obj.GetStatusReady() {
+			return nil, apiv1beta1.ReasonDatasetNotReady, nil
+		}
+		inputs = append(inputs, datasetInput{dir: datasetMountDir(mounts, m), object: obj})
+	}
+	return inputs, "", nil
+}
+
+// invalidDatasetMounts returns why the Dataset mounts of obj can never be
+// satisfied (or "" if they can): mounts that share a directory would be
+// rejected as duplicate volume mounts, and mounts that (transitively) depend
+// on obj itself would never become ready.
+func invalidDatasetMounts(ctx context.Context, c client.Client, obj client.Object, mounts []apiv1beta1.DatasetMount) (string, error) {
+	dirs := map[string]string{}
+	for _, m := range mounts {
+		dir := datasetMountDir(mounts, m)
+		if other, ok := dirs[dir]; ok {
+			return fmt.Sprintf("datasets %q and %q are both mounted at /content/%v", other, m.Dataset.Name, dir), nil
+		}
+		dirs[dir] = m.Dataset.Name
+	}
+
+	// Objects that can not be referenced as a Dataset (i.e. Models) are
+	// never part of a cycle.
+	kind := datasetRefKind(obj)
+	if kind == "" {
+		return "", nil
+	}
+	self := datasetRefKey(kind, obj.GetName())
+	visited := map[string]bool{}
+	var visit func(refs []apiv1beta1.DatasetRef, path []string) (string, error)
+	visit = func(refs []apiv1beta1.DatasetRef, path []string) (string, error) {
+		for _, ref := range refs {
+			key := datasetRefKey(ref.Kind, ref.Name)
+			if key == self {
+				return fmt.Sprintf("dataset mounts form a cycle: %v", strings.Join(append(path, key), " -> ")), nil
+			}
+			if visited[key] {
+				continue
+			}
+			visited[key] = true
+
+			next, err := getDatasetObject(ctx, c, obj.GetNamespace(), ref)
+			if err != nil {
+				if apierrors.IsNotFound(err) || errors.Is(err, errUnsupportedDatasetKind) {
+					// Reported once the object is mounted.
+					continue
+				}
+				return "", fmt.Errorf("getting dataset %q: %w", ref.Name, err)
+			}
+			if msg, err := visit(datasetRefsOf(next), append(path, key)); msg != "" || err != nil {
+				return msg, err
+			}
+		}
+		return "", nil
+	}
+	return visit(datasetRefsOf(obj), []string{self})
+}
+
+// datasetRefsOf returns the Dataset references that an object depends on.
+func datasetRefsOf(obj client.Object) []apiv1beta1.DatasetRef {
+	var refs []apiv1beta1.DatasetRef
+	switch obj := obj.(type) {
+	case *apiv1beta1.Dataset:
+		for _, m := range obj.Spec.Datasets {
+			refs = append(refs, m.Dataset)
+		}
+	case *apiv1beta1.Model:
+		for _, m := range obj.GetDatasetMounts() {
+			refs = append(refs, m.Dataset)
+		}
+	case *apiv1beta1.BatchInference:
+		refs = append(refs, obj.Spec.Dataset)
+	case *apiv1beta1.Evaluation:
+		refs = append(refs, obj.Spec.Dataset)
+	case *apiv1beta1.Notebook:
+		if obj.Spec.Dataset != nil {
+			refs = append(refs, *obj.Spec.Dataset)
+		}
+	}
+	return refs
+}
+
+// datasetRefKind returns the kind that references to obj use or "" if obj
+// can not be referenced as a Dataset.
+func datasetRefKind(obj client.Object) string {
+	switch obj.(type) {
+	case *apiv1beta1.Dataset:
+		return "Dataset"
+	case *apiv1beta1.BatchInference:
+		return "BatchInference"
+	}
+	return ""
+}
+
+func datasetRefKey(kind, name string) string {
+	if kind == "" {
+		kind = "Dataset"
+	}
+	return kind + "/" + name
+}
+
+func datasetMountDir(mounts []apiv1beta1.DatasetMount, m apiv1beta1.DatasetMount) string {
+	switch {
+	case m.Name != "":
+		return "data/" + m.Name
+	case len(mounts) == 1:
+		return "data"
+	default:
+		return "data/" + m.Dataset.Name
+	}
+}
+
+// mountDatasetInputs mounts the artifacts of every input read-only into a
+// container.
+func mountDatasetInputs(cld cloud.Cloud, podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, container string, inputs []datasetInput) error {
+	for i, in := range inputs {
+		if err := cld.MountBucket(podMetadata, podSpec, in.object, cloud.MountBucketConfig{
+			Name: fmt.Sprintf("dataset-%v", i),
+			Mounts: []cloud.BucketMount{
+				{BucketSubdir: "artifacts", ContentSubdir: in.dir},
+			},
+			Container: container,
+			ReadOnly:  true,
+		}); err != nil {
+			return fmt.Errorf("mounting dataset %q: %w", in.object.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// updateObject updates an object while keeping its kind. Updating clears the
+// kind of typed objects, which is needed later on (i.e. to compute artifact
+// URLs).
+func updateObject(ctx context.Context, c client.Client, obj client.Object) error {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if err := c.Update(ctx, obj); err != nil {
+		return err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	return nil
+}
@@ -1,11 +1,20 @@
 package controller
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 )
 
 func Test_resolveEnv(t *testing.T) {
@@ -63,3 +72,177 @@ func Test_resolveEnv(t *testing.T) {
 		require.Truef(t, reflect.DeepEqual(actual, tc.expected), "resolveEnv(%v): expected %v, actual %v", tc.input, tc.expected, actual)
 	}
 }
+
+func Test_applyRunPolicy(t *testing.T) {
+	job := &batchv1.Job{Spec: batchv1.JobSpec{BackoffLimit: ptr.To(int32(2))}}
+	applyRunPolicy(job, nil)
+	require.Equal(t, int32(2), *job.Spec.BackoffLimit, "defaults should be kept without a policy")
+
+	rules := []batchv1.PodFailurePolicyRule{
+		{
+			Action: batchv1.PodFailurePolicyActionFailJob,
+			OnExitCodes: &batchv1.PodFailurePolicyOnExitCodesRequirement{
+				Operator: batchv1.PodFailurePolicyOnExitCodesOpIn,
+				Values:   []int32{1},
+			},
+		},
+		{
+			Action: batchv1.PodFailurePolicyActionIgnore,
+			OnPodConditions: []batchv1.PodFailurePolicyOnPodConditionsPattern{
+				{Type: corev1.DisruptionTarget, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+	applyRunPolicy(job, &apiv1beta1.RunPolicy{
+		Retries:                 ptr.To(int32(5)),
+		MaxDurationSeconds:      ptr.To(int64(3600)),
+		TTLSecondsAfterFinished: ptr.To(int32(600)),
+		PodFailurePolicy:        rules,
+	})
+	require.Equal(t, int32(5), *job.Spec.BackoffLimit)
+	require.Equal(t, int64(3600), *job.Spec.ActiveDeadlineSeconds)
+	require.Equal(t, int32(600), *job.Spec.TTLSecondsAfterFinished)
+	require.Equal(t, rules, job.Spec.PodFailurePolicy.Rules)
+}
+
+func Test_jobFailedBeforeCleanup(t *testing.T) {
+	failed := []metav1.Condition{{
+		Type:               apiv1beta1.ConditionComplete,
+		Status:             metav1.ConditionFalse,
+		Reason:             apiv1beta1.ReasonJobFailed,
+		ObservedGeneration: 2,
+	}}
+	withTTL := &apiv1beta1.RunPolicy{TTLSecondsAfterFinished: ptr.To(int32(0))}
+
+	require.True(t, jobFailedBeforeCleanup(withTTL, failed, 2))
+	require.False(t, jobFailedBeforeCleanup(withTTL, failed, 3), "a new generation should get a new Job")
+	require.False(t, jobFailedBeforeCleanup(nil, failed, 2), "without a TTL the failed Job is still around")
+	require.False(t, jobFailedBeforeCleanup(withTTL, nil, 2))
+}
+
+func Test_datasetMountDir(t *testing.T) {
+	single := []apiv1beta1.DatasetMount{
+		{Dataset: apiv1beta1.DatasetRef{Name: "squad"}},
+	}
+	require.Equal(t, "data", datasetMountDir(single, single[0]))
+
+	named := []apiv1beta1.DatasetMount{
+		{Name: "train", Dataset: apiv1beta1.DatasetRef{Name: "squad"}},
+	}
+	require.Equal(t, "data/train", datasetMountDir(named, named[0]))
+
+	multiple := []apiv1beta1.DatasetMount{
+		{Name: "train", Dataset: apiv1beta1.DatasetRef{Name: "squad"}},
+		{Dataset: apiv1beta1.DatasetRef{Name: "squad-v2"}},
+	}
+	require.Equal(t, "data/train", datasetMountDir(multiple, multiple[0]))
+	require.Equal(t, "data/squad-v2", datasetMountDir(multiple, multiple[1]))
+}
+
+func Test_getDatasetInputs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1beta1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&apiv1beta1.Dataset{
+			ObjectMeta: metav1.ObjectMeta{Name: "squad", Namespace: "default"},
+			Status:     apiv1beta1.DatasetStatus{Ready: true},
+		},
+	).Build()
+	ctx := context.Background()
+
+	inputs, reason, err := getDatasetInputs(ctx, c, "default", []apiv1beta1.DatasetMount{
+		{Dataset: apiv1beta1.DatasetRef{Name: "squad"}},
+	})
+	require.NoError(t, err)
+	require.Empty(t, reason)
+	require.Len(t, inputs, 1)
+
+	_, reason, err = getDatasetInputs(ctx, c, "default", []apiv1beta1.DatasetMount{
+		{Dataset: apiv1beta1.DatasetRef{Name: "missing"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, apiv1beta1.ReasonDatasetNotFound, reason)
+
+	_, reason, err = getDatasetInputs(ctx, c, "default", []apiv1beta1.DatasetMount{
+		{Dataset: apiv1beta1.DatasetRef{Name: "squad", Kind: "Model"}},
+	})
+	require.NoError(t, err, "an unsupported kind should not be retried")
+	require.Equal(t, apiv1beta1.ReasonDatasetKindUnsupported, reason)
+}
+
+func Test_invalidDatasetMounts(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1beta1.AddToScheme(scheme))
+
+	mount := func(name string) apiv1beta1.DatasetMount {
+		return apiv1beta1.DatasetMount{Dataset: apiv1beta1.DatasetRef{Name: name}}
+	}
+	dataset := func(name string, mounts ...apiv1beta1.DatasetMount) *apiv1beta1.Dataset {
+		return &apiv1beta1.Dataset{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       apiv1beta1.DatasetSpec{Datasets: mounts},
+		}
+	}
+	bi := &apiv1beta1.BatchInference{
+		ObjectMeta: metav1.ObjectMeta{Name: "predictions", Namespace: "default"},
+		Spec: apiv1beta1.BatchInferenceSpec{
+			Dataset: apiv1beta1.DatasetRef{Name: "from-predictions"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		dataset("squad"),
+		dataset("a", mount("b")),
+		dataset("b", mount("a")),
+		dataset("from-predictions", apiv1beta1.DatasetMount{
+			Dataset: apiv1beta1.DatasetRef{Name: "predictions", Kind: "BatchInference"},
+		}),
+		bi,
+	).Build()
+	ctx := context.Background()
+
+	cases := []struct {
+		name   string
+		obj    client.Object
+		mounts []apiv1beta1.DatasetMount
+		expect string
+	}{
+		{
+			name:   "valid",
+			obj:    dataset("derived", mount("squad"), mount("missing")),
+			mounts: []apiv1beta1.DatasetMount{mount("squad"), mount("missing")},
+		},
+		{
+			name:   "self",
+			obj:    dataset("a", mount("a")),
+			mounts: []apiv1beta1.DatasetMount{mount("a")},
+			expect: "dataset mounts form a cycle: Dataset/a -> Dataset/a",
+		},
+		{
+			name:   "cycle",
+			obj:    dataset("a", mount("b")),
+			mounts: []apiv1beta1.DatasetMount{mount("b")},
+			expect: "dataset mounts form a cycle: Dataset/a -> Dataset/b -> Dataset/a",
+		},
+		{
+			name:   "cycle through a batch inference",
+			obj:    bi,
+			expect: "dataset mounts form a cycle: BatchInference/predictions -> Dataset/from-predictions -> BatchInference/predictions",
+		},
+		{
+			name: "same dir",
+			obj:  &apiv1beta1.Model{ObjectMeta: metav1.ObjectMeta{Name: "m", Namespace: "default"}},
+			mounts: []apiv1beta1.DatasetMount{
+				{Name: "squad", Dataset: apiv1beta1.DatasetRef{Name: "squad-v2"}},
+				mount("squad"),
+			},
+			expect: `datasets "squad-v2" and "squad" are both mounted at /content/data/squad`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg, err := invalidDatasetMounts(ctx, c, tc.obj, tc.mounts)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, msg)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// copyDir copies every regular file under src into dst. Files that already
+// exist with the same size are skipped.
+func copyDir(src, dst string) error {
+	if src == "" {
+		return fmt.Errorf("no source directory")
+	}
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if err := verify(target, file{size: info.Size()}); err == nil {
+			log.Printf("Skipping %v: already copied", rel)
+			return nil
+		}
+		log.Printf("Copying %v", rel)
+		if err := copyFile(p, target, info.Size()); err != nil {
+			return fmt.Errorf("copying %v: %w", rel, err)
+		}
+		return nil
+	})
+}
+
+func copyFile(src, dst string, size int64) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	partial := dst + partialSuffix
+	out, err := os.Create(partial)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	if err := verify(partial, file{size: size}); err != nil {
+		os.Remove(partial)
+		return err
+	}
+
+	return os.Rename(partial, dst)
+}
@@ -0,0 +1,147 @@
+package importer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// partialSuffix is appended to the names of files that are being downloaded.
+const partialSuffix = ".partial"
+
+// download downloads a file into dir. The file is written to a partial file
+// first which is resumed on retries (and reruns) and only renamed into place
+// once it is verified.
+func (im *Importer) download(ctx context.Context, f file, dir string) error {
+	dst := filepath.Join(dir, f.path)
+
+	if err := verify(dst, f); err == nil {
+		log.Printf("Skipping %v: already downloaded", f.path)
+		return nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		log.Printf("Downloading %v again: %v", f.path, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+
+	partial := dst + partialSuffix
+	var err error
+	for attempt := 0; attempt <= im.Retries; attempt++ {
+		if attempt > 0 {
+			log.Printf("Resuming %v (attempt %v): %v", f.path, attempt+1, err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt) * time.Second):
+			}
+		}
+		if err = im.fetch(ctx, f, partial); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := verify(partial, f); err != nil {
+		// Start from scratch next time.
+		os.Remove(partial)
+		return err
+	}
+
+	return os.Rename(partial, dst)
+}
+
+// fetch downloads a file into the given path, continuing from the end of
+// the file if it already exists.
+func (im *Importer) fetch(ctx context.Context, f file, dst string) error {
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("opening file: %w", err)
+	}
+	defer out.Close()
+
+	offset, err := out.Seek(0, io.SeekEnd)
+	if err != nil {
+		return fmt.Errorf("seeking file: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.url, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	if f.token != "" {
+		req.Header.Set("Authorization", "Bearer "+f.token)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := im.client().Do(req)
+	if err != nil {
+		return fmt.Errorf("requesting: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		// The server does not support ranges: start over.
+		if err := out.Truncate(0); err != nil {
+			return fmt.Errorf("truncating file: %w", err)
+		}
+		if _, err := out.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("seeking file: %w", err)
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		// Already complete.
+		return nil
+	default:
+		return fmt.Errorf("unexpected status: %v", resp.Status)
+	}
+
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+
+	return out.Close()
+}
+
+// verify checks the size and checksum of a downloaded file (when known).
+func verify(p string, f file) error {
+	info, err := os.Stat(p)
+	if err != nil {
+		return err
+	}
+	if f.size > 0 && info.Size() != f.size {
+		return fmt.Errorf("size mismatch: expected %v, got %v", f.size, info.Size())
+	}
+	if f.sha256 == "" {
+		return nil
+	}
+
+	in, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, in); err != nil {
+		return fmt.Errorf("hashing file: %w", err)
+	}
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != f.sha256 {
+		return fmt.Errorf("sha256 mismatch: expected %v, got %v", f.sha256, sum)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+)
+
+// huggingFaceRevision is the response of the revision API.
+type huggingFaceRevision struct {
+	SHA      string `json:"sha"`
+	Siblings []struct {
+		RFilename string `json:"rfilename"`
+		Size      int64  `json:"size"`
+		LFS       *struct {
+			SHA256 string `json:"sha256"`
+			Size   int64  `json:"size"`
+		} `json:"lfs"`
+	} `json:"siblings"`
+}
+
+// huggingFaceFiles lists the files of a revision of a repo. Downloads are
+// pinned to the commit of the revision at the time of listing.
+func (im *Importer) huggingFaceFiles(ctx context.Context, src *apiv1beta1.HuggingFaceSource) ([]file, error) {
+	endpoint := strings.TrimSuffix(im.HuggingFaceEndpoint, "/")
+	if endpoint == "" {
+		endpoint = defaultHuggingFaceEndpoint
+	}
+	revision := src.Revision
+	if revision == "" {
+		revision = "main"
+	}
+	repoType := src.RepoType
+	if repoType == "" {
+		repoType = "model"
+	}
+	// Dataset files are served under a prefix, model files are not.
+	var prefix string
+	if repoType != "model" {
+		prefix = repoType + "s/"
+	}
+
+	apiURL := fmt.Sprintf("%s/api/%ss/%s/revision/%s?blobs=true", endpoint, repoType, src.Repo, url.PathEscape(revision))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	if im.HuggingFaceToken != "" {
+		req.Header.Set("Authorization", "Bearer "+im.HuggingFaceToken)
+	}
+	resp, err := im.client().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status: %v", resp.Status)
+	}
+
+	var rev huggingFaceRevision
+	if err := json.NewDecoder(resp.Body).Decode(&rev); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	if rev.SHA != "" {
+		revision = rev.SHA
+	}
+
+	var files []file
+	for _, s := range rev.Siblings {
+		match, err := matchAny(src.Files, s.RFilename)
+		if err != nil {
+			return nil, err
+		}
+		if !match {
+			continue
+		}
+		f := file{
+			url:   fmt.Sprintf("%s/%s%s/resolve/%s/%s", endpoint, prefix, src.Repo, url.PathEscape(revision), s.RFilename),
+			path:  s.RFilename,
+			size:  s.Size,
+			token: im.HuggingFaceToken,
+		}
+		if s.LFS != nil {
+			f.sha256 = s.LFS.SHA256
+			f.size = s.LFS.Size
+		}
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files matched in repo %q at revision %q", src.Repo, revision)
+	}
+
+	return files, nil
+}
+
+// matchAny returns true if there are no patterns or the name matches any of
+// them.
+func matchAny(patterns []string, name string) (bool, error) {
+	if len(patterns) == 0 {
+		return true, nil
+	}
+	for _, p := range patterns {
+		match, err := path.Match(p, name)
+		if err != nil {
+			return false, fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+		if match {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,111 @@
+// Package importer implements the built-in importer that downloads the
+// artifacts of Datasets and Models from well-known sources.
+package importer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+)
+
+// SourceEnvVar is the environment variable that the import source is passed
+// in (as JSON).
+const SourceEnvVar = "IMPORT_SOURCE"
+
+const defaultHuggingFaceEndpoint = "https://huggingface.co"
+
+// Importer downloads the files of an import source into a directory.
+type Importer struct {
+	// Client is used for all HTTP requests. Defaults to http.DefaultClient.
+	Client *http.Client
+
+	// HuggingFaceEndpoint defaults to https://huggingface.co.
+	HuggingFaceEndpoint string
+	// HuggingFaceToken is sent as a bearer token to Hugging Face (if set).
+	HuggingFaceToken string
+
+	// SourceDir is where bucket sources are mounted.
+	SourceDir string
+
+	// Retries is the number of times a failed download is resumed.
+	Retries int
+}
+
+// file is a single file to download.
+type file struct {
+	url string
+	// path relative to the destination directory.
+	path string
+	// sha256 and size are verified if set.
+	sha256 string
+	size   int64
+	// token is sent as a bearer token (if set).
+	token string
+}
+
+// Import downloads every file of the source into dir. Files that were
+// already downloaded (and verified) are skipped and partial downloads are
+// resumed, so Import can be rerun after a failure.
+func (im *Importer) Import(ctx context.Context, src apiv1beta1.ImportSource, dir string) error {
+	var files []file
+	switch {
+	case src.HuggingFace != nil:
+		var err error
+		files, err = im.huggingFaceFiles(ctx, src.HuggingFace)
+		if err != nil {
+			return fmt.Errorf("listing hugging face files: %w", err)
+		}
+	case len(src.URLs) > 0:
+		for _, u := range src.URLs {
+			f, err := urlFile(u)
+			if err != nil {
+				return err
+			}
+			files = append(files, f)
+		}
+	case src.Bucket != nil:
+		return copyDir(im.SourceDir, dir)
+	default:
+		return fmt.Errorf("no source specified")
+	}
+
+	for _, f := range files {
+		if !filepath.IsLocal(f.path) {
+			return fmt.Errorf("invalid file path: %q", f.path)
+		}
+		log.Printf("Downloading %v to %v", f.url, f.path)
+		if err := im.download(ctx, f, dir); err != nil {
+			return fmt.Errorf("downloading %v: %w", f.url, err)
+		}
+	}
+
+	return nil
+}
+
+func urlFile(src apiv1beta1.URLSource) (file, error) {
+	u, err := url.Parse(src.URL)
+	if err != nil {
+		return file{}, fmt.Errorf("parsing url: %w", err)
+	}
+	p := src.Path
+	if p == "" {
+		p = path.Base(u.Path)
+		if p == "/" || p == "." {
+			return file{}, fmt.Errorf("unable to determine path for url: %v", src.URL)
+		}
+	}
+	return file{url: src.URL, path: p, sha256: src.SHA256}, nil
+}
+
+func (im *Importer) client() *http.Client {
+	if im.Client == nil {
+		return http.DefaultClient
+	}
+	return im.Client
+}
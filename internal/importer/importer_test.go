@@ -0,0 +1,194 @@
+package importer_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+	"github.com/substratusai/substratus/internal/importer"
+)
+
+// fileServer serves files with support for range requests and records the
+// requests it received.
+type fileServer struct {
+	files map[string][]byte
+
+	mtx    sync.Mutex
+	ranges map[string][]string
+}
+
+func newFileServer(t *testing.T, files map[string][]byte, extra http.Handler) (*httptest.Server, *fileServer) {
+	fs := &fileServer{files: files, ranges: map[string][]string{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/files/", func(w http.ResponseWriter, r *http.Request) {
+		data, ok := fs.files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fs.mtx.Lock()
+		fs.ranges[r.URL.Path] = append(fs.ranges[r.URL.Path], r.Header.Get("Range"))
+		fs.mtx.Unlock()
+		http.ServeContent(w, r, r.URL.Path, time.Time{}, bytes.NewReader(data))
+	})
+	if extra != nil {
+		mux.Handle("/", extra)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, fs
+}
+
+func sha(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestImportURLs(t *testing.T) {
+	ctx := context.Background()
+	a := []byte("hello world")
+	b := bytes.Repeat([]byte("0123456789"), 1000)
+	srv, fs := newFileServer(t, map[string][]byte{
+		"/files/a.txt":   a,
+		"/files/b/c.bin": b,
+	}, nil)
+
+	dir := t.TempDir()
+	// A previous run that was interrupted half way through b.
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "nested"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "nested", "c.bin.partial"), b[:5000], 0644))
+
+	im := &importer.Importer{}
+	src := apiv1beta1.ImportSource{
+		URLs: []apiv1beta1.URLSource{
+			{URL: srv.URL + "/files/a.txt", SHA256: sha(a)},
+			{URL: srv.URL + "/files/b/c.bin", Path: "nested/c.bin", SHA256: sha(b)},
+		},
+	}
+	require.NoError(t, im.Import(ctx, src, dir))
+
+	got, err := os.ReadFile(filepath.Join(dir, "a.txt"))
+	require.NoError(t, err)
+	require.Equal(t, a, got)
+	got, err = os.ReadFile(filepath.Join(dir, "nested", "c.bin"))
+	require.NoError(t, err)
+	require.Equal(t, b, got, "resumed download should be complete")
+	require.NoFileExists(t, filepath.Join(dir, "nested", "c.bin.partial"))
+	require.Equal(t, []string{"bytes=5000-"}, fs.ranges["/files/b/c.bin"], "download should be resumed")
+
+	// Rerunning should not download anything again.
+	srv.Close()
+	require.NoError(t, im.Import(ctx, src, dir))
+}
+
+func TestImportURLsChecksumMismatch(t *testing.T) {
+	srv, _ := newFileServer(t, map[string][]byte{
+		"/files/a.txt": []byte("tampered"),
+	}, nil)
+
+	dir := t.TempDir()
+	im := &importer.Importer{}
+	err := im.Import(context.Background(), apiv1beta1.ImportSource{
+		URLs: []apiv1beta1.URLSource{
+			{URL: srv.URL + "/files/a.txt", SHA256: sha([]byte("original"))},
+		},
+	}, dir)
+	require.ErrorContains(t, err, "sha256 mismatch")
+	require.NoFileExists(t, filepath.Join(dir, "a.txt"))
+	require.NoFileExists(t, filepath.Join(dir, "a.txt.partial"))
+}
+
+func TestImportURLsInvalidPath(t *testing.T) {
+	im := &importer.Importer{}
+	err := im.Import(context.Background(), apiv1beta1.ImportSource{
+		URLs: []apiv1beta1.URLSource{
+			{URL: "http://localhost/a.txt", Path: "../a.txt"},
+		},
+	}, t.TempDir())
+	require.ErrorContains(t, err, "invalid file path")
+}
+
+func TestImportHuggingFace(t *testing.T) {
+	config := []byte(`{"model_type": "opt"}`)
+	weights := bytes.Repeat([]byte{1, 2, 3}, 1000)
+
+	const commit = "abc123"
+	var token string
+	api := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("Authorization")
+		if r.URL.Path != "/api/models/facebook/opt-125m/revision/main" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"sha": commit,
+			"siblings": []map[string]any{
+				{"rfilename": "config.json", "size": len(config)},
+				{"rfilename": "pytorch_model.bin", "size": 100, "lfs": map[string]any{"sha256": sha(weights), "size": len(weights)}},
+				{"rfilename": "README.md", "size": 10},
+			},
+		})
+	})
+	mux := http.NewServeMux()
+	mux.Handle("/api/", api)
+	mux.HandleFunc("/facebook/opt-125m/resolve/"+commit+"/", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/files/"+filepath.Base(r.URL.Path), http.StatusFound)
+	})
+	srv, _ := newFileServer(t, map[string][]byte{
+		"/files/config.json":       config,
+		"/files/pytorch_model.bin": weights,
+	}, mux)
+
+	dir := t.TempDir()
+	im := &importer.Importer{
+		HuggingFaceEndpoint: srv.URL,
+		HuggingFaceToken:    "secret",
+	}
+	require.NoError(t, im.Import(context.Background(), apiv1beta1.ImportSource{
+		HuggingFace: &apiv1beta1.HuggingFaceSource{
+			Repo:  "facebook/opt-125m",
+			Files: []string{"*.json", "*.bin"},
+		},
+	}, dir))
+	require.Equal(t, "Bearer secret", token)
+
+	got, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	require.NoError(t, err)
+	require.Equal(t, config, got)
+	got, err = os.ReadFile(filepath.Join(dir, "pytorch_model.bin"))
+	require.NoError(t, err)
+	require.Equal(t, weights, got)
+	require.NoFileExists(t, filepath.Join(dir, "README.md"), "files that do not match should be skipped")
+}
+
+func TestImportBucket(t *testing.T) {
+	src := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "train"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "train", "a.jsonl"), []byte(`{"a": 1}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "b.jsonl"), []byte(`{"b": 2}`), 0644))
+
+	dst := t.TempDir()
+	im := &importer.Importer{SourceDir: src}
+	require.NoError(t, im.Import(context.Background(), apiv1beta1.ImportSource{
+		Bucket: &apiv1beta1.BucketSource{URL: "gs://some-bucket/some-path"},
+	}, dst))
+
+	got, err := os.ReadFile(filepath.Join(dst, "train", "a.jsonl"))
+	require.NoError(t, err)
+	require.Equal(t, `{"a": 1}`, string(got))
+	got, err = os.ReadFile(filepath.Join(dst, "b.jsonl"))
+	require.NoError(t, err)
+	require.Equal(t, `{"b": 2}`, string(got))
+}
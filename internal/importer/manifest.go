@@ -0,0 +1,95 @@
+package importer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// Manifest lists the files of a directory of artifacts. It is stored
+// alongside the artifacts (as manifest.json) so that their integrity can be
+// checked later on.
+type Manifest struct {
+	// Size is the total size of all files in bytes.
+	Size  int64          `json:"size"`
+	Files []ManifestFile `json:"files"`
+}
+
+type ManifestFile struct {
+	// Path relative to the artifacts directory (using forward slashes).
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// NewManifest hashes every regular file under dir. Files are listed in
+// lexical order.
+func NewManifest(dir string) (*Manifest, error) {
+	m := &Manifest{Files: []ManifestFile{}}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		log.Printf("Hashing %v", rel)
+		size, sum, err := hashFile(p)
+		if err != nil {
+			return fmt.Errorf("hashing %v: %w", rel, err)
+		}
+		m.Files = append(m.Files, ManifestFile{
+			Path:   filepath.ToSlash(rel),
+			Size:   size,
+			SHA256: sum,
+		})
+		m.Size += size
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// WriteManifest writes the manifest of dir to the given file.
+func WriteManifest(dir, file string) error {
+	m, err := NewManifest(dir)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling manifest: %w", err)
+	}
+	partial := file + partialSuffix
+	if err := os.WriteFile(partial, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(partial, file)
+}
+
+func hashFile(p string) (int64, string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return 0, "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return 0, "", err
+	}
+	return n, hex.EncodeToString(h.Sum(nil)), nil
+}
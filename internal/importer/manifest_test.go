@@ -0,0 +1,35 @@
+package importer_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/substratusai/substratus/internal/importer"
+)
+
+func TestWriteManifest(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "train"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "train", "a.jsonl"), []byte(`{"a": 1}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.jsonl"), []byte(`{"bb": 2}`), 0644))
+
+	file := filepath.Join(t.TempDir(), "manifest.json")
+	require.NoError(t, importer.WriteManifest(dir, file))
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	var m importer.Manifest
+	require.NoError(t, json.Unmarshal(data, &m))
+
+	require.Equal(t, importer.Manifest{
+		Size: 17,
+		Files: []importer.ManifestFile{
+			{Path: "b.jsonl", Size: 9, SHA256: sha([]byte(`{"bb": 2}`))},
+			{Path: "train/a.jsonl", Size: 8, SHA256: sha([]byte(`{"a": 1}`))},
+		},
+	}, m)
+}
@@ -0,0 +1,147 @@
+package resources
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+)
+
+//go:embed gpu_catalog.yaml
+var defaultGPUCatalog []byte
+
+// GPUCatalog describes how to schedule accelerators on each cloud.
+type GPUCatalog struct {
+	Clouds map[string]CloudGPUs `json:"clouds"`
+}
+
+type CloudGPUs struct {
+	// DefaultCapacityType is used when a capacity type is not requested.
+	DefaultCapacityType apiv1beta1.CapacityType `json:"defaultCapacityType,omitempty"`
+	// CapacityTypes holds the scheduling constraints that select
+	// spot/on-demand nodes. They are applied on top of the GPU constraints.
+	CapacityTypes map[apiv1beta1.CapacityType]GPUScheduling `json:"capacityTypes,omitempty"`
+	// GPUs that are supported on this cloud.
+	GPUs map[apiv1beta1.GPUType]GPUInfo `json:"gpus,omitempty"`
+	// Default is used for any GPU type that is not listed under GPUs.
+	Default *GPUInfo `json:"default,omitempty"`
+}
+
+type GPUInfo struct {
+	ResourceName corev1.ResourceName `json:"resourceName"`
+	GPUScheduling
+}
+
+type GPUScheduling struct {
+	NodeSelector map[string]string   `json:"nodeSelector,omitempty"`
+	Tolerations  []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// DefaultGPUCatalog returns the catalog that is compiled into the
+// controller. It is parsed once and shared, so it must not be modified.
+var DefaultGPUCatalog = sync.OnceValue(func() *GPUCatalog {
+	c, err := ParseGPUCatalog(defaultGPUCatalog)
+	if err != nil {
+		panic(fmt.Sprintf("parsing default gpu catalog: %v", err))
+	}
+	return c
+})
+
+// LoadGPUCatalog reads a catalog from a YAML file (i.e. a mounted ConfigMap).
+func LoadGPUCatalog(path string) (*GPUCatalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+	return ParseGPUCatalog(data)
+}
+
+func ParseGPUCatalog(data []byte) (*GPUCatalog, error) {
+	var c GPUCatalog
+	if err := yaml.UnmarshalStrict(data, &c); err != nil {
+		return nil, fmt.Errorf("unmarshalling: %w", err)
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (c *GPUCatalog) validate() error {
+	for cloudName, cld := range c.Clouds {
+		if cld.DefaultCapacityType != "" {
+			if _, ok := cld.CapacityTypes[cld.DefaultCapacityType]; !ok {
+				return fmt.Errorf("cloud %s: default capacity type %q is not defined", cloudName, cld.DefaultCapacityType)
+			}
+		}
+		for gpuType, info := range cld.GPUs {
+			if info.ResourceName == "" {
+				return fmt.Errorf("cloud %s: gpu %s: resourceName is required", cloudName, gpuType)
+			}
+		}
+		if cld.Default != nil && cld.Default.ResourceName == "" {
+			return fmt.Errorf("cloud %s: default: resourceName is required", cloudName)
+		}
+	}
+	return nil
+}
+
+// GetGPUInfo returns the scheduling information for a GPU type on a given
+// cloud. If capacityType is empty, the cloud's default capacity type is used.
+func (c *GPUCatalog) GetGPUInfo(cloudName string, gpuType apiv1beta1.GPUType, capacityType apiv1beta1.CapacityType) (*GPUInfo, error) {
+	cld, ok := c.Clouds[cloudName]
+	if !ok {
+		return nil, fmt.Errorf("GPUs are not supported on cloud %s", cloudName)
+	}
+
+	info, ok := cld.GPUs[gpuType]
+	if !ok {
+		if cld.Default == nil {
+			return nil, fmt.Errorf("GPU %s is not supported on cloud %s", gpuType, cloudName)
+		}
+		info = *cld.Default
+	}
+
+	result := &GPUInfo{
+		ResourceName: info.ResourceName,
+		GPUScheduling: GPUScheduling{
+			NodeSelector: map[string]string{},
+		},
+	}
+	result.merge(info.GPUScheduling)
+
+	if capacityType == "" {
+		capacityType = cld.DefaultCapacityType
+	}
+	if capacityType != "" {
+		sched, err := c.GetCapacityScheduling(cloudName, capacityType)
+		if err != nil {
+			return nil, err
+		}
+		result.merge(*sched)
+	}
+
+	return result, nil
+}
+
+// GetCapacityScheduling returns the scheduling constraints that select nodes
+// of the given capacity type on a given cloud.
+func (c *GPUCatalog) GetCapacityScheduling(cloudName string, capacityType apiv1beta1.CapacityType) (*GPUScheduling, error) {
+	sched, ok := c.Clouds[cloudName].CapacityTypes[capacityType]
+	if !ok {
+		return nil, fmt.Errorf("capacity type %q is not supported on cloud %s", capacityType, cloudName)
+	}
+	return &sched, nil
+}
+
+func (i *GPUInfo) merge(s GPUScheduling) {
+	for k, v := range s.NodeSelector {
+		i.NodeSelector[k] = v
+	}
+	i.Tolerations = append(i.Tolerations, s.Tolerations...)
+}
@@ -0,0 +1,147 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+	"github.com/substratusai/substratus/internal/cloud"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_DefaultGPUCatalog(t *testing.T) {
+	catalog := DefaultGPUCatalog()
+	require.Same(t, catalog, DefaultGPUCatalog(), "the default catalog should only be parsed once")
+
+	gkeSpot := corev1.Toleration{
+		Key:      "cloud.google.com/gke-spot",
+		Operator: corev1.TolerationOpEqual,
+		Value:    "true",
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+
+	testCases := []struct {
+		Name         string
+		Cloud        string
+		GPUType      apiv1beta1.GPUType
+		CapacityType apiv1beta1.CapacityType
+		Expected     *GPUInfo
+		ExpectErr    bool
+	}{
+		{
+			Name:    "gcp defaults to spot",
+			Cloud:   cloud.GCPName,
+			GPUType: apiv1beta1.GPUTypeNvidiaL4,
+			Expected: &GPUInfo{
+				ResourceName: "nvidia.com/gpu",
+				GPUScheduling: GPUScheduling{
+					NodeSelector: map[string]string{"cloud.google.com/gke-accelerator": "nvidia-l4"},
+					Tolerations:  []corev1.Toleration{gkeSpot},
+				},
+			},
+		},
+		{
+			Name:         "gcp on-demand",
+			Cloud:        cloud.GCPName,
+			GPUType:      apiv1beta1.GPUTypeNvidiaH100,
+			CapacityType: apiv1beta1.CapacityTypeOnDemand,
+			Expected: &GPUInfo{
+				ResourceName: "nvidia.com/gpu",
+				GPUScheduling: GPUScheduling{
+					NodeSelector: map[string]string{"cloud.google.com/gke-accelerator": "nvidia-h100-80gb"},
+				},
+			},
+		},
+		{
+			Name:      "gcp unsupported gpu",
+			Cloud:     cloud.GCPName,
+			GPUType:   apiv1beta1.GPUTypeNvidiaA10G,
+			ExpectErr: true,
+		},
+		{
+			Name:      "unknown cloud",
+			Cloud:     "aws",
+			GPUType:   apiv1beta1.GPUTypeNvidiaA10G,
+			ExpectErr: true,
+		},
+		{
+			Name:    "on-prem falls back to default",
+			Cloud:   cloud.KindName,
+			GPUType: apiv1beta1.GPUTypeNvidiaV100,
+			Expected: &GPUInfo{
+				ResourceName:  "nvidia.com/gpu",
+				GPUScheduling: GPUScheduling{NodeSelector: map[string]string{}},
+			},
+		},
+		{
+			Name:    "on-prem amd",
+			Cloud:   cloud.PVCName,
+			GPUType: apiv1beta1.GPUTypeAMDMI300X,
+			Expected: &GPUInfo{
+				ResourceName:  "amd.com/gpu",
+				GPUScheduling: GPUScheduling{NodeSelector: map[string]string{}},
+			},
+		},
+		{
+			Name:         "on-prem has no capacity types",
+			Cloud:        cloud.S3Name,
+			GPUType:      apiv1beta1.GPUTypeNvidiaT4,
+			CapacityType: apiv1beta1.CapacityTypeSpot,
+			ExpectErr:    true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("Running test case %v", testCase.Name)
+		info, err := catalog.GetGPUInfo(testCase.Cloud, testCase.GPUType, testCase.CapacityType)
+		if testCase.ExpectErr {
+			require.Error(t, err, "Expected error with case", testCase.Name)
+			continue
+		}
+		require.NoError(t, err, "Encountered error with case", testCase.Name)
+		require.Equal(t, testCase.Expected, info, "Unexpected info with case", testCase.Name)
+	}
+}
+
+func Test_ParseGPUCatalog(t *testing.T) {
+	_, err := ParseGPUCatalog([]byte(`
+clouds:
+  gcp:
+    gpus:
+      nvidia-t4: {}
+`))
+	require.Error(t, err, "resourceName should be required")
+
+	_, err = ParseGPUCatalog([]byte(`
+clouds:
+  gcp:
+    defaultCapacityType: spot
+`))
+	require.Error(t, err, "default capacity type should be defined")
+
+	_, err = ParseGPUCatalog([]byte(`
+clouds:
+  gcp:
+    unknownField: true
+`))
+	require.Error(t, err, "unknown fields should be rejected")
+
+	catalog, err := ParseGPUCatalog([]byte(`
+clouds:
+  gcp:
+    gpus:
+      nvidia-t4:
+        resourceName: nvidia.com/gpu
+        nodeSelector:
+          pool: t4
+        tolerations:
+        - key: nvidia.com/gpu
+          operator: Exists
+          effect: NoSchedule
+`))
+	require.NoError(t, err)
+	info, err := catalog.GetGPUInfo(cloud.GCPName, apiv1beta1.GPUTypeNvidiaT4, "")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"pool": "t4"}, info.NodeSelector)
+	require.Len(t, info.Tolerations, 1)
+}
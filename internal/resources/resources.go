@@ -7,22 +7,25 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 )
 
-func Apply(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, containerName string, cloudName string, res *apiv1.Resources) error {
+// Apply sets container resources and schedules the Pod onto nodes with the
+// requested GPUs and capacity type. If gpus is nil, the default GPU catalog
+// is used.
+func Apply(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, containerName string, cloudName string, gpus *GPUCatalog, res *apiv1beta1.Resources, sched *apiv1beta1.Scheduling) error {
 	// TODO: Auto-determine resources if nil.
 	if res == nil {
 		// TODO(nstogner): Cloud-specific conditional should go away...
 		// Most likely this stuff will all go into a ConfigMap that contains cloud-specific
 		// information.
 		if cloudName == "kind" {
-			res = &apiv1.Resources{}
+			res = &apiv1beta1.Resources{}
 		} else {
-			res = &apiv1.Resources{
-				CPU:    2,
-				Memory: 4,
-				Disk:   100,
+			res = &apiv1beta1.Resources{
+				CPU:    resource.NewQuantity(2, resource.DecimalSI),
+				Memory: resource.NewQuantity(4*gigabyte, resource.BinarySI),
+				Disk:   resource.NewQuantity(100*gigabyte, resource.BinarySI),
 			}
 		}
 	}
@@ -32,14 +35,26 @@ func Apply(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, containerNam
 		Limits:   corev1.ResourceList{},
 	}
 
-	resources.Requests[corev1.ResourceCPU] = *resource.NewQuantity(res.CPU, resource.DecimalSI)
-	resources.Requests[corev1.ResourceMemory] = *resource.NewQuantity(res.Memory*gigabyte, resource.BinarySI)
-	resources.Requests[corev1.ResourceEphemeralStorage] = *resource.NewQuantity(res.Disk*gigabyte, resource.BinarySI)
+	setQuantity(resources.Requests, corev1.ResourceCPU, res.CPU)
+	setQuantity(resources.Requests, corev1.ResourceMemory, res.Memory)
+	setQuantity(resources.Requests, corev1.ResourceEphemeralStorage, res.Disk)
+	if res.Limits != nil {
+		setQuantity(resources.Limits, corev1.ResourceCPU, res.Limits.CPU)
+		setQuantity(resources.Limits, corev1.ResourceMemory, res.Limits.Memory)
+		setQuantity(resources.Limits, corev1.ResourceEphemeralStorage, res.Limits.Disk)
+	}
+
+	if sched == nil {
+		sched = &apiv1beta1.Scheduling{}
+	}
+	if gpus == nil && (res.GPU != nil || sched.CapacityType != "") {
+		gpus = DefaultGPUCatalog()
+	}
 
 	if res.GPU != nil {
-		gpuInfo, ok := GetGPUInfo(cloudName, res.GPU.Type)
-		if !ok {
-			return fmt.Errorf("GPU %s is not supported on cloud %s", res.GPU.Type, cloudName)
+		gpuInfo, err := gpus.GetGPUInfo(cloudName, res.GPU.Type, sched.CapacityType)
+		if err != nil {
+			return err
 		}
 
 		resources.Requests[gpuInfo.ResourceName] = *resource.NewQuantity(res.GPU.Count, resource.DecimalSI)
@@ -48,20 +63,21 @@ func Apply(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, containerNam
 		if podSpec.NodeSelector == nil {
 			podSpec.NodeSelector = map[string]string{}
 		}
-
-		// TODO: Move this GCP code into cloud-specific configuration.
-		// Toleration is needed to trigger NAP
-		// https://cloud.google.com/kubernetes-engine/docs/how-to/node-auto-provisioning#support_for_spot_vms
-		podSpec.Tolerations = append(podSpec.Tolerations, corev1.Toleration{
-			Key:      "cloud.google.com/gke-spot",
-			Operator: corev1.TolerationOpEqual,
-			Value:    "true",
-			Effect:   corev1.TaintEffectNoSchedule,
-		})
-
-		for k, v := range gpuInfo.NodeSelector {
-			podSpec.NodeSelector[k] = v
+		applyScheduling(podSpec, gpuInfo.NodeSelector, gpuInfo.Tolerations)
+	} else if sched.CapacityType != "" {
+		capacity, err := gpus.GetCapacityScheduling(cloudName, sched.CapacityType)
+		if err != nil {
+			return err
 		}
+		applyScheduling(podSpec, capacity.NodeSelector, capacity.Tolerations)
+	}
+
+	applyScheduling(podSpec, sched.NodeSelector, sched.Tolerations)
+	if sched.Affinity != nil {
+		podSpec.Affinity = sched.Affinity.DeepCopy()
+	}
+	if sched.PriorityClassName != "" {
+		podSpec.PriorityClassName = sched.PriorityClassName
 	}
 
 	if !setContainerResources(containerName, podSpec, resources) {
@@ -71,6 +87,54 @@ func Apply(podMetadata *metav1.ObjectMeta, podSpec *corev1.PodSpec, containerNam
 	return nil
 }
 
+// EnsureDisk raises the ephemeral storage request of a container (after
+// Apply) so that it fits the given number of bytes with 10% headroom,
+// rounded up to the next gigabyte. A lower ephemeral storage limit is raised
+// as well.
+func EnsureDisk(podSpec *corev1.PodSpec, containerName string, bytes int64) error {
+	if bytes <= 0 {
+		return nil
+	}
+	min := resource.NewQuantity(roundUpGB(bytes+bytes/10), resource.BinarySI)
+
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			if containers[i].Name != containerName {
+				continue
+			}
+			res := &containers[i].Resources
+			if req, ok := res.Requests[corev1.ResourceEphemeralStorage]; !ok || req.Cmp(*min) < 0 {
+				if res.Requests == nil {
+					res.Requests = corev1.ResourceList{}
+				}
+				res.Requests[corev1.ResourceEphemeralStorage] = min.DeepCopy()
+			}
+			if lim, ok := res.Limits[corev1.ResourceEphemeralStorage]; ok && lim.Cmp(*min) < 0 {
+				res.Limits[corev1.ResourceEphemeralStorage] = min.DeepCopy()
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("container %s not found in pod", containerName)
+}
+
+func setQuantity(list corev1.ResourceList, name corev1.ResourceName, q *resource.Quantity) {
+	if q != nil {
+		list[name] = q.DeepCopy()
+	}
+}
+
+func applyScheduling(podSpec *corev1.PodSpec, nodeSelector map[string]string, tolerations []corev1.Toleration) {
+	if len(nodeSelector) > 0 && podSpec.NodeSelector == nil {
+		podSpec.NodeSelector = map[string]string{}
+	}
+	for k, v := range nodeSelector {
+		podSpec.NodeSelector[k] = v
+	}
+	podSpec.Tolerations = append(podSpec.Tolerations, tolerations...)
+}
+
 func ContainerBuilderResources(cloudName string) corev1.ResourceRequirements {
 	// TODO(nstogner): Cloud-specific conditional should go away...
 	// Most likely this stuff will all go into a ConfigMap that contains cloud-specific
@@ -4,7 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
-	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 	"github.com/substratusai/substratus/internal/cloud"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -13,36 +13,228 @@ import (
 
 func Test_Apply(t *testing.T) {
 	objectMeta := &metav1.ObjectMeta{Name: "test", Namespace: "test"}
-	podSpec := &corev1.PodSpec{Containers: []corev1.Container{
-		{Name: "test"},
-	}}
+
+	q := func(s string) *resource.Quantity {
+		v := resource.MustParse(s)
+		return &v
+	}
 
 	testCases := []struct {
-		Name      string
-		Resources *apiv1.Resources
-		Expected  *apiv1.Resources
+		Name           string
+		Resources      *apiv1beta1.Resources
+		ExpectedLimits map[corev1.ResourceName]string
+		Expected       map[corev1.ResourceName]string
 	}{
 		{
 			Name:      "not nil",
-			Resources: &apiv1.Resources{CPU: 8, Memory: 8, Disk: 300},
-			Expected:  &apiv1.Resources{CPU: 8, Memory: 8, Disk: 300},
+			Resources: &apiv1beta1.Resources{CPU: q("8"), Memory: q("8Gi"), Disk: q("300Gi")},
+			Expected: map[corev1.ResourceName]string{
+				corev1.ResourceCPU:              "8",
+				corev1.ResourceMemory:           "8Gi",
+				corev1.ResourceEphemeralStorage: "300Gi",
+			},
+			ExpectedLimits: map[corev1.ResourceName]string{},
 		},
 		{
 			Name:      "nil",
 			Resources: nil,
-			Expected:  &apiv1.Resources{CPU: 2, Memory: 4, Disk: 100},
+			Expected: map[corev1.ResourceName]string{
+				corev1.ResourceCPU:              "2",
+				corev1.ResourceMemory:           "4Gi",
+				corev1.ResourceEphemeralStorage: "100Gi",
+			},
+			ExpectedLimits: map[corev1.ResourceName]string{},
+		},
+		{
+			Name: "fractional with limits",
+			Resources: &apiv1beta1.Resources{
+				CPU:    q("500m"),
+				Memory: q("1.5Gi"),
+				Limits: &apiv1beta1.ResourceLimits{CPU: q("1"), Memory: q("2Gi")},
+			},
+			Expected: map[corev1.ResourceName]string{
+				corev1.ResourceCPU:    "500m",
+				corev1.ResourceMemory: "1536Mi",
+			},
+			ExpectedLimits: map[corev1.ResourceName]string{
+				corev1.ResourceCPU:    "1",
+				corev1.ResourceMemory: "2Gi",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("Running test case %v", testCase.Name)
+		podSpec := &corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "test"},
+		}}
+		err := Apply(objectMeta, podSpec, "test", cloud.GCPName, nil, testCase.Resources, nil)
+		require.NoError(t, err, "Encountered error with case", testCase.Name)
+
+		requests := map[corev1.ResourceName]string{}
+		for k, v := range podSpec.Containers[0].Resources.Requests {
+			requests[k] = v.String()
+		}
+		require.Equal(t, testCase.Expected, requests, testCase.Name)
+
+		limits := map[corev1.ResourceName]string{}
+		for k, v := range podSpec.Containers[0].Resources.Limits {
+			limits[k] = v.String()
+		}
+		require.Equal(t, testCase.ExpectedLimits, limits, testCase.Name)
+	}
+}
+
+func Test_Apply_Scheduling(t *testing.T) {
+	gkeSpot := corev1.Toleration{
+		Key:      "cloud.google.com/gke-spot",
+		Operator: corev1.TolerationOpEqual,
+		Value:    "true",
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+	userToleration := corev1.Toleration{
+		Key:      "dedicated",
+		Operator: corev1.TolerationOpEqual,
+		Value:    "ml",
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+	affinity := &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{
+						Key:      "zone",
+						Operator: corev1.NodeSelectorOpIn,
+						Values:   []string{"a"},
+					}},
+				}},
+			},
+		},
+	}
+	gpu := &apiv1beta1.Resources{GPU: &apiv1beta1.GPUResources{Type: apiv1beta1.GPUTypeNvidiaL4, Count: 1}}
+
+	testCases := []struct {
+		Name       string
+		Resources  *apiv1beta1.Resources
+		Scheduling *apiv1beta1.Scheduling
+		Expected   corev1.PodSpec
+	}{
+		{
+			Name:      "gpu defaults to spot",
+			Resources: gpu,
+			Expected: corev1.PodSpec{
+				NodeSelector: map[string]string{"cloud.google.com/gke-accelerator": "nvidia-l4"},
+				Tolerations:  []corev1.Toleration{gkeSpot},
+			},
+		},
+		{
+			Name:       "gpu on-demand",
+			Resources:  gpu,
+			Scheduling: &apiv1beta1.Scheduling{CapacityType: apiv1beta1.CapacityTypeOnDemand},
+			Expected: corev1.PodSpec{
+				NodeSelector: map[string]string{"cloud.google.com/gke-accelerator": "nvidia-l4"},
+			},
+		},
+		{
+			Name:       "cpu spot",
+			Scheduling: &apiv1beta1.Scheduling{CapacityType: apiv1beta1.CapacityTypeSpot},
+			Expected: corev1.PodSpec{
+				Tolerations: []corev1.Toleration{gkeSpot},
+			},
+		},
+		{
+			Name:      "user settings",
+			Resources: gpu,
+			Scheduling: &apiv1beta1.Scheduling{
+				CapacityType:      apiv1beta1.CapacityTypeOnDemand,
+				NodeSelector:      map[string]string{"pool": "serving"},
+				Tolerations:       []corev1.Toleration{userToleration},
+				Affinity:          affinity,
+				PriorityClassName: "high",
+			},
+			Expected: corev1.PodSpec{
+				NodeSelector: map[string]string{
+					"cloud.google.com/gke-accelerator": "nvidia-l4",
+					"pool":                             "serving",
+				},
+				Tolerations:       []corev1.Toleration{userToleration},
+				Affinity:          affinity,
+				PriorityClassName: "high",
+			},
 		},
 	}
 
 	for _, testCase := range testCases {
 		t.Logf("Running test case %v", testCase.Name)
-		err := Apply(objectMeta, podSpec, "test", cloud.GCPName, testCase.Resources)
+		podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "test"}}}
+		err := Apply(&metav1.ObjectMeta{}, podSpec, "test", cloud.GCPName, nil, testCase.Resources, testCase.Scheduling)
 		require.NoError(t, err, "Encountered error with case", testCase.Name)
-		require.Equal(t, podSpec.Containers[0].Resources.Requests.Cpu(),
-			resource.NewQuantity(testCase.Expected.CPU, resource.DecimalSI))
-		require.Equal(t, podSpec.Containers[0].Resources.Requests.Memory(),
-			resource.NewQuantity(testCase.Expected.Memory*gigabyte, resource.BinarySI))
-		require.Equal(t, podSpec.Containers[0].Resources.Requests.StorageEphemeral(),
-			resource.NewQuantity(testCase.Expected.Disk*gigabyte, resource.BinarySI))
+		require.Equal(t, testCase.Expected.NodeSelector, podSpec.NodeSelector, testCase.Name)
+		require.Equal(t, testCase.Expected.Tolerations, podSpec.Tolerations, testCase.Name)
+		require.Equal(t, testCase.Expected.Affinity, podSpec.Affinity, testCase.Name)
+		require.Equal(t, testCase.Expected.PriorityClassName, podSpec.PriorityClassName, testCase.Name)
+	}
+}
+
+func Test_EnsureDisk(t *testing.T) {
+	testCases := []struct {
+		Name             string
+		Requests, Limits corev1.ResourceList
+		Bytes            int64
+		ExpectedRequest  string
+		ExpectedLimit    string
+	}{
+		{
+			Name:            "unknown size",
+			Requests:        corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("10Gi")},
+			ExpectedRequest: "10Gi",
+		},
+		{
+			Name:            "fits",
+			Requests:        corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("100Gi")},
+			Bytes:           50 * gigabyte,
+			ExpectedRequest: "100Gi",
+		},
+		{
+			Name:            "raised",
+			Requests:        corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("10Gi")},
+			Bytes:           20 * gigabyte,
+			ExpectedRequest: "22Gi",
+		},
+		{
+			Name:            "no request",
+			Bytes:           1,
+			ExpectedRequest: "1Gi",
+		},
+		{
+			Name:            "limit raised",
+			Requests:        corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("10Gi")},
+			Limits:          corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("15Gi")},
+			Bytes:           20 * gigabyte,
+			ExpectedRequest: "22Gi",
+			ExpectedLimit:   "22Gi",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			podSpec := &corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:      "test",
+					Resources: corev1.ResourceRequirements{Requests: tc.Requests, Limits: tc.Limits},
+				}},
+			}
+			require.NoError(t, EnsureDisk(podSpec, "test", tc.Bytes))
+
+			res := podSpec.Containers[0].Resources
+			req := res.Requests[corev1.ResourceEphemeralStorage]
+			require.Equal(t, tc.ExpectedRequest, req.String())
+			if tc.ExpectedLimit != "" {
+				lim := res.Limits[corev1.ResourceEphemeralStorage]
+				require.Equal(t, tc.ExpectedLimit, lim.String())
+			}
+		})
 	}
+
+	require.Error(t, EnsureDisk(&corev1.PodSpec{}, "missing", 1))
 }
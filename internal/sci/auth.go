@@ -0,0 +1,159 @@
+package sci
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	authnv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultTokenAudience is the audience of the ServiceAccount tokens that the
+// controller presents to the SCI.
+const DefaultTokenAudience = "sci"
+
+// reviewCacheTTL is how long a successful TokenReview is trusted for.
+const reviewCacheTTL = time.Minute
+
+// TokenReviewer creates TokenReviews (i.e. the TokenReviews client of a
+// Kubernetes clientset).
+type TokenReviewer interface {
+	Create(ctx context.Context, review *authnv1.TokenReview, opts metav1.CreateOptions) (*authnv1.TokenReview, error)
+}
+
+// Authenticator only lets through requests that carry a bearer token of an
+// authorized user (i.e. the ServiceAccount of the controller manager). Tokens
+// are verified with the Kubernetes TokenReview API.
+type Authenticator struct {
+	Reviewer TokenReviewer
+	// Audiences that the token must be valid for.
+	Audiences []string
+	// Usernames that are authorized (i.e.
+	// "system:serviceaccount:substratus:controller-manager").
+	Usernames []string
+
+	mtx sync.Mutex
+	// reviewed maps the hash of a token to the time at which its review
+	// expires.
+	reviewed map[[sha256.Size]byte]time.Time
+}
+
+// UnaryServerInterceptor authenticates unary calls.
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := a.authenticate(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor authenticates streaming calls.
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.authenticate(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (a *Authenticator) authenticate(ctx context.Context, method string) error {
+	// Health checks are made by the kubelet and carry no credentials.
+	if strings.HasPrefix(method, "/grpc.health.v1.Health/") {
+		return nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	var token string
+	for _, v := range md.Get("authorization") {
+		if t, ok := strings.CutPrefix(v, "Bearer "); ok {
+			token = t
+			break
+		}
+	}
+	if token == "" {
+		return status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+
+	key := sha256.Sum256([]byte(token))
+	now := time.Now()
+	a.mtx.Lock()
+	expiration, ok := a.reviewed[key]
+	a.mtx.Unlock()
+	if ok && now.Before(expiration) {
+		return nil
+	}
+
+	review, err := a.Reviewer.Create(ctx, &authnv1.TokenReview{
+		Spec: authnv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: a.Audiences,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		log.Printf("TokenReview failed: %v", err)
+		return status.Error(codes.Unavailable, "unable to review token")
+	}
+	if !review.Status.Authenticated {
+		return status.Errorf(codes.Unauthenticated, "invalid token: %v", review.Status.Error)
+	}
+	if !a.authorized(review.Status.User.Username) {
+		log.Printf("Rejected call to %v by %q", method, review.Status.User.Username)
+		return status.Errorf(codes.PermissionDenied, "user %q is not authorized", review.Status.User.Username)
+	}
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	if a.reviewed == nil {
+		a.reviewed = map[[sha256.Size]byte]time.Time{}
+	}
+	for k, exp := range a.reviewed {
+		if now.After(exp) {
+			delete(a.reviewed, k)
+		}
+	}
+	a.reviewed[key] = now.Add(reviewCacheTTL)
+
+	return nil
+}
+
+func (a *Authenticator) authorized(username string) bool {
+	for _, u := range a.Usernames {
+		if u == username {
+			return true
+		}
+	}
+	return false
+}
+
+// TokenFileCredentials sends the token in a file (i.e. a projected
+// ServiceAccount token) as a bearer token with every call. The file is read
+// on every call because the kubelet rotates the token.
+type TokenFileCredentials struct {
+	Path string
+}
+
+func (c TokenFileCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := os.ReadFile(c.Path)
+	if err != nil {
+		return nil, fmt.Errorf("reading token: %w", err)
+	}
+	return map[string]string{
+		"authorization": "Bearer " + strings.TrimSpace(string(token)),
+	}, nil
+}
+
+func (c TokenFileCredentials) RequireTransportSecurity() bool {
+	return true
+}
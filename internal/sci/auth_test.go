@@ -0,0 +1,72 @@
+package sci_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	authnv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/substratusai/substratus/internal/sci"
+)
+
+// fakeReviewer authenticates the tokens in its map (token -> username) for
+// the "sci" audience.
+type fakeReviewer struct {
+	users   map[string]string
+	reviews int
+}
+
+func (f *fakeReviewer) Create(ctx context.Context, review *authnv1.TokenReview, opts metav1.CreateOptions) (*authnv1.TokenReview, error) {
+	f.reviews++
+	username, ok := f.users[review.Spec.Token]
+	if ok && len(review.Spec.Audiences) == 1 && review.Spec.Audiences[0] == "sci" {
+		review.Status.Authenticated = true
+		review.Status.User.Username = username
+	} else {
+		review.Status.Error = "invalid token"
+	}
+	return review, nil
+}
+
+func TestAuthenticator(t *testing.T) {
+	const controller = "system:serviceaccount:substratus:controller-manager"
+	reviewer := &fakeReviewer{users: map[string]string{
+		"controller-token": controller,
+		"notebook-token":   "system:serviceaccount:default:notebook",
+	}}
+	auth := &sci.Authenticator{
+		Reviewer:  reviewer,
+		Audiences: []string{"sci"},
+		Usernames: []string{controller},
+	}
+	interceptor := auth.UnaryServerInterceptor()
+
+	call := func(method, token string) error {
+		ctx := context.Background()
+		if token != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+		}
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		return err
+	}
+	const method = "/sci.v1.Controller/BindIdentity"
+
+	require.NoError(t, call(method, "controller-token"))
+	require.Equal(t, codes.Unauthenticated, status.Code(call(method, "")))
+	require.Equal(t, codes.Unauthenticated, status.Code(call(method, "forged-token")))
+	require.Equal(t, codes.PermissionDenied, status.Code(call(method, "notebook-token")))
+	require.NoError(t, call("/grpc.health.v1.Health/Check", ""), "health checks should not require a token")
+
+	t.Log("Caching reviews")
+	reviews := reviewer.reviews
+	require.NoError(t, call(method, "controller-token"))
+	require.Equal(t, reviews, reviewer.reviews)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/substratusai/substratus/internal/sci"
+	"github.com/substratusai/substratus/internal/sci/s3api"
 )
 
 type Server struct {
@@ -33,6 +34,22 @@ type Clients struct {
 	IAMClient *iam.IAM
 }
 
+// CreateSignedDownloadURL presigns a GET request for an S3 object.
+func (s *Server) CreateSignedDownloadURL(ctx context.Context, req *sci.CreateSignedDownloadURLRequest) (*sci.CreateSignedDownloadURLResponse, error) {
+	getReq, _ := s.Clients.S3Client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: awsSdk.String(req.GetBucketName()),
+		Key:    awsSdk.String(req.GetObjectName()),
+	})
+
+	expiration := time.Duration(req.GetExpirationSeconds()) * time.Second
+	url, err := getReq.Presign(expiration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to presign request: %w", err)
+	}
+
+	return &sci.CreateSignedDownloadURLResponse{Url: url}, nil
+}
+
 func (s *Server) GetObjectMd5(ctx context.Context, req *sci.GetObjectMd5Request) (*sci.GetObjectMd5Response, error) {
 	// ensure the object is accessible
 	bucketName, objectName := req.GetBucketName(), req.GetObjectName()
@@ -57,6 +74,26 @@ func (s *Server) GetObjectMd5(ctx context.Context, req *sci.GetObjectMd5Request)
 	}, nil
 }
 
+func (s *Server) GetObject(ctx context.Context, req *sci.GetObjectRequest) (*sci.GetObjectResponse, error) {
+	return s3api.GetObject(ctx, s.Clients.S3Client, req)
+}
+
+// ListObjects lists the S3 objects with a given prefix.
+func (s *Server) ListObjects(ctx context.Context, req *sci.ListObjectsRequest) (*sci.ListObjectsResponse, error) {
+	return s3api.ListObjects(ctx, s.Clients.S3Client, req)
+}
+
+// StatObject returns the attributes of an S3 object.
+func (s *Server) StatObject(ctx context.Context, req *sci.StatObjectRequest) (*sci.StatObjectResponse, error) {
+	return s3api.StatObject(ctx, s.Clients.S3Client, req)
+}
+
+// DeleteObjects deletes every S3 object with a given prefix and the given
+// objects.
+func (s *Server) DeleteObjects(ctx context.Context, req *sci.DeleteObjectsRequest) (*sci.DeleteObjectsResponse, error) {
+	return s3api.DeleteObjects(ctx, s.Clients.S3Client, req)
+}
+
 func (s *Server) CreateSignedURL(ctx context.Context, req *sci.CreateSignedURLRequest) (*sci.CreateSignedURLResponse, error) {
 	bucketName, objectName, checksum := req.GetBucketName(),
 		req.GetObjectName(),
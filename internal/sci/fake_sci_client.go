@@ -2,16 +2,31 @@ package sci
 
 import (
 	context "context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"path"
+	"sort"
+	"strings"
+	"sync"
 
 	grpc "google.golang.org/grpc"
 )
 
-type FakeSCIControllerClient struct{}
+type FakeSCIControllerClient struct {
+	mtx     sync.Mutex
+	objects map[string][]byte
+}
 
 func (c *FakeSCIControllerClient) CreateSignedURL(ctx context.Context, in *CreateSignedURLRequest, opts ...grpc.CallOption) (*CreateSignedURLResponse, error) {
 	return &CreateSignedURLResponse{}, nil
 }
 
+func (c *FakeSCIControllerClient) CreateSignedDownloadURL(ctx context.Context, in *CreateSignedDownloadURLRequest, opts ...grpc.CallOption) (*CreateSignedDownloadURLResponse, error) {
+	return &CreateSignedDownloadURLResponse{Url: "https://signed.example.com/" + path.Join(in.BucketName, in.ObjectName)}, nil
+}
+
 func (c *FakeSCIControllerClient) GetObjectMd5(ctx context.Context, in *GetObjectMd5Request, opts ...grpc.CallOption) (*GetObjectMd5Response, error) {
 	return &GetObjectMd5Response{}, nil
 }
@@ -19,3 +34,95 @@ func (c *FakeSCIControllerClient) GetObjectMd5(ctx context.Context, in *GetObjec
 func (c *FakeSCIControllerClient) BindIdentity(ctx context.Context, in *BindIdentityRequest, opts ...grpc.CallOption) (*BindIdentityResponse, error) {
 	return &BindIdentityResponse{}, nil
 }
+
+func (c *FakeSCIControllerClient) GetObject(ctx context.Context, in *GetObjectRequest, opts ...grpc.CallOption) (*GetObjectResponse, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	contents, ok := c.objects[path.Join(in.BucketName, in.ObjectName)]
+	if !ok {
+		return nil, NotFound(in.BucketName, in.ObjectName)
+	}
+	return &GetObjectResponse{Contents: contents}, nil
+}
+
+func (c *FakeSCIControllerClient) ListObjects(ctx context.Context, in *ListObjectsRequest, opts ...grpc.CallOption) (*ListObjectsResponse, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	var objects []*ObjectInfo
+	for key, contents := range c.objects {
+		name := strings.TrimPrefix(key, in.BucketName+"/")
+		if in.BucketName != "" && name == key {
+			continue
+		}
+		if !strings.HasPrefix(name, in.Prefix) || name <= in.PageToken {
+			continue
+		}
+		objects = append(objects, &ObjectInfo{Name: name, Size: int64(len(contents))})
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Name < objects[j].Name })
+
+	resp := &ListObjectsResponse{Objects: objects}
+	if max := MaxResults(in); len(objects) > max {
+		resp.Objects = objects[:max]
+		resp.NextPageToken = objects[max-1].Name
+	}
+	return resp, nil
+}
+
+func (c *FakeSCIControllerClient) StatObject(ctx context.Context, in *StatObjectRequest, opts ...grpc.CallOption) (*StatObjectResponse, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	contents, ok := c.objects[path.Join(in.BucketName, in.ObjectName)]
+	if !ok {
+		return nil, NotFound(in.BucketName, in.ObjectName)
+	}
+	sum := md5.Sum(contents)
+	crc := crc32.Checksum(contents, crc32.MakeTable(crc32.Castagnoli))
+	return &StatObjectResponse{
+		Size:           int64(len(contents)),
+		Md5Checksum:    hex.EncodeToString(sum[:]),
+		Crc32CChecksum: fmt.Sprintf("%08x", crc),
+	}, nil
+}
+
+func (c *FakeSCIControllerClient) DeleteObjects(ctx context.Context, in *DeleteObjectsRequest, opts ...grpc.CallOption) (*DeleteObjectsResponse, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if err := ValidateDeleteObjects(in); err != nil {
+		return nil, err
+	}
+
+	var count int64
+	if in.Prefix != "" {
+		for key := range c.objects {
+			name := strings.TrimPrefix(key, in.BucketName+"/")
+			if in.BucketName != "" && name == key {
+				continue
+			}
+			if strings.HasPrefix(name, in.Prefix) {
+				delete(c.objects, key)
+				count++
+			}
+		}
+	}
+	for _, name := range in.ObjectNames {
+		key := path.Join(in.BucketName, name)
+		if _, ok := c.objects[key]; ok {
+			delete(c.objects, key)
+			count++
+		}
+	}
+	return &DeleteObjectsResponse{DeletedCount: count}, nil
+}
+
+// SetObject sets the contents of an object that will be returned by GetObject
+// (and listed by ListObjects).
+func (c *FakeSCIControllerClient) SetObject(bucket, object string, contents []byte) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.objects == nil {
+		c.objects = map[string][]byte{}
+	}
+	c.objects[path.Join(bucket, object)] = contents
+}
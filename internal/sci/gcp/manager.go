@@ -18,6 +18,7 @@ import (
 	"github.com/substratusai/substratus/internal/sci"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/iam/v1"
+	"google.golang.org/api/iterator"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -79,18 +80,7 @@ func (s *Server) CreateSignedURL(ctx context.Context, req *sci.CreateSignedURLRe
 		Expires:        time.Now().Add(time.Duration(req.GetExpirationSeconds()) * time.Second),
 		GoogleAccessID: s.SaEmail,
 		MD5:            base64md5,
-		SignBytes: func(b []byte) ([]byte, error) {
-			req := &credentialspb.SignBlobRequest{
-				Payload: b,
-				Name:    s.SaEmail,
-			}
-			resp, err := s.Clients.IAMCredentialsClient.SignBlob(ctx, req)
-			if err != nil {
-				log.Error(err, "error signing blob")
-				return nil, fmt.Errorf("failed to sign the blob: %w", err)
-			}
-			return resp.SignedBlob, err
-		},
+		SignBytes:      s.signBytes(ctx),
 	}
 
 	// Create a signed URL
@@ -103,6 +93,45 @@ func (s *Server) CreateSignedURL(ctx context.Context, req *sci.CreateSignedURLRe
 	return &sci.CreateSignedURLResponse{Url: url}, nil
 }
 
+// CreateSignedDownloadURL generates a signed URL for downloading a GCS
+// object.
+func (s *Server) CreateSignedDownloadURL(ctx context.Context, req *sci.CreateSignedDownloadURLRequest) (*sci.CreateSignedDownloadURLResponse, error) {
+	log := log.FromContext(ctx)
+	log.Info("creating signed download URL", "bucket", req.BucketName, "object", req.ObjectName)
+
+	url, err := storage.SignedURL(req.GetBucketName(), req.GetObjectName(), &storage.SignedURLOptions{
+		Scheme:         storage.SigningSchemeV4,
+		Method:         http.MethodGet,
+		Expires:        time.Now().Add(time.Duration(req.GetExpirationSeconds()) * time.Second),
+		GoogleAccessID: s.SaEmail,
+		SignBytes:      s.signBytes(ctx),
+	})
+	if err != nil {
+		log.Error(err, "error creating signed url")
+		return nil, fmt.Errorf("error creating signed url: %w", err)
+	}
+
+	return &sci.CreateSignedDownloadURLResponse{Url: url}, nil
+}
+
+// signBytes returns a function that signs URLs as the service account
+// (using the IAM credentials API).
+func (s *Server) signBytes(ctx context.Context) func([]byte) ([]byte, error) {
+	log := log.FromContext(ctx)
+	return func(b []byte) ([]byte, error) {
+		req := &credentialspb.SignBlobRequest{
+			Payload: b,
+			Name:    s.SaEmail,
+		}
+		resp, err := s.Clients.IAMCredentialsClient.SignBlob(ctx, req)
+		if err != nil {
+			log.Error(err, "error signing blob")
+			return nil, fmt.Errorf("failed to sign the blob: %w", err)
+		}
+		return resp.SignedBlob, err
+	}
+}
+
 func (s *Server) GetObjectMd5(ctx context.Context, req *sci.GetObjectMd5Request) (*sci.GetObjectMd5Response, error) {
 	bucketName, objectName := req.GetBucketName(), req.GetObjectName()
 	bucket := s.Clients.Storage.Bucket(bucketName)
@@ -115,6 +144,101 @@ func (s *Server) GetObjectMd5(ctx context.Context, req *sci.GetObjectMd5Request)
 	return &sci.GetObjectMd5Response{Md5Checksum: md5str}, nil
 }
 
+// GetObject returns the contents of a (small) GCS object.
+func (s *Server) GetObject(ctx context.Context, req *sci.GetObjectRequest) (*sci.GetObjectResponse, error) {
+	r, err := s.Clients.Storage.Bucket(req.GetBucketName()).Object(req.GetObjectName()).NewReader(ctx)
+	if err != nil {
+		if err == storage.ErrObjectNotExist {
+			return nil, sci.NotFound(req.GetBucketName(), req.GetObjectName())
+		}
+		return nil, err
+	}
+	defer r.Close()
+
+	contents, err := sci.ReadAll(r, req.GetMaxBytes())
+	if err != nil {
+		return nil, fmt.Errorf("reading object: %w", err)
+	}
+
+	return &sci.GetObjectResponse{Contents: contents}, nil
+}
+
+// ListObjects lists the GCS objects with a given prefix.
+func (s *Server) ListObjects(ctx context.Context, req *sci.ListObjectsRequest) (*sci.ListObjectsResponse, error) {
+	it := s.Clients.Storage.Bucket(req.GetBucketName()).Objects(ctx, &storage.Query{Prefix: req.GetPrefix()})
+	var attrs []*storage.ObjectAttrs
+	next, err := iterator.NewPager(it, sci.MaxResults(req), req.GetPageToken()).NextPage(&attrs)
+	if err != nil {
+		return nil, fmt.Errorf("listing objects: %w", err)
+	}
+
+	resp := &sci.ListObjectsResponse{NextPageToken: next}
+	for _, a := range attrs {
+		resp.Objects = append(resp.Objects, &sci.ObjectInfo{Name: a.Name, Size: a.Size})
+	}
+	return resp, nil
+}
+
+// StatObject returns the attributes of a GCS object.
+func (s *Server) StatObject(ctx context.Context, req *sci.StatObjectRequest) (*sci.StatObjectResponse, error) {
+	attrs, err := s.Clients.Storage.Bucket(req.GetBucketName()).Object(req.GetObjectName()).Attrs(ctx)
+	if err == storage.ErrObjectNotExist {
+		return nil, sci.NotFound(req.GetBucketName(), req.GetObjectName())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &sci.StatObjectResponse{
+		Size:           attrs.Size,
+		Md5Checksum:    hex.EncodeToString(attrs.MD5),
+		Crc32CChecksum: fmt.Sprintf("%08x", attrs.CRC32C),
+		UpdatedUnix:    attrs.Updated.Unix(),
+	}, nil
+}
+
+// DeleteObjects deletes every GCS object with a given prefix and the given
+// objects.
+func (s *Server) DeleteObjects(ctx context.Context, req *sci.DeleteObjectsRequest) (*sci.DeleteObjectsResponse, error) {
+	log := log.FromContext(ctx)
+	log.Info("deleting objects", "bucket", req.BucketName, "prefix", req.Prefix, "objects", len(req.ObjectNames))
+
+	if err := sci.ValidateDeleteObjects(req); err != nil {
+		return nil, err
+	}
+
+	bucket := s.Clients.Storage.Bucket(req.GetBucketName())
+	var count int64
+	if req.GetPrefix() != "" {
+		it := bucket.Objects(ctx, &storage.Query{Prefix: req.GetPrefix()})
+		for {
+			attrs, err := it.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("listing objects: %w", err)
+			}
+			if err := bucket.Object(attrs.Name).Delete(ctx); err != nil && err != storage.ErrObjectNotExist {
+				return nil, fmt.Errorf("deleting object %q: %w", attrs.Name, err)
+			}
+			count++
+		}
+	}
+	for _, name := range req.GetObjectNames() {
+		err := bucket.Object(name).Delete(ctx)
+		if err == storage.ErrObjectNotExist {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("deleting object %q: %w", name, err)
+		}
+		count++
+	}
+
+	return &sci.DeleteObjectsResponse{DeletedCount: count}, nil
+}
+
 func (s *Server) BindIdentity(ctx context.Context, req *sci.BindIdentityRequest) (*sci.BindIdentityResponse, error) {
 	log := log.FromContext(ctx)
 	log.Info("Binding K8s Service Account to GCP Service Account",
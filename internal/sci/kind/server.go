@@ -2,10 +2,10 @@ package kind
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
-	"io"
+	"io/fs"
 	"log"
 	"net/http"
 	"os"
@@ -17,81 +17,130 @@ import (
 
 var _ sci.ControllerServer = &Server{}
 
+// Server serves uploads into a hostPath directory of the kind node. The
+// signed URL handler is exposed on a NodePort, so it only accepts URLs that
+// were signed by this server.
 type Server struct {
+	// Dir is the bucket root (i.e. "/bucket"). Object names are expected to
+	// be absolute paths within this directory.
+	Dir string
+	// SignedURLAddress is the address that clients use to reach the signed
+	// URL handler.
 	SignedURLAddress string
+	// SigningKey is the HMAC key that signed URLs are signed with.
+	SigningKey []byte
 
 	sci.UnimplementedControllerServer
 }
 
 // ServeHTTP implements the http.Handler interface and is used to provide cloud-bucket-like signed URL support.
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	log.Printf("Signed URL Server: %v", r.URL.Path)
-
-	switch r.Method {
-	case http.MethodPut:
-		// Expect "Content-Type: application/octet-stream" in a PUT request and save the body to a file.
-		if r.Header.Get("Content-Type") != "application/octet-stream" {
-			log.Print("client sent wrong content-type")
-			w.WriteHeader(http.StatusBadRequest)
-			return
-		}
-
-		md5B64 := r.Header.Get("Content-MD5")
-		if md5B64 == "" {
-			log.Print("client did not send content-md5")
-			w.WriteHeader(http.StatusBadRequest)
-			return
-		}
-		md5Raw, err := base64.StdEncoding.DecodeString(md5B64)
-		if err != nil {
-			log.Printf("content-md5 is not base64 encoded: %v", err)
-			w.WriteHeader(http.StatusBadRequest)
-			return
-		}
-		md5 := hex.EncodeToString(md5Raw)
+	log.Printf("Signed URL Server: %v %v", r.Method, r.URL.Path)
 
-		if err := s.saveUpload(r.Body, r.URL.Path, md5); err != nil {
-			log.Printf("failed to save upload: %v", err)
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
+	path, err := s.resolvePath(r.URL.Path)
+	if err != nil {
+		log.Printf("invalid path: %v", err)
+		w.WriteHeader(http.StatusForbidden)
+		return
 	}
 
+	sci.ServeSignedURL(w, r, s.signer(), path)
 }
 
-func (s *Server) saveUpload(r io.Reader, urlPath, md5 string) error {
-	// urlPath should look like: "/bucket/<guid>/..."
-	dir := filepath.Dir(urlPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("mkdir (all): %v", err)
-	}
-	if err := os.WriteFile(filepath.Join(dir, "md5.txt"), []byte(md5), 0644); err != nil {
-		return fmt.Errorf("write md5 file: %v", err)
+func (s *Server) signer() sci.URLSigner {
+	return sci.URLSigner{Key: s.SigningKey}
+}
+
+// resolvePath cleans the given object path and ensures that it is within
+// the bucket directory.
+func (s *Server) resolvePath(objPath string) (string, error) {
+	dir := filepath.Clean(s.Dir)
+	path := filepath.Clean("/" + objPath)
+	if !strings.HasPrefix(path, dir+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %q is outside of %q", objPath, dir)
 	}
+	return path, nil
+}
 
-	f, err := os.Create(urlPath)
+// resolvePrefix is like resolvePath but also accepts the bucket directory
+// itself (i.e. "bucket/") so that the whole bucket can be listed. The
+// returned prefix is relative to the bucket directory.
+func (s *Server) resolvePrefix(prefix string) (string, error) {
+	dir := filepath.Clean(s.Dir)
+	if filepath.Clean("/"+prefix) == dir {
+		return "", nil
+	}
+	path, err := s.resolvePath(prefix)
 	if err != nil {
-		return err
+		return "", err
 	}
-	defer f.Close()
-	if _, err := io.Copy(f, r); err != nil {
-		return err
+	rel := s.relPath(path)
+	if strings.HasSuffix(prefix, "/") {
+		rel += "/"
 	}
-	return f.Close()
+	return rel, nil
+}
+
+// relPath returns a path that resolvePath returned relative to the bucket
+// directory (using forward slashes).
+func (s *Server) relPath(path string) string {
+	return filepath.ToSlash(strings.TrimPrefix(path, filepath.Clean(s.Dir)+string(filepath.Separator)))
+}
+
+// namePrefix is what object names start with (they are relative to "/"
+// while files are listed and deleted relative to the bucket directory).
+func (s *Server) namePrefix() string {
+	return strings.TrimPrefix(filepath.ToSlash(filepath.Clean(s.Dir)), "/") + "/"
 }
 
 func (s *Server) CreateSignedURL(ctx context.Context, req *sci.CreateSignedURLRequest) (*sci.CreateSignedURLResponse, error) {
 	log.Printf("CreateSignedURL: %v", req.ObjectName)
 
+	path, err := s.resolvePath(req.ObjectName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := hex.DecodeString(req.Md5Checksum); err != nil || req.Md5Checksum == "" {
+		return nil, fmt.Errorf("invalid md5 checksum %q", req.Md5Checksum)
+	}
+
+	u, err := s.signer().SignURL(s.SignedURLAddress, http.MethodPut, path, req.Md5Checksum, req.ExpirationSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("signing url: %w", err)
+	}
+
 	return &sci.CreateSignedURLResponse{
-		Url: fmt.Sprintf("%v/%v", s.SignedURLAddress, req.ObjectName),
+		Url: u,
+	}, nil
+}
+
+func (s *Server) CreateSignedDownloadURL(ctx context.Context, req *sci.CreateSignedDownloadURLRequest) (*sci.CreateSignedDownloadURLResponse, error) {
+	log.Printf("CreateSignedDownloadURL: %v", req.ObjectName)
+
+	path, err := s.resolvePath(req.ObjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := s.signer().SignURL(s.SignedURLAddress, http.MethodGet, path, "", req.ExpirationSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("signing url: %w", err)
+	}
+
+	return &sci.CreateSignedDownloadURLResponse{
+		Url: u,
 	}, nil
 }
 
 func (s *Server) GetObjectMd5(ctx context.Context, req *sci.GetObjectMd5Request) (*sci.GetObjectMd5Response, error) {
 	log.Printf("GetObjectMd5: %v", req.ObjectName)
 
-	path := filepath.Join(filepath.Dir(req.ObjectName), "md5.txt")
+	objPath, err := s.resolvePath(req.ObjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(filepath.Dir(objPath), "md5.txt")
 	contents, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("read md5 file: %v", err)
@@ -105,6 +154,126 @@ func (s *Server) GetObjectMd5(ctx context.Context, req *sci.GetObjectMd5Request)
 	}, nil
 }
 
+func (s *Server) GetObject(ctx context.Context, req *sci.GetObjectRequest) (*sci.GetObjectResponse, error) {
+	log.Printf("GetObject: %v", req.ObjectName)
+
+	objPath, err := s.resolvePath(req.ObjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(objPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, sci.NotFound(req.BucketName, req.ObjectName)
+		}
+		return nil, fmt.Errorf("open object file: %v", err)
+	}
+	defer f.Close()
+
+	contents, err := sci.ReadAll(f, req.MaxBytes)
+	if err != nil {
+		return nil, fmt.Errorf("read object file: %v", err)
+	}
+
+	return &sci.GetObjectResponse{
+		Contents: contents,
+	}, nil
+}
+
+func (s *Server) ListObjects(ctx context.Context, req *sci.ListObjectsRequest) (*sci.ListObjectsResponse, error) {
+	log.Printf("ListObjects: %v", req.Prefix)
+
+	prefix, err := s.resolvePrefix(req.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	namePrefix := s.namePrefix()
+	resp, err := sci.ListFiles(s.Dir, &sci.ListObjectsRequest{
+		BucketName: req.BucketName,
+		Prefix:     prefix,
+		MaxResults: req.MaxResults,
+		PageToken:  strings.TrimPrefix(req.PageToken, namePrefix),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range resp.Objects {
+		obj.Name = namePrefix + obj.Name
+	}
+	if resp.NextPageToken != "" {
+		resp.NextPageToken = namePrefix + resp.NextPageToken
+	}
+	return resp, nil
+}
+
+func (s *Server) StatObject(ctx context.Context, req *sci.StatObjectRequest) (*sci.StatObjectResponse, error) {
+	log.Printf("StatObject: %v", req.ObjectName)
+
+	objPath, err := s.resolvePath(req.ObjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := sci.StatFile(objPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, sci.NotFound(req.BucketName, req.ObjectName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("stat object file: %v", err)
+	}
+
+	return resp, nil
+}
+
+func (s *Server) DeleteObjects(ctx context.Context, req *sci.DeleteObjectsRequest) (*sci.DeleteObjectsResponse, error) {
+	log.Printf("DeleteObjects: %v %v", req.Prefix, req.ObjectNames)
+
+	if err := sci.ValidateDeleteObjects(req); err != nil {
+		return nil, err
+	}
+
+	// The bucket directory itself is never deleted: resolvePath rejects it.
+	var prefix string
+	if req.Prefix != "" {
+		path, err := s.resolvePath(req.Prefix)
+		if err != nil {
+			return nil, err
+		}
+		prefix = s.relPath(path)
+		if strings.HasSuffix(req.Prefix, "/") {
+			prefix += "/"
+		}
+	}
+	names := make([]string, 0, len(req.ObjectNames))
+	for _, name := range req.ObjectNames {
+		path, err := s.resolvePath(name)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, s.relPath(path))
+	}
+
+	var count int64
+	if prefix != "" {
+		n, err := sci.DeleteFiles(s.Dir, prefix)
+		count += n
+		if err != nil {
+			return nil, fmt.Errorf("delete files: %v", err)
+		}
+	}
+	n, err := sci.DeleteFileNames(s.Dir, names)
+	count += n
+	if err != nil {
+		return nil, fmt.Errorf("delete files: %v", err)
+	}
+
+	return &sci.DeleteObjectsResponse{
+		DeletedCount: count,
+	}, nil
+}
+
 func (s *Server) BindIdentity(ctx context.Context, in *sci.BindIdentityRequest) (*sci.BindIdentityResponse, error) {
 	return &sci.BindIdentityResponse{}, nil
 }
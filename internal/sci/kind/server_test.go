@@ -1,16 +1,18 @@
 package kind_test
 
 import (
-	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -21,9 +23,12 @@ import (
 )
 
 func TestServer(t *testing.T) {
-	bucketDir := os.TempDir()
+	bucketDir := t.TempDir()
 
-	s := &scikind.Server{}
+	s := &scikind.Server{
+		Dir:        bucketDir,
+		SigningKey: []byte("test-signing-key"),
+	}
 
 	signedURLServer := httptest.NewServer(s)
 	defer signedURLServer.Close()
@@ -46,38 +51,71 @@ func TestServer(t *testing.T) {
 
 	ctx := context.Background()
 
+	// MD5 encoded "hello":
+	const md5Hex = "5d41402abc4b2a76b9719d911017c592"
+	md5Btys, err := hex.DecodeString(md5Hex)
+	require.NoError(t, err)
+	md5B64 := base64.StdEncoding.EncodeToString(md5Btys)
+
+	upload := func(t *testing.T, url, contentMD5, body string) int {
+		req, err := http.NewRequest(http.MethodPut, url, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/octet-stream")
+		req.Header.Set("Content-MD5", contentMD5)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	var uploadURL string
 	{
 		t.Log("Creating signed url")
 		resp, err := c.CreateSignedURL(ctx, &sci.CreateSignedURLRequest{
-			Md5Checksum: "123",
-			ObjectName:  "abc/uploads/latest.tar.gz",
+			Md5Checksum:       md5Hex,
+			ObjectName:        filepath.Join(bucketDir, "abc/uploads/latest.tar.gz"),
+			ExpirationSeconds: 300,
 		})
 		require.NoError(t, err)
-		require.Equal(t, fmt.Sprintf("%v/abc/uploads/latest.tar.gz", signedURLServer.URL), resp.Url)
+		require.True(t, strings.HasPrefix(resp.Url, fmt.Sprintf("%v%v?", signedURLServer.URL, filepath.Join(bucketDir, "abc/uploads/latest.tar.gz"))), resp.Url)
+		uploadURL = resp.Url
+
+		_, err = c.CreateSignedURL(ctx, &sci.CreateSignedURLRequest{
+			Md5Checksum:       md5Hex,
+			ObjectName:        "/etc/abc/uploads/latest.tar.gz",
+			ExpirationSeconds: 300,
+		})
+		require.Error(t, err, "objects outside of the bucket should be rejected")
 	}
 
 	{
-		t.Log("Uploading file")
-		body := bytes.NewReader([]byte("hello"))
-		req, err := http.NewRequest(
-			http.MethodPut,
-			fmt.Sprintf("%v%v", signedURLServer.URL, filepath.Join(bucketDir, "/abc/uploads/latest.tar.gz")),
-			body,
-		)
-		require.NoError(t, err)
-		req.Header.Set("Content-Type", "application/octet-stream")
+		t.Log("Rejecting invalid uploads")
+		unsigned := fmt.Sprintf("%v%v", signedURLServer.URL, filepath.Join(bucketDir, "abc/uploads/latest.tar.gz"))
+		require.Equal(t, http.StatusForbidden, upload(t, unsigned, md5B64, "hello"), "unsigned url")
 
-		// MD5 encoded "hello":
-		md5Hex := "5d41402abc4b2a76b9719d911017c592"
-		md5Btys, err := hex.DecodeString(md5Hex)
-		require.NoError(t, err)
-		md5B64 := base64.StdEncoding.EncodeToString(md5Btys)
-		req.Header.Set("Content-MD5", md5B64)
+		otherObject := strings.Replace(uploadURL, "latest.tar.gz", "other.tar.gz", 1)
+		require.Equal(t, http.StatusForbidden, upload(t, otherObject, md5B64, "hello"), "url of another object")
 
+		u, err := url.Parse(uploadURL)
 		require.NoError(t, err)
-		resp, err := http.DefaultClient.Do(req)
-		require.NoError(t, err)
-		require.Equal(t, 200, resp.StatusCode)
+		q := u.Query()
+		q.Set("expires", "99999999999")
+		u.RawQuery = q.Encode()
+		require.Equal(t, http.StatusForbidden, upload(t, u.String(), md5B64, "hello"), "extended expiration")
+
+		otherMD5 := base64.StdEncoding.EncodeToString(make([]byte, 16))
+		require.Equal(t, http.StatusForbidden, upload(t, uploadURL, otherMD5, "hello"), "content-md5 that was not signed")
+
+		outside := fmt.Sprintf("%v%v/../../etc/passwd?%v", signedURLServer.URL, bucketDir, u.RawQuery)
+		require.Equal(t, http.StatusForbidden, upload(t, outside, md5B64, "hello"), "path outside of the bucket")
+
+		require.Equal(t, http.StatusBadRequest, upload(t, uploadURL, md5B64, "goodbye"), "body that does not match content-md5")
+		require.NoFileExists(t, filepath.Join(bucketDir, "abc/uploads/latest.tar.gz"))
+	}
+
+	{
+		t.Log("Uploading file")
+		require.Equal(t, http.StatusOK, upload(t, uploadURL, md5B64, "hello"))
 
 		require.FileExists(t, filepath.Join(bucketDir, "abc/uploads/md5.txt"))
 		contents, err := os.ReadFile(filepath.Join(bucketDir, "abc/uploads/latest.tar.gz"))
@@ -94,4 +132,150 @@ func TestServer(t *testing.T) {
 		require.Equal(t, "5d41402abc4b2a76b9719d911017c592", resp.Md5Checksum)
 	}
 
+	{
+		t.Log("Listing objects")
+		resp, err := c.ListObjects(ctx, &sci.ListObjectsRequest{
+			Prefix: strings.TrimPrefix(filepath.Join(bucketDir, "abc/uploads"), "/") + "/",
+		})
+		require.NoError(t, err)
+		var names []string
+		for _, obj := range resp.Objects {
+			names = append(names, obj.Name)
+		}
+		require.Contains(t, names, strings.TrimPrefix(filepath.Join(bucketDir, "abc/uploads/latest.tar.gz"), "/"))
+	}
+
+	{
+		t.Log("Listing the bucket root (as the artifacts sweep does)")
+		resp, err := c.ListObjects(ctx, &sci.ListObjectsRequest{
+			Prefix: strings.TrimPrefix(bucketDir, "/") + "/",
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Objects, 2)
+		require.Equal(t, strings.TrimPrefix(filepath.Join(bucketDir, "abc/uploads/latest.tar.gz"), "/"), resp.Objects[0].Name)
+
+		t.Log("Paginating objects")
+		page, err := c.ListObjects(ctx, &sci.ListObjectsRequest{
+			Prefix:     strings.TrimPrefix(bucketDir, "/") + "/",
+			MaxResults: 1,
+		})
+		require.NoError(t, err)
+		require.Equal(t, resp.Objects[0].Name, page.Objects[0].Name)
+		page, err = c.ListObjects(ctx, &sci.ListObjectsRequest{
+			Prefix:     strings.TrimPrefix(bucketDir, "/") + "/",
+			MaxResults: 1,
+			PageToken:  page.NextPageToken,
+		})
+		require.NoError(t, err)
+		require.Len(t, page.Objects, 1)
+		require.Equal(t, resp.Objects[1].Name, page.Objects[0].Name)
+		require.Empty(t, page.NextPageToken)
+	}
+
+	for _, name := range []string{"../etc/passwd", "/etc", "/etc/"} {
+		t.Logf("Rejecting %q", name)
+		_, err := c.GetObjectMd5(ctx, &sci.GetObjectMd5Request{ObjectName: name})
+		require.Error(t, err, "GetObjectMd5")
+		_, err = c.GetObject(ctx, &sci.GetObjectRequest{ObjectName: name})
+		require.Error(t, err, "GetObject")
+		_, err = c.ListObjects(ctx, &sci.ListObjectsRequest{Prefix: name})
+		require.Error(t, err, "ListObjects")
+		_, err = c.StatObject(ctx, &sci.StatObjectRequest{ObjectName: name})
+		require.Error(t, err, "StatObject")
+		_, err = c.DeleteObjects(ctx, &sci.DeleteObjectsRequest{Prefix: name})
+		require.Error(t, err, "DeleteObjects (prefix)")
+		_, err = c.DeleteObjects(ctx, &sci.DeleteObjectsRequest{ObjectNames: []string{name}})
+		require.Error(t, err, "DeleteObjects (names)")
+	}
+	_, err = c.DeleteObjects(ctx, &sci.DeleteObjectsRequest{Prefix: strings.TrimPrefix(bucketDir, "/") + "/"})
+	require.Error(t, err, "the bucket directory itself should never be deleted")
+
+	{
+		t.Log("Stating object")
+		resp, err := c.StatObject(ctx, &sci.StatObjectRequest{
+			ObjectName: strings.TrimPrefix(filepath.Join(bucketDir, "abc/uploads/latest.tar.gz"), "/"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(len("hello")), resp.Size)
+		require.Equal(t, "5d41402abc4b2a76b9719d911017c592", resp.Md5Checksum)
+		require.Equal(t, "9a71bb4c", resp.Crc32CChecksum)
+		require.NotZero(t, resp.UpdatedUnix)
+
+		_, err = c.StatObject(ctx, &sci.StatObjectRequest{
+			ObjectName: strings.TrimPrefix(filepath.Join(bucketDir, "abc/missing"), "/"),
+		})
+		require.True(t, sci.IsNotFound(err), "expected a not found error, got: %v", err)
+		_, err = c.GetObject(ctx, &sci.GetObjectRequest{
+			ObjectName: strings.TrimPrefix(filepath.Join(bucketDir, "abc/missing"), "/"),
+		})
+		require.True(t, sci.IsNotFound(err), "expected a not found error, got: %v", err)
+	}
+
+	{
+		t.Log("Downloading file")
+		objectName := strings.TrimPrefix(filepath.Join(bucketDir, "abc/uploads/latest.tar.gz"), "/")
+		resp, err := c.CreateSignedDownloadURL(ctx, &sci.CreateSignedDownloadURLRequest{
+			ObjectName:        objectName,
+			ExpirationSeconds: 300,
+		})
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(resp.Url, fmt.Sprintf("%v/%v?", signedURLServer.URL, objectName)), resp.Url)
+
+		req, err := http.NewRequest(http.MethodGet, resp.Url, nil)
+		require.NoError(t, err)
+		req.Header.Set("Range", "bytes=2-")
+		httpResp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer httpResp.Body.Close()
+		require.Equal(t, http.StatusPartialContent, httpResp.StatusCode)
+		body, err := io.ReadAll(httpResp.Body)
+		require.NoError(t, err)
+		require.Equal(t, "llo", string(body))
+
+		httpResp, err = http.Get(fmt.Sprintf("%v/%v", signedURLServer.URL, objectName))
+		require.NoError(t, err)
+		httpResp.Body.Close()
+		require.Equal(t, http.StatusForbidden, httpResp.StatusCode, "unsigned downloads should be rejected")
+
+		httpResp, err = http.Get(uploadURL)
+		require.NoError(t, err)
+		httpResp.Body.Close()
+		require.Equal(t, http.StatusForbidden, httpResp.StatusCode, "upload urls should not allow downloads")
+
+		missing, err := c.CreateSignedDownloadURL(ctx, &sci.CreateSignedDownloadURLRequest{
+			ObjectName:        strings.TrimPrefix(filepath.Join(bucketDir, "abc/missing"), "/"),
+			ExpirationSeconds: 300,
+		})
+		require.NoError(t, err)
+		httpResp, err = http.Get(missing.Url)
+		require.NoError(t, err)
+		httpResp.Body.Close()
+		require.Equal(t, http.StatusNotFound, httpResp.StatusCode)
+	}
+
+	{
+		t.Log("Deleting objects by name")
+		resp, err := c.DeleteObjects(ctx, &sci.DeleteObjectsRequest{
+			ObjectNames: []string{
+				strings.TrimPrefix(filepath.Join(bucketDir, "abc/uploads/md5.txt"), "/"),
+				strings.TrimPrefix(filepath.Join(bucketDir, "abc/missing"), "/"),
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), resp.DeletedCount)
+		require.NoFileExists(t, filepath.Join(bucketDir, "abc/uploads/md5.txt"))
+
+		_, err = c.DeleteObjects(ctx, &sci.DeleteObjectsRequest{})
+		require.Error(t, err, "a request without prefix or names should be rejected")
+	}
+
+	{
+		t.Log("Deleting objects")
+		resp, err := c.DeleteObjects(ctx, &sci.DeleteObjectsRequest{
+			Prefix: strings.TrimPrefix(filepath.Join(bucketDir, "abc"), "/") + "/",
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), resp.DeletedCount)
+		require.NoFileExists(t, filepath.Join(bucketDir, "abc/uploads/latest.tar.gz"))
+	}
 }
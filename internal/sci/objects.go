@@ -0,0 +1,253 @@
+package sci
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NotFound returns the error that is returned for missing objects.
+func NotFound(bucket, object string) error {
+	return status.Errorf(codes.NotFound, "object not found: %v/%v", bucket, object)
+}
+
+// IsNotFound returns true if err (as returned by a client) reports a
+// missing object.
+func IsNotFound(err error) bool {
+	return status.Code(err) == codes.NotFound
+}
+
+// ValidateDeleteObjects rejects requests that do not select any objects. An
+// empty prefix on its own would delete everything in the bucket.
+func ValidateDeleteObjects(req *DeleteObjectsRequest) error {
+	if req.GetPrefix() == "" && len(req.GetObjectNames()) == 0 {
+		return fmt.Errorf("prefix or object names must be set")
+	}
+	return nil
+}
+
+// ReadAll reads from r until EOF. If maxBytes is greater than zero and r
+// contains more than maxBytes, an error is returned.
+func ReadAll(r io.Reader, maxBytes int64) ([]byte, error) {
+	if maxBytes <= 0 {
+		return io.ReadAll(r)
+	}
+	contents, err := io.ReadAll(io.LimitReader(r, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(contents)) > maxBytes {
+		return nil, fmt.Errorf("object is larger than %v bytes", maxBytes)
+	}
+	return contents, nil
+}
+
+// DefaultMaxResults is the page size of ListObjects when none is requested.
+const DefaultMaxResults = 1000
+
+// MaxResults returns the requested page size or the default.
+func MaxResults(req *ListObjectsRequest) int {
+	if n := req.GetMaxResults(); n > 0 {
+		return int(n)
+	}
+	return DefaultMaxResults
+}
+
+// ListFiles lists the regular files under root whose names (relative to
+// root, using forward slashes) start with prefix. Results are sorted by name
+// and paginated using the name of the last object of the previous page as
+// the page token. A missing directory results in an empty list.
+func ListFiles(root string, req *ListObjectsRequest) (*ListObjectsResponse, error) {
+	prefix := strings.TrimPrefix(req.GetPrefix(), "/")
+
+	// Walk the deepest directory that contains every match.
+	start := filepath.Join(root, filepath.FromSlash(prefix))
+	if !strings.HasSuffix(prefix, "/") {
+		start = filepath.Dir(start)
+	}
+
+	var objects []*ObjectInfo
+	err := filepath.WalkDir(start, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if !strings.HasPrefix(name, prefix) || name <= req.GetPageToken() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, &ObjectInfo{Name: name, Size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Name < objects[j].Name })
+
+	resp := &ListObjectsResponse{Objects: objects}
+	if max := MaxResults(req); len(objects) > max {
+		resp.Objects = objects[:max]
+		resp.NextPageToken = objects[max-1].Name
+	}
+	return resp, nil
+}
+
+// DeleteFiles removes the regular files under root whose names (relative to
+// root, using forward slashes) start with prefix and returns how many were
+// removed. Directories that are left empty are removed as well. An empty
+// prefix is rejected to avoid deleting everything under root.
+func DeleteFiles(root, prefix string) (int64, error) {
+	prefix = strings.TrimPrefix(prefix, "/")
+	if prefix == "" {
+		return 0, fmt.Errorf("prefix must not be empty")
+	}
+
+	start := filepath.Join(root, filepath.FromSlash(prefix))
+	if !strings.HasSuffix(prefix, "/") {
+		start = filepath.Dir(start)
+	}
+
+	var (
+		count int64
+		dirs  []string
+	)
+	err := filepath.WalkDir(start, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			dirs = append(dirs, p)
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		if !strings.HasPrefix(filepath.ToSlash(rel), prefix) {
+			return nil
+		}
+		if err := os.Remove(p); err != nil {
+			return err
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+
+	// Remove the deepest directories first, non-empty ones are kept.
+	for i := len(dirs) - 1; i >= 0; i-- {
+		os.Remove(dirs[i])
+	}
+
+	return count, nil
+}
+
+// DeleteFileNames removes the given files (relative to root, using forward
+// slashes) and returns how many were removed. Missing files are ignored.
+func DeleteFileNames(root string, names []string) (int64, error) {
+	var count int64
+	for _, name := range names {
+		err := os.Remove(filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(name, "/"))))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// StatFile returns the size, checksums and modification time of a file. The
+// checksums are computed by reading the whole file.
+func StatFile(p string) (*StatObjectResponse, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("not a regular file: %v", p)
+	}
+
+	md5Hash := md5.New()
+	crcHash := crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	if _, err := io.Copy(io.MultiWriter(md5Hash, crcHash), f); err != nil {
+		return nil, err
+	}
+
+	return &StatObjectResponse{
+		Size:           info.Size(),
+		Md5Checksum:    hex.EncodeToString(md5Hash.Sum(nil)),
+		Crc32CChecksum: hex.EncodeToString(crcHash.Sum(nil)),
+		UpdatedUnix:    info.ModTime().Unix(),
+	}, nil
+}
+
+// ServeFile responds to a GET or HEAD request of a signed download URL with
+// the contents of a regular file. Range requests are supported so that
+// downloads can be resumed.
+func ServeFile(w http.ResponseWriter, r *http.Request, p string) {
+	f, err := os.Open(p)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		log.Printf("failed to open file: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		log.Printf("failed to stat file: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if !info.Mode().IsRegular() {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	http.ServeContent(w, r, filepath.Base(p), info.ModTime(), f)
+}
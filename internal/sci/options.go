@@ -0,0 +1,121 @@
+package sci
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// ServerOptions configure the transport security and authentication of an
+// SCI gRPC server. They are shared by all SCI implementations.
+type ServerOptions struct {
+	TLSCertFile     string
+	TLSKeyFile      string
+	TLSClientCAFile string
+
+	// AuthorizedUsers are the only users (as reported by TokenReview) that
+	// may call the SCI. Authentication is disabled when empty.
+	AuthorizedUsers string
+	TokenAudience   string
+}
+
+func (o *ServerOptions) BindFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.TLSCertFile, "tls-cert-file", "", "File containing the TLS certificate of the gRPC server. Serves plaintext when not set.")
+	fs.StringVar(&o.TLSKeyFile, "tls-key-file", "", "File containing the TLS private key of the gRPC server.")
+	fs.StringVar(&o.TLSClientCAFile, "tls-client-ca-file", "", "File containing the CA bundle that client certificates are verified against. Client certificates are required when set (mTLS).")
+	fs.StringVar(&o.AuthorizedUsers, "authorized-users", "", "Comma separated list of the users that may call the SCI (i.e. \"system:serviceaccount:substratus:controller-manager\"). Callers are authenticated with ServiceAccount tokens via TokenReview. Authentication is disabled when not set.")
+	fs.StringVar(&o.TokenAudience, "token-audience", DefaultTokenAudience, "Audience that the tokens of callers must be valid for.")
+}
+
+// GRPCServerOptions returns the options to create a gRPC server with. The
+// authenticator uses the in-cluster Kubernetes config.
+func (o *ServerOptions) GRPCServerOptions() ([]grpc.ServerOption, error) {
+	var opts []grpc.ServerOption
+
+	if o.TLSCertFile != "" || o.TLSKeyFile != "" {
+		cfg, err := ServerTLSConfig(o.TLSCertFile, o.TLSKeyFile, o.TLSClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("configuring tls: %w", err)
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(cfg)))
+	} else {
+		if o.TLSClientCAFile != "" {
+			return nil, errors.New("--tls-client-ca-file requires --tls-cert-file and --tls-key-file")
+		}
+		log.Println("WARNING: TLS is not configured, serving plaintext gRPC")
+	}
+
+	if o.AuthorizedUsers != "" {
+		restConfig, err := rest.InClusterConfig()
+		if err != nil {
+			return nil, fmt.Errorf("getting in-cluster config: %w", err)
+		}
+		clientset, err := kubernetes.NewForConfig(restConfig)
+		if err != nil {
+			return nil, fmt.Errorf("creating clientset: %w", err)
+		}
+		auth := &Authenticator{
+			Reviewer:  clientset.AuthenticationV1().TokenReviews(),
+			Audiences: []string{o.TokenAudience},
+			Usernames: strings.Split(o.AuthorizedUsers, ","),
+		}
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor()),
+			grpc.ChainStreamInterceptor(auth.StreamServerInterceptor()),
+		)
+	} else {
+		log.Println("WARNING: --authorized-users is not set, callers are not authenticated")
+	}
+
+	return opts, nil
+}
+
+// ClientOptions configure how the controller connects to the SCI.
+type ClientOptions struct {
+	TLSCAFile     string
+	TLSCertFile   string
+	TLSKeyFile    string
+	TLSServerName string
+	TokenFile     string
+}
+
+func (o *ClientOptions) BindFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.TLSCAFile, "sci-tls-ca-file", "", "File containing the CA bundle that the SCI server certificate is verified against. Connects in plaintext when no TLS flags are set.")
+	fs.StringVar(&o.TLSCertFile, "sci-tls-cert-file", "", "File containing the client certificate that is presented to the SCI (mTLS).")
+	fs.StringVar(&o.TLSKeyFile, "sci-tls-key-file", "", "File containing the private key of the client certificate.")
+	fs.StringVar(&o.TLSServerName, "sci-tls-server-name", "", "Name that the SCI server certificate is verified against. Defaults to the host of --sci-address.")
+	fs.StringVar(&o.TokenFile, "sci-token-file", "", "File containing a ServiceAccount token (with the SCI audience) that is sent with every call. Requires TLS.")
+}
+
+// DialOptions returns the options to dial the SCI with.
+func (o *ClientOptions) DialOptions() ([]grpc.DialOption, error) {
+	var opts []grpc.DialOption
+
+	if o.TLSCAFile != "" || o.TLSCertFile != "" || o.TLSKeyFile != "" {
+		cfg, err := ClientTLSConfig(o.TLSCAFile, o.TLSCertFile, o.TLSKeyFile, o.TLSServerName)
+		if err != nil {
+			return nil, fmt.Errorf("configuring tls: %w", err)
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(cfg)))
+	} else {
+		if o.TokenFile != "" {
+			return nil, errors.New("--sci-token-file requires TLS (--sci-tls-ca-file)")
+		}
+		log.Println("WARNING: TLS is not configured, connecting to the SCI in plaintext")
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	if o.TokenFile != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(TokenFileCredentials{Path: o.TokenFile}))
+	}
+
+	return opts, nil
+}
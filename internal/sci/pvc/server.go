@@ -0,0 +1,244 @@
+package pvc
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	sci "github.com/substratusai/substratus/internal/sci"
+)
+
+var _ sci.ControllerServer = &Server{}
+
+// Server serves uploads into a directory that a ReadWriteMany PVC is mounted
+// at, the same way that the kind server does for a hostPath volume.
+type Server struct {
+	// Dir is where the artifacts PVC is mounted. Object names are expected to
+	// be absolute paths within this directory (i.e. "/bucket/<guid>/...").
+	Dir string
+	// SignedURLAddress is the address that clients use to reach the
+	// signed URL handler (i.e. an Ingress or LoadBalancer address).
+	SignedURLAddress string
+	// SigningKey is the HMAC key that signed URLs are signed with.
+	SigningKey []byte
+
+	sci.UnimplementedControllerServer
+}
+
+// ServeHTTP implements the http.Handler interface and is used to provide cloud-bucket-like signed URL support.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log.Printf("Signed URL Server: %v", r.URL.Path)
+
+	path, err := s.resolvePath(r.URL.Path)
+	if err != nil {
+		log.Printf("invalid path: %v", err)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	sci.ServeSignedURL(w, r, s.signer(), path)
+}
+
+// resolvePath cleans the given object path and ensures that it is within
+// the PVC directory. The PVC is shared by all namespaces so writes must
+// never escape it.
+func (s *Server) resolvePath(objPath string) (string, error) {
+	dir := filepath.Clean(s.Dir)
+	path := filepath.Clean("/" + objPath)
+	if !strings.HasPrefix(path, dir+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %q is outside of %q", objPath, dir)
+	}
+	return path, nil
+}
+
+// resolvePrefix is like resolvePath but also accepts the PVC directory
+// itself (i.e. "bucket/") so that the whole bucket can be listed. The
+// returned prefix is cleaned and keeps a trailing slash.
+func (s *Server) resolvePrefix(prefix string) (string, error) {
+	dir := filepath.Clean(s.Dir)
+	path := filepath.Clean("/" + prefix)
+	if path == dir {
+		return dir + "/", nil
+	}
+	path, err := s.resolvePath(prefix)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasSuffix(prefix, "/") {
+		path += "/"
+	}
+	return path, nil
+}
+
+func (s *Server) signer() sci.URLSigner {
+	return sci.URLSigner{Key: s.SigningKey}
+}
+
+func (s *Server) CreateSignedURL(ctx context.Context, req *sci.CreateSignedURLRequest) (*sci.CreateSignedURLResponse, error) {
+	log.Printf("CreateSignedURL: %v", req.ObjectName)
+
+	path, err := s.resolvePath(req.ObjectName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := hex.DecodeString(req.Md5Checksum); err != nil || req.Md5Checksum == "" {
+		return nil, fmt.Errorf("invalid md5 checksum %q", req.Md5Checksum)
+	}
+
+	u, err := s.signer().SignURL(s.SignedURLAddress, http.MethodPut, path, req.Md5Checksum, req.ExpirationSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("signing url: %w", err)
+	}
+
+	return &sci.CreateSignedURLResponse{
+		Url: u,
+	}, nil
+}
+
+func (s *Server) CreateSignedDownloadURL(ctx context.Context, req *sci.CreateSignedDownloadURLRequest) (*sci.CreateSignedDownloadURLResponse, error) {
+	log.Printf("CreateSignedDownloadURL: %v", req.ObjectName)
+
+	path, err := s.resolvePath(req.ObjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := s.signer().SignURL(s.SignedURLAddress, http.MethodGet, path, "", req.ExpirationSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("signing url: %w", err)
+	}
+
+	return &sci.CreateSignedDownloadURLResponse{
+		Url: u,
+	}, nil
+}
+
+func (s *Server) GetObjectMd5(ctx context.Context, req *sci.GetObjectMd5Request) (*sci.GetObjectMd5Response, error) {
+	log.Printf("GetObjectMd5: %v", req.ObjectName)
+
+	objPath, err := s.resolvePath(req.ObjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(filepath.Dir(objPath), "md5.txt")
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read md5 file: %v", err)
+	}
+
+	md5 := strings.TrimSpace(string(contents))
+	log.Printf("GetObjectMd5: found file %q with md5: %v", path, md5)
+
+	return &sci.GetObjectMd5Response{
+		Md5Checksum: md5,
+	}, nil
+}
+
+func (s *Server) GetObject(ctx context.Context, req *sci.GetObjectRequest) (*sci.GetObjectResponse, error) {
+	log.Printf("GetObject: %v", req.ObjectName)
+
+	objPath, err := s.resolvePath(req.ObjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(objPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, sci.NotFound(req.BucketName, req.ObjectName)
+		}
+		return nil, fmt.Errorf("open object file: %v", err)
+	}
+	defer f.Close()
+
+	contents, err := sci.ReadAll(f, req.MaxBytes)
+	if err != nil {
+		return nil, fmt.Errorf("read object file: %v", err)
+	}
+
+	return &sci.GetObjectResponse{
+		Contents: contents,
+	}, nil
+}
+
+func (s *Server) ListObjects(ctx context.Context, req *sci.ListObjectsRequest) (*sci.ListObjectsResponse, error) {
+	log.Printf("ListObjects: %v", req.Prefix)
+
+	prefix, err := s.resolvePrefix(req.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return sci.ListFiles("/", &sci.ListObjectsRequest{
+		BucketName: req.BucketName,
+		Prefix:     prefix,
+		MaxResults: req.MaxResults,
+		PageToken:  req.PageToken,
+	})
+}
+
+func (s *Server) StatObject(ctx context.Context, req *sci.StatObjectRequest) (*sci.StatObjectResponse, error) {
+	log.Printf("StatObject: %v", req.ObjectName)
+
+	objPath, err := s.resolvePath(req.ObjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := sci.StatFile(objPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, sci.NotFound(req.BucketName, req.ObjectName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("stat object file: %v", err)
+	}
+
+	return resp, nil
+}
+
+func (s *Server) DeleteObjects(ctx context.Context, req *sci.DeleteObjectsRequest) (*sci.DeleteObjectsResponse, error) {
+	log.Printf("DeleteObjects: %v %v", req.Prefix, req.ObjectNames)
+
+	if err := sci.ValidateDeleteObjects(req); err != nil {
+		return nil, err
+	}
+	if req.Prefix != "" {
+		if _, err := s.resolvePath(req.Prefix); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range req.ObjectNames {
+		if _, err := s.resolvePath(name); err != nil {
+			return nil, err
+		}
+	}
+
+	var count int64
+	if req.Prefix != "" {
+		n, err := sci.DeleteFiles("/", req.Prefix)
+		count += n
+		if err != nil {
+			return nil, fmt.Errorf("delete files: %v", err)
+		}
+	}
+	n, err := sci.DeleteFileNames("/", req.ObjectNames)
+	count += n
+	if err != nil {
+		return nil, fmt.Errorf("delete files: %v", err)
+	}
+
+	return &sci.DeleteObjectsResponse{
+		DeletedCount: count,
+	}, nil
+}
+
+func (s *Server) BindIdentity(ctx context.Context, in *sci.BindIdentityRequest) (*sci.BindIdentityResponse, error) {
+	return &sci.BindIdentityResponse{}, nil
+}
@@ -0,0 +1,279 @@
+package pvc_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	sci "github.com/substratusai/substratus/internal/sci"
+	scipvc "github.com/substratusai/substratus/internal/sci/pvc"
+)
+
+func TestServer(t *testing.T) {
+	bucketDir := t.TempDir()
+
+	s := &scipvc.Server{
+		Dir:        bucketDir,
+		SigningKey: []byte("test-signing-key"),
+	}
+
+	signedURLServer := httptest.NewServer(s)
+	defer signedURLServer.Close()
+	s.SignedURLAddress = signedURLServer.URL
+
+	ctx := context.Background()
+
+	// MD5 encoded "hello":
+	const md5Hex = "5d41402abc4b2a76b9719d911017c592"
+	objectName := filepath.Join(bucketDir, "abc/uploads/latest.tar.gz")
+
+	md5Btys, err := hex.DecodeString(md5Hex)
+	require.NoError(t, err)
+	md5B64 := base64.StdEncoding.EncodeToString(md5Btys)
+
+	upload := func(t *testing.T, url, contentMD5, body string) *http.Response {
+		req, err := http.NewRequest(http.MethodPut, url, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/octet-stream")
+		req.Header.Set("Content-MD5", contentMD5)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+	put := func(t *testing.T, url string) *http.Response {
+		return upload(t, url, md5B64, "hello")
+	}
+
+	var signedURL string
+	{
+		t.Log("Creating signed url")
+		resp, err := s.CreateSignedURL(ctx, &sci.CreateSignedURLRequest{
+			Md5Checksum:       md5Hex,
+			ObjectName:        objectName,
+			ExpirationSeconds: 300,
+		})
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(resp.Url, fmt.Sprintf("%v%v?", signedURLServer.URL, objectName)), resp.Url)
+		signedURL = resp.Url
+	}
+
+	{
+		t.Log("Rejecting invalid uploads")
+		require.Equal(t, http.StatusForbidden, put(t, signedURLServer.URL+objectName).StatusCode, "unsigned url")
+
+		otherObject := strings.Replace(signedURL, "latest.tar.gz", "other.tar.gz", 1)
+		require.Equal(t, http.StatusForbidden, put(t, otherObject).StatusCode, "url of another object")
+
+		otherMD5 := base64.StdEncoding.EncodeToString(make([]byte, 16))
+		require.Equal(t, http.StatusForbidden, upload(t, signedURL, otherMD5, "hello").StatusCode, "content-md5 that was not signed")
+
+		require.Equal(t, http.StatusBadRequest, upload(t, signedURL, md5B64, "hell").StatusCode, "truncated body")
+		require.NoFileExists(t, objectName)
+	}
+
+	{
+		t.Log("Uploading file")
+		resp := put(t, signedURL)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		require.FileExists(t, filepath.Join(bucketDir, "abc/uploads/md5.txt"))
+		contents, err := os.ReadFile(objectName)
+		require.NoError(t, err)
+		require.Equal(t, "hello", string(contents))
+	}
+
+	{
+		t.Log("Getting md5")
+		resp, err := s.GetObjectMd5(ctx, &sci.GetObjectMd5Request{
+			ObjectName: objectName,
+		})
+		require.NoError(t, err)
+		require.Equal(t, md5Hex, resp.Md5Checksum)
+	}
+
+	{
+		t.Log("Getting object")
+		resp, err := s.GetObject(ctx, &sci.GetObjectRequest{
+			ObjectName: objectName,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "hello", string(resp.Contents))
+
+		_, err = s.GetObject(ctx, &sci.GetObjectRequest{
+			ObjectName: objectName,
+			MaxBytes:   4,
+		})
+		require.Error(t, err, "objects larger than max bytes should be rejected")
+	}
+
+	{
+		t.Log("Downloading file")
+		resp, err := s.CreateSignedDownloadURL(ctx, &sci.CreateSignedDownloadURLRequest{
+			ObjectName:        objectName,
+			ExpirationSeconds: 300,
+		})
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(resp.Url, fmt.Sprintf("%v%v?", signedURLServer.URL, objectName)), resp.Url)
+
+		unsigned, err := http.Get(signedURLServer.URL + objectName)
+		require.NoError(t, err)
+		unsigned.Body.Close()
+		require.Equal(t, http.StatusForbidden, unsigned.StatusCode, "unsigned url")
+
+		otherObject, err := http.Get(strings.Replace(resp.Url, "latest.tar.gz", "md5.txt", 1))
+		require.NoError(t, err)
+		otherObject.Body.Close()
+		require.Equal(t, http.StatusForbidden, otherObject.StatusCode, "url of another object")
+
+		upload, err := http.Get(signedURL)
+		require.NoError(t, err)
+		upload.Body.Close()
+		require.Equal(t, http.StatusForbidden, upload.StatusCode, "upload urls can not be used for downloads")
+
+		httpResp, err := http.Get(resp.Url)
+		require.NoError(t, err)
+		defer httpResp.Body.Close()
+		require.Equal(t, http.StatusOK, httpResp.StatusCode)
+		body, err := io.ReadAll(httpResp.Body)
+		require.NoError(t, err)
+		require.Equal(t, "hello", string(body))
+	}
+
+	{
+		t.Log("Listing objects")
+		prefix := filepath.Join(bucketDir, "abc") + "/"
+		resp, err := s.ListObjects(ctx, &sci.ListObjectsRequest{Prefix: prefix})
+		require.NoError(t, err)
+		require.Len(t, resp.Objects, 2)
+		require.Equal(t, strings.TrimPrefix(objectName, "/"), resp.Objects[0].Name)
+		require.Equal(t, int64(len("hello")), resp.Objects[0].Size)
+		require.Equal(t, strings.TrimPrefix(filepath.Join(bucketDir, "abc/uploads/md5.txt"), "/"), resp.Objects[1].Name)
+
+		t.Log("Paginating objects")
+		page, err := s.ListObjects(ctx, &sci.ListObjectsRequest{Prefix: prefix, MaxResults: 1})
+		require.NoError(t, err)
+		require.Equal(t, resp.Objects[:1], page.Objects)
+		require.NotEmpty(t, page.NextPageToken)
+		page, err = s.ListObjects(ctx, &sci.ListObjectsRequest{Prefix: prefix, MaxResults: 1, PageToken: page.NextPageToken})
+		require.NoError(t, err)
+		require.Equal(t, resp.Objects[1:], page.Objects)
+		require.Empty(t, page.NextPageToken)
+
+		t.Log("Listing a missing prefix")
+		resp, err = s.ListObjects(ctx, &sci.ListObjectsRequest{Prefix: filepath.Join(bucketDir, "missing") + "/"})
+		require.NoError(t, err)
+		require.Empty(t, resp.Objects)
+
+		t.Log("Listing the bucket root (as the artifacts sweep does)")
+		root, err := s.ListObjects(ctx, &sci.ListObjectsRequest{Prefix: strings.TrimPrefix(bucketDir, "/") + "/"})
+		require.NoError(t, err)
+		require.Len(t, root.Objects, 2)
+		require.Equal(t, strings.TrimPrefix(objectName, "/"), root.Objects[0].Name)
+	}
+
+	{
+		t.Log("Rejecting paths outside of the bucket directory")
+		_, err := s.CreateSignedURL(ctx, &sci.CreateSignedURLRequest{
+			ObjectName: "/etc/passwd",
+		})
+		require.Error(t, err)
+
+		resp := put(t, signedURLServer.URL+"/etc/abc/uploads/latest.tar.gz")
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		_, err = s.CreateSignedDownloadURL(ctx, &sci.CreateSignedDownloadURLRequest{
+			ObjectName: "/etc/passwd",
+		})
+		require.Error(t, err)
+
+		resp, err = http.Get(signedURLServer.URL + "/etc/passwd")
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		_, err = s.GetObjectMd5(ctx, &sci.GetObjectMd5Request{
+			ObjectName: filepath.Join(bucketDir, "../abc/uploads/latest.tar.gz"),
+		})
+		require.Error(t, err)
+
+		_, err = s.GetObject(ctx, &sci.GetObjectRequest{
+			ObjectName: "/etc/passwd",
+		})
+		require.Error(t, err)
+
+		_, err = s.ListObjects(ctx, &sci.ListObjectsRequest{
+			Prefix: "/etc/",
+		})
+		require.Error(t, err)
+
+		_, err = s.DeleteObjects(ctx, &sci.DeleteObjectsRequest{
+			Prefix: "/etc/",
+		})
+		require.Error(t, err)
+
+		_, err = s.DeleteObjects(ctx, &sci.DeleteObjectsRequest{
+			Prefix: bucketDir + "/",
+		})
+		require.Error(t, err, "the bucket directory itself should never be deleted")
+
+		_, err = s.DeleteObjects(ctx, &sci.DeleteObjectsRequest{
+			ObjectNames: []string{"/etc/passwd"},
+		})
+		require.Error(t, err)
+
+		_, err = s.StatObject(ctx, &sci.StatObjectRequest{
+			ObjectName: "/etc/passwd",
+		})
+		require.Error(t, err)
+	}
+
+	{
+		t.Log("Stating object")
+		resp, err := s.StatObject(ctx, &sci.StatObjectRequest{
+			ObjectName: filepath.Join(bucketDir, "abc/uploads/latest.tar.gz"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(len("hello")), resp.Size)
+		require.Equal(t, "5d41402abc4b2a76b9719d911017c592", resp.Md5Checksum)
+
+		_, err = s.StatObject(ctx, &sci.StatObjectRequest{
+			ObjectName: filepath.Join(bucketDir, "abc/missing"),
+		})
+		require.True(t, sci.IsNotFound(err), "expected a not found error, got: %v", err)
+		_, err = s.GetObject(ctx, &sci.GetObjectRequest{
+			ObjectName: filepath.Join(bucketDir, "abc/missing"),
+		})
+		require.True(t, sci.IsNotFound(err), "expected a not found error, got: %v", err)
+	}
+
+	{
+		t.Log("Deleting objects by name")
+		resp, err := s.DeleteObjects(ctx, &sci.DeleteObjectsRequest{
+			ObjectNames: []string{filepath.Join(bucketDir, "abc/uploads/md5.txt")},
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), resp.DeletedCount)
+		require.NoFileExists(t, filepath.Join(bucketDir, "abc/uploads/md5.txt"))
+	}
+
+	{
+		t.Log("Deleting objects")
+		resp, err := s.DeleteObjects(ctx, &sci.DeleteObjectsRequest{
+			Prefix: filepath.Join(bucketDir, "abc") + "/",
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), resp.DeletedCount)
+		require.NoDirExists(t, filepath.Join(bucketDir, "abc"))
+		require.DirExists(t, bucketDir)
+	}
+}
@@ -0,0 +1,184 @@
+// Package s3 provides an implementation of the Substratus Cloud Interface (SCI)
+// for S3-compatible object stores (i.e. MinIO).
+package s3
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	awsSdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/substratusai/substratus/internal/sci"
+	"github.com/substratusai/substratus/internal/sci/s3api"
+)
+
+var _ sci.ControllerServer = &Server{}
+
+type Server struct {
+	sci.UnimplementedControllerServer
+	Clients
+}
+
+type Clients struct {
+	S3Client *s3.S3
+	// PresignClient signs the URLs that are handed out to clients outside
+	// of the cluster (i.e. `sub run` uploads). It should use an endpoint that
+	// is reachable from there. Defaults to S3Client.
+	PresignClient *s3.S3
+}
+
+func (c Clients) presignClient() *s3.S3 {
+	if c.PresignClient != nil {
+		return c.PresignClient
+	}
+	return c.S3Client
+}
+
+type Config struct {
+	// Endpoint is the URL of the S3-compatible API.
+	Endpoint string
+	// Region is ignored by most S3-compatible stores but is required by the SDK.
+	Region string
+	// AccessKeyID and SecretAccessKey are static credentials for the store.
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// NewS3Client returns a client that uses path-style addressing against the
+// given endpoint. Path-style addressing is required because S3-compatible
+// stores typically do not support virtual-hosted-style bucket subdomains.
+func NewS3Client(cfg Config) (*s3.S3, error) {
+	if cfg.Endpoint == "" {
+		return nil, fmt.Errorf("endpoint is required")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+
+	sess, err := session.NewSession(&awsSdk.Config{
+		Endpoint:         awsSdk.String(cfg.Endpoint),
+		Region:           awsSdk.String(cfg.Region),
+		S3ForcePathStyle: awsSdk.Bool(true),
+		Credentials:      credentials.NewStaticCredentials(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating session: %w", err)
+	}
+
+	return s3.New(sess), nil
+}
+
+func (s *Server) CreateSignedURL(ctx context.Context, req *sci.CreateSignedURLRequest) (*sci.CreateSignedURLResponse, error) {
+	log.Printf("CreateSignedURL: %v/%v", req.BucketName, req.ObjectName)
+
+	bucketName, objectName, checksum := req.GetBucketName(),
+		req.GetObjectName(),
+		req.GetMd5Checksum()
+
+	// Convert hex MD5 to base64
+	data, err := hex.DecodeString(checksum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode MD5 checksum: %w", err)
+	}
+	base64md5 := base64.StdEncoding.EncodeToString(data)
+
+	putReq, _ := s.Clients.presignClient().PutObjectRequest(&s3.PutObjectInput{
+		Bucket:      awsSdk.String(bucketName),
+		Key:         awsSdk.String(objectName),
+		ContentType: awsSdk.String("application/octet-stream"),
+		ContentMD5:  awsSdk.String(base64md5),
+	})
+
+	expiration := time.Duration(req.GetExpirationSeconds()) * time.Second
+	url, err := putReq.Presign(expiration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to presign request: %w", err)
+	}
+
+	return &sci.CreateSignedURLResponse{Url: url}, nil
+}
+
+// CreateSignedDownloadURL presigns a GET request for an S3 object.
+func (s *Server) CreateSignedDownloadURL(ctx context.Context, req *sci.CreateSignedDownloadURLRequest) (*sci.CreateSignedDownloadURLResponse, error) {
+	log.Printf("CreateSignedDownloadURL: %v/%v", req.BucketName, req.ObjectName)
+
+	getReq, _ := s.Clients.presignClient().GetObjectRequest(&s3.GetObjectInput{
+		Bucket: awsSdk.String(req.GetBucketName()),
+		Key:    awsSdk.String(req.GetObjectName()),
+	})
+
+	expiration := time.Duration(req.GetExpirationSeconds()) * time.Second
+	url, err := getReq.Presign(expiration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to presign request: %w", err)
+	}
+
+	return &sci.CreateSignedDownloadURLResponse{Url: url}, nil
+}
+
+func (s *Server) GetObjectMd5(ctx context.Context, req *sci.GetObjectMd5Request) (*sci.GetObjectMd5Response, error) {
+	log.Printf("GetObjectMd5: %v/%v", req.BucketName, req.ObjectName)
+
+	headResult, err := s.Clients.S3Client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: awsSdk.String(req.GetBucketName()),
+		Key:    awsSdk.String(req.GetObjectName()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if headResult.ETag == nil {
+		return nil, fmt.Errorf("object does not exist: %s", s3.ErrCodeNoSuchKey)
+	}
+
+	// NOTE: The ETag of a single-part upload is the hex encoded MD5 of the
+	// object wrapped in quotes. Multi-part uploads have a dash suffix and
+	// will never match a requested checksum.
+	md5 := strings.Trim(*headResult.ETag, `"`)
+
+	return &sci.GetObjectMd5Response{
+		Md5Checksum: md5,
+	}, nil
+}
+
+func (s *Server) GetObject(ctx context.Context, req *sci.GetObjectRequest) (*sci.GetObjectResponse, error) {
+	log.Printf("GetObject: %v/%v", req.BucketName, req.ObjectName)
+
+	return s3api.GetObject(ctx, s.Clients.S3Client, req)
+}
+
+// ListObjects lists the S3 objects with a given prefix.
+func (s *Server) ListObjects(ctx context.Context, req *sci.ListObjectsRequest) (*sci.ListObjectsResponse, error) {
+	log.Printf("ListObjects: %v/%v", req.BucketName, req.Prefix)
+
+	return s3api.ListObjects(ctx, s.Clients.S3Client, req)
+}
+
+// StatObject returns the attributes of an S3 object.
+func (s *Server) StatObject(ctx context.Context, req *sci.StatObjectRequest) (*sci.StatObjectResponse, error) {
+	log.Printf("StatObject: %v/%v", req.BucketName, req.ObjectName)
+
+	return s3api.StatObject(ctx, s.Clients.S3Client, req)
+}
+
+// DeleteObjects deletes every S3 object with a given prefix and the given
+// objects.
+func (s *Server) DeleteObjects(ctx context.Context, req *sci.DeleteObjectsRequest) (*sci.DeleteObjectsResponse, error) {
+	log.Printf("DeleteObjects: %v/%v %v", req.BucketName, req.Prefix, req.ObjectNames)
+
+	return s3api.DeleteObjects(ctx, s.Clients.S3Client, req)
+}
+
+// BindIdentity is a no-op: workloads authenticate to the object store
+// using static credentials.
+func (s *Server) BindIdentity(ctx context.Context, req *sci.BindIdentityRequest) (*sci.BindIdentityResponse, error) {
+	return &sci.BindIdentityResponse{}, nil
+}
@@ -0,0 +1,281 @@
+package s3_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/substratusai/substratus/internal/sci"
+	scis3 "github.com/substratusai/substratus/internal/sci/s3"
+)
+
+// fakeObjectStore is a minimal stand-in for an S3-compatible store (i.e. MinIO)
+// that only understands path-style PUT, HEAD and GET object requests,
+// (unpaginated) ListObjectsV2 requests and DeleteObjects requests.
+// lastModified is reported for every object.
+var lastModified = time.Date(2023, 8, 1, 12, 0, 0, 0, time.UTC)
+
+type fakeObjectStore struct {
+	mtx     sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeObjectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	key := strings.TrimPrefix(r.URL.Path, "/")
+
+	switch r.Method {
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		sum := md5.Sum(body)
+		if r.Header.Get("Content-MD5") != base64.StdEncoding.EncodeToString(sum[:]) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.objects[key] = body
+		w.Header().Set("ETag", fmt.Sprintf("%q", hex.EncodeToString(sum[:])))
+	case http.MethodHead:
+		body, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		sum := md5.Sum(body)
+		w.Header().Set("ETag", fmt.Sprintf("%q", hex.EncodeToString(sum[:])))
+		w.Header().Set("Content-Length", fmt.Sprint(len(body)))
+		w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+	case http.MethodGet:
+		if r.URL.Query().Get("list-type") != "2" {
+			body, ok := f.objects[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(body)
+			return
+		}
+		bucket, prefix := key+"/", r.URL.Query().Get("prefix")
+		var keys []string
+		for k := range f.objects {
+			if strings.HasPrefix(k, bucket+prefix) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		fmt.Fprint(w, `<ListBucketResult><IsTruncated>false</IsTruncated>`)
+		for _, k := range keys {
+			fmt.Fprintf(w, `<Contents><Key>%s</Key><Size>%d</Size></Contents>`, strings.TrimPrefix(k, bucket), len(f.objects[k]))
+		}
+		fmt.Fprint(w, `</ListBucketResult>`)
+	case http.MethodPost:
+		if _, ok := r.URL.Query()["delete"]; !ok {
+			w.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+		var del struct {
+			Objects []struct {
+				Key string
+			} `xml:"Object"`
+		}
+		if err := xml.NewDecoder(r.Body).Decode(&del); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for _, obj := range del.Objects {
+			delete(f.objects, key+"/"+obj.Key)
+		}
+		fmt.Fprint(w, `<DeleteResult></DeleteResult>`)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestServer(t *testing.T) {
+	store := &fakeObjectStore{objects: map[string][]byte{}}
+	storeServer := httptest.NewServer(store)
+	defer storeServer.Close()
+
+	// publicServer stands in for the address that the store is exposed at
+	// outside of the cluster (i.e. an Ingress).
+	publicServer := httptest.NewServer(store)
+	defer publicServer.Close()
+
+	s3Client, err := scis3.NewS3Client(scis3.Config{
+		Endpoint:        storeServer.URL,
+		AccessKeyID:     "test-access-key",
+		SecretAccessKey: "test-secret-key",
+	})
+	require.NoError(t, err)
+	presignClient, err := scis3.NewS3Client(scis3.Config{
+		Endpoint:        publicServer.URL,
+		AccessKeyID:     "test-access-key",
+		SecretAccessKey: "test-secret-key",
+	})
+	require.NoError(t, err)
+
+	s := &scis3.Server{Clients: scis3.Clients{S3Client: s3Client, PresignClient: presignClient}}
+	ctx := context.Background()
+
+	const (
+		bucket = "substratus-artifacts"
+		object = "abc/uploads/latest.tar.gz"
+		// MD5 encoded "hello":
+		md5Hex = "5d41402abc4b2a76b9719d911017c592"
+	)
+
+	var signedURL string
+	{
+		t.Log("Creating signed url")
+		resp, err := s.CreateSignedURL(ctx, &sci.CreateSignedURLRequest{
+			BucketName:        bucket,
+			ObjectName:        object,
+			ExpirationSeconds: 300,
+			Md5Checksum:       md5Hex,
+		})
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(resp.Url, fmt.Sprintf("%v/%v/%v?", publicServer.URL, bucket, object)),
+			"expected path-style url of the public endpoint, got: %v", resp.Url)
+		signedURL = resp.Url
+	}
+
+	{
+		t.Log("Uploading file")
+		req, err := http.NewRequest(http.MethodPut, signedURL, bytes.NewReader([]byte("hello")))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/octet-stream")
+		md5Btys, err := hex.DecodeString(md5Hex)
+		require.NoError(t, err)
+		req.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(md5Btys))
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	{
+		t.Log("Downloading file")
+		resp, err := s.CreateSignedDownloadURL(ctx, &sci.CreateSignedDownloadURLRequest{
+			BucketName:        bucket,
+			ObjectName:        object,
+			ExpirationSeconds: 300,
+		})
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(resp.Url, fmt.Sprintf("%v/%v/%v?", publicServer.URL, bucket, object)),
+			"expected path-style url of the public endpoint, got: %v", resp.Url)
+
+		httpResp, err := http.Get(resp.Url)
+		require.NoError(t, err)
+		defer httpResp.Body.Close()
+		require.Equal(t, http.StatusOK, httpResp.StatusCode)
+		body, err := io.ReadAll(httpResp.Body)
+		require.NoError(t, err)
+		require.Equal(t, "hello", string(body))
+	}
+
+	{
+		t.Log("Getting md5")
+		resp, err := s.GetObjectMd5(ctx, &sci.GetObjectMd5Request{
+			BucketName: bucket,
+			ObjectName: object,
+		})
+		require.NoError(t, err)
+		require.Equal(t, md5Hex, resp.Md5Checksum)
+	}
+
+	{
+		t.Log("Getting md5 of missing object")
+		_, err := s.GetObjectMd5(ctx, &sci.GetObjectMd5Request{
+			BucketName: bucket,
+			ObjectName: "does/not/exist",
+		})
+		require.Error(t, err)
+	}
+
+	{
+		t.Log("Listing objects")
+		resp, err := s.ListObjects(ctx, &sci.ListObjectsRequest{
+			BucketName: bucket,
+			Prefix:     "abc/",
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Objects, 1)
+		require.Equal(t, object, resp.Objects[0].Name)
+		require.Equal(t, int64(len("hello")), resp.Objects[0].Size)
+		require.Empty(t, resp.NextPageToken)
+	}
+
+	{
+		t.Log("Stating object")
+		resp, err := s.StatObject(ctx, &sci.StatObjectRequest{
+			BucketName: bucket,
+			ObjectName: object,
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(len("hello")), resp.Size)
+		require.Equal(t, md5Hex, resp.Md5Checksum)
+		require.Equal(t, lastModified.Unix(), resp.UpdatedUnix)
+
+		_, err = s.StatObject(ctx, &sci.StatObjectRequest{
+			BucketName: bucket,
+			ObjectName: "does/not/exist",
+		})
+		require.True(t, sci.IsNotFound(err), "expected a not found error, got: %v", err)
+		_, err = s.GetObject(ctx, &sci.GetObjectRequest{
+			BucketName: bucket,
+			ObjectName: "does/not/exist",
+		})
+		require.True(t, sci.IsNotFound(err), "expected a not found error, got: %v", err)
+	}
+
+	{
+		t.Log("Deleting objects by name")
+		store.objects[bucket+"/abc/other"] = []byte("other")
+		resp, err := s.DeleteObjects(ctx, &sci.DeleteObjectsRequest{
+			BucketName:  bucket,
+			ObjectNames: []string{"abc/other"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), resp.DeletedCount)
+		require.NotContains(t, store.objects, bucket+"/abc/other")
+	}
+
+	{
+		t.Log("Deleting objects")
+		resp, err := s.DeleteObjects(ctx, &sci.DeleteObjectsRequest{
+			BucketName: bucket,
+			Prefix:     "abc/",
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), resp.DeletedCount)
+		require.Empty(t, store.objects)
+
+		_, err = s.DeleteObjects(ctx, &sci.DeleteObjectsRequest{BucketName: bucket})
+		require.Error(t, err, "a request without prefix or names should be rejected")
+	}
+
+	{
+		t.Log("Binding identity")
+		_, err := s.BindIdentity(ctx, &sci.BindIdentityRequest{})
+		require.NoError(t, err)
+	}
+}
@@ -0,0 +1,184 @@
+// Package s3api implements the object RPCs of the Substratus Cloud Interface
+// (SCI) against the S3 API. It is shared by the AWS and the S3-compatible
+// servers.
+package s3api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	awsSdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/substratusai/substratus/internal/sci"
+)
+
+// GetObject returns the contents of a (small) S3 object.
+func GetObject(ctx context.Context, client *s3.S3, req *sci.GetObjectRequest) (*sci.GetObjectResponse, error) {
+	out, err := client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: awsSdk.String(req.GetBucketName()),
+		Key:    awsSdk.String(req.GetObjectName()),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, sci.NotFound(req.GetBucketName(), req.GetObjectName())
+		}
+		return nil, err
+	}
+	defer out.Body.Close()
+
+	contents, err := sci.ReadAll(out.Body, req.GetMaxBytes())
+	if err != nil {
+		return nil, fmt.Errorf("reading object: %w", err)
+	}
+
+	return &sci.GetObjectResponse{Contents: contents}, nil
+}
+
+// ListObjects lists the S3 objects with a given prefix.
+func ListObjects(ctx context.Context, client *s3.S3, req *sci.ListObjectsRequest) (*sci.ListObjectsResponse, error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket:  awsSdk.String(req.GetBucketName()),
+		Prefix:  awsSdk.String(req.GetPrefix()),
+		MaxKeys: awsSdk.Int64(int64(sci.MaxResults(req))),
+	}
+	if req.GetPageToken() != "" {
+		input.ContinuationToken = awsSdk.String(req.GetPageToken())
+	}
+	out, err := client.ListObjectsV2WithContext(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("listing objects: %w", err)
+	}
+
+	resp := &sci.ListObjectsResponse{}
+	if awsSdk.BoolValue(out.IsTruncated) {
+		resp.NextPageToken = awsSdk.StringValue(out.NextContinuationToken)
+	}
+	for _, obj := range out.Contents {
+		resp.Objects = append(resp.Objects, &sci.ObjectInfo{
+			Name: awsSdk.StringValue(obj.Key),
+			Size: awsSdk.Int64Value(obj.Size),
+		})
+	}
+	return resp, nil
+}
+
+// StatObject returns the attributes of an S3 object. The CRC32C checksum is
+// only known if one was sent when the object was uploaded.
+func StatObject(ctx context.Context, client *s3.S3, req *sci.StatObjectRequest) (*sci.StatObjectResponse, error) {
+	out, err := client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket:       awsSdk.String(req.GetBucketName()),
+		Key:          awsSdk.String(req.GetObjectName()),
+		ChecksumMode: awsSdk.String(s3.ChecksumModeEnabled),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, sci.NotFound(req.GetBucketName(), req.GetObjectName())
+		}
+		return nil, err
+	}
+
+	resp := &sci.StatObjectResponse{Size: awsSdk.Int64Value(out.ContentLength)}
+	if out.LastModified != nil {
+		resp.UpdatedUnix = out.LastModified.Unix()
+	}
+	// NOTE: The ETag of a multi-part upload (with a dash suffix) is not an
+	// MD5 checksum.
+	if etag := strings.Trim(awsSdk.StringValue(out.ETag), `"`); !strings.Contains(etag, "-") {
+		resp.Md5Checksum = etag
+	}
+	if crc, err := base64.StdEncoding.DecodeString(awsSdk.StringValue(out.ChecksumCRC32C)); err == nil {
+		resp.Crc32CChecksum = hex.EncodeToString(crc)
+	}
+
+	return resp, nil
+}
+
+// DeleteObjects deletes every S3 object with a given prefix and the given
+// objects. Each page of the listing is deleted with a single batch request.
+func DeleteObjects(ctx context.Context, client *s3.S3, req *sci.DeleteObjectsRequest) (*sci.DeleteObjectsResponse, error) {
+	if err := sci.ValidateDeleteObjects(req); err != nil {
+		return nil, err
+	}
+
+	var count int64
+	if req.GetPrefix() != "" {
+		var delErr error
+		input := &s3.ListObjectsV2Input{
+			Bucket: awsSdk.String(req.GetBucketName()),
+			Prefix: awsSdk.String(req.GetPrefix()),
+		}
+		err := client.ListObjectsV2PagesWithContext(ctx, input, func(page *s3.ListObjectsV2Output, _ bool) bool {
+			keys := make([]string, 0, len(page.Contents))
+			for _, obj := range page.Contents {
+				keys = append(keys, awsSdk.StringValue(obj.Key))
+			}
+			if delErr = deleteKeys(ctx, client, req.GetBucketName(), keys); delErr != nil {
+				return false
+			}
+			count += int64(len(keys))
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing objects: %w", err)
+		}
+		if delErr != nil {
+			return nil, delErr
+		}
+	}
+
+	// NOTE: S3 does not report whether a deleted object existed, so every
+	// requested name is counted.
+	if err := deleteKeys(ctx, client, req.GetBucketName(), req.GetObjectNames()); err != nil {
+		return nil, err
+	}
+	count += int64(len(req.GetObjectNames()))
+
+	return &sci.DeleteObjectsResponse{DeletedCount: count}, nil
+}
+
+// maxDeleteKeys is the maximum number of keys of a DeleteObjects request.
+const maxDeleteKeys = 1000
+
+// deleteKeys deletes the given objects with as few batch requests as
+// possible.
+func deleteKeys(ctx context.Context, client *s3.S3, bucket string, keys []string) error {
+	for len(keys) > 0 {
+		n := min(len(keys), maxDeleteKeys)
+		del := &s3.Delete{Quiet: awsSdk.Bool(true)}
+		for _, key := range keys[:n] {
+			del.Objects = append(del.Objects, &s3.ObjectIdentifier{Key: awsSdk.String(key)})
+		}
+		out, err := client.DeleteObjectsWithContext(ctx, &s3.DeleteObjectsInput{
+			Bucket: awsSdk.String(bucket),
+			Delete: del,
+		})
+		if err != nil {
+			return fmt.Errorf("deleting objects: %w", err)
+		}
+		if len(out.Errors) > 0 {
+			e := out.Errors[0]
+			return fmt.Errorf("deleting object %q: %v", awsSdk.StringValue(e.Key), awsSdk.StringValue(e.Message))
+		}
+		keys = keys[n:]
+	}
+	return nil
+}
+
+// isNotFound returns true if a HEAD or GET request failed because the object
+// does not exist.
+func isNotFound(err error) bool {
+	var aerr awserr.Error
+	if errors.As(err, &aerr) {
+		switch aerr.Code() {
+		case s3.ErrCodeNoSuchKey, "NotFound":
+			return true
+		}
+	}
+	return false
+}
@@ -19,8 +19,13 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ControllerClient interface {
 	CreateSignedURL(ctx context.Context, in *CreateSignedURLRequest, opts ...grpc.CallOption) (*CreateSignedURLResponse, error)
+	CreateSignedDownloadURL(ctx context.Context, in *CreateSignedDownloadURLRequest, opts ...grpc.CallOption) (*CreateSignedDownloadURLResponse, error)
 	GetObjectMd5(ctx context.Context, in *GetObjectMd5Request, opts ...grpc.CallOption) (*GetObjectMd5Response, error)
 	BindIdentity(ctx context.Context, in *BindIdentityRequest, opts ...grpc.CallOption) (*BindIdentityResponse, error)
+	GetObject(ctx context.Context, in *GetObjectRequest, opts ...grpc.CallOption) (*GetObjectResponse, error)
+	ListObjects(ctx context.Context, in *ListObjectsRequest, opts ...grpc.CallOption) (*ListObjectsResponse, error)
+	StatObject(ctx context.Context, in *StatObjectRequest, opts ...grpc.CallOption) (*StatObjectResponse, error)
+	DeleteObjects(ctx context.Context, in *DeleteObjectsRequest, opts ...grpc.CallOption) (*DeleteObjectsResponse, error)
 }
 
 type controllerClient struct {
@@ -40,6 +45,15 @@ func (c *controllerClient) CreateSignedURL(ctx context.Context, in *CreateSigned
 	return out, nil
 }
 
+func (c *controllerClient) CreateSignedDownloadURL(ctx context.Context, in *CreateSignedDownloadURLRequest, opts ...grpc.CallOption) (*CreateSignedDownloadURLResponse, error) {
+	out := new(CreateSignedDownloadURLResponse)
+	err := c.cc.Invoke(ctx, "/sci.v1.Controller/CreateSignedDownloadURL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *controllerClient) GetObjectMd5(ctx context.Context, in *GetObjectMd5Request, opts ...grpc.CallOption) (*GetObjectMd5Response, error) {
 	out := new(GetObjectMd5Response)
 	err := c.cc.Invoke(ctx, "/sci.v1.Controller/GetObjectMd5", in, out, opts...)
@@ -58,13 +72,54 @@ func (c *controllerClient) BindIdentity(ctx context.Context, in *BindIdentityReq
 	return out, nil
 }
 
+func (c *controllerClient) GetObject(ctx context.Context, in *GetObjectRequest, opts ...grpc.CallOption) (*GetObjectResponse, error) {
+	out := new(GetObjectResponse)
+	err := c.cc.Invoke(ctx, "/sci.v1.Controller/GetObject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) ListObjects(ctx context.Context, in *ListObjectsRequest, opts ...grpc.CallOption) (*ListObjectsResponse, error) {
+	out := new(ListObjectsResponse)
+	err := c.cc.Invoke(ctx, "/sci.v1.Controller/ListObjects", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) StatObject(ctx context.Context, in *StatObjectRequest, opts ...grpc.CallOption) (*StatObjectResponse, error) {
+	out := new(StatObjectResponse)
+	err := c.cc.Invoke(ctx, "/sci.v1.Controller/StatObject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) DeleteObjects(ctx context.Context, in *DeleteObjectsRequest, opts ...grpc.CallOption) (*DeleteObjectsResponse, error) {
+	out := new(DeleteObjectsResponse)
+	err := c.cc.Invoke(ctx, "/sci.v1.Controller/DeleteObjects", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ControllerServer is the server API for Controller service.
 // All implementations must embed UnimplementedControllerServer
 // for forward compatibility
 type ControllerServer interface {
 	CreateSignedURL(context.Context, *CreateSignedURLRequest) (*CreateSignedURLResponse, error)
+	CreateSignedDownloadURL(context.Context, *CreateSignedDownloadURLRequest) (*CreateSignedDownloadURLResponse, error)
 	GetObjectMd5(context.Context, *GetObjectMd5Request) (*GetObjectMd5Response, error)
 	BindIdentity(context.Context, *BindIdentityRequest) (*BindIdentityResponse, error)
+	GetObject(context.Context, *GetObjectRequest) (*GetObjectResponse, error)
+	ListObjects(context.Context, *ListObjectsRequest) (*ListObjectsResponse, error)
+	StatObject(context.Context, *StatObjectRequest) (*StatObjectResponse, error)
+	DeleteObjects(context.Context, *DeleteObjectsRequest) (*DeleteObjectsResponse, error)
 	mustEmbedUnimplementedControllerServer()
 }
 
@@ -75,12 +130,27 @@ type UnimplementedControllerServer struct {
 func (UnimplementedControllerServer) CreateSignedURL(context.Context, *CreateSignedURLRequest) (*CreateSignedURLResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateSignedURL not implemented")
 }
+func (UnimplementedControllerServer) CreateSignedDownloadURL(context.Context, *CreateSignedDownloadURLRequest) (*CreateSignedDownloadURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSignedDownloadURL not implemented")
+}
 func (UnimplementedControllerServer) GetObjectMd5(context.Context, *GetObjectMd5Request) (*GetObjectMd5Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetObjectMd5 not implemented")
 }
 func (UnimplementedControllerServer) BindIdentity(context.Context, *BindIdentityRequest) (*BindIdentityResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BindIdentity not implemented")
 }
+func (UnimplementedControllerServer) GetObject(context.Context, *GetObjectRequest) (*GetObjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetObject not implemented")
+}
+func (UnimplementedControllerServer) ListObjects(context.Context, *ListObjectsRequest) (*ListObjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListObjects not implemented")
+}
+func (UnimplementedControllerServer) StatObject(context.Context, *StatObjectRequest) (*StatObjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StatObject not implemented")
+}
+func (UnimplementedControllerServer) DeleteObjects(context.Context, *DeleteObjectsRequest) (*DeleteObjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteObjects not implemented")
+}
 func (UnimplementedControllerServer) mustEmbedUnimplementedControllerServer() {}
 
 // UnsafeControllerServer may be embedded to opt out of forward compatibility for this service.
@@ -112,6 +182,24 @@ func _Controller_CreateSignedURL_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Controller_CreateSignedDownloadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSignedDownloadURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).CreateSignedDownloadURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sci.v1.Controller/CreateSignedDownloadURL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).CreateSignedDownloadURL(ctx, req.(*CreateSignedDownloadURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Controller_GetObjectMd5_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetObjectMd5Request)
 	if err := dec(in); err != nil {
@@ -148,6 +236,78 @@ func _Controller_BindIdentity_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Controller_GetObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).GetObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sci.v1.Controller/GetObject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).GetObject(ctx, req.(*GetObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_ListObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListObjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).ListObjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sci.v1.Controller/ListObjects",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).ListObjects(ctx, req.(*ListObjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_StatObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).StatObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sci.v1.Controller/StatObject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).StatObject(ctx, req.(*StatObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_DeleteObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteObjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).DeleteObjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sci.v1.Controller/DeleteObjects",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).DeleteObjects(ctx, req.(*DeleteObjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Controller_ServiceDesc is the grpc.ServiceDesc for Controller service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -159,6 +319,10 @@ var Controller_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateSignedURL",
 			Handler:    _Controller_CreateSignedURL_Handler,
 		},
+		{
+			MethodName: "CreateSignedDownloadURL",
+			Handler:    _Controller_CreateSignedDownloadURL_Handler,
+		},
 		{
 			MethodName: "GetObjectMd5",
 			Handler:    _Controller_GetObjectMd5_Handler,
@@ -167,6 +331,22 @@ var Controller_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BindIdentity",
 			Handler:    _Controller_BindIdentity_Handler,
 		},
+		{
+			MethodName: "GetObject",
+			Handler:    _Controller_GetObject_Handler,
+		},
+		{
+			MethodName: "ListObjects",
+			Handler:    _Controller_ListObjects_Handler,
+		},
+		{
+			MethodName: "StatObject",
+			Handler:    _Controller_StatObject_Handler,
+		},
+		{
+			MethodName: "DeleteObjects",
+			Handler:    _Controller_DeleteObjects_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "sci.proto",
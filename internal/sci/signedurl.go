@@ -0,0 +1,196 @@
+package sci
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// URLSigner signs and verifies the URLs of the signed URL handlers that the
+// kind and pvc servers use in place of a cloud bucket. A signature binds the
+// method, the object path, the expiration and (for uploads) the MD5 checksum
+// of the content.
+type URLSigner struct {
+	// Key is the HMAC key that URLs are signed with.
+	Key []byte
+}
+
+// LoadSigningKey reads the signing key from a file. A random key is generated
+// when no file is given (URLs are then invalidated when the server restarts).
+func LoadSigningKey(file string) ([]byte, error) {
+	if file == "" {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("generating signing key: %w", err)
+		}
+		return key, nil
+	}
+	key, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading signing key: %w", err)
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("signing key file %q is empty", file)
+	}
+	return key, nil
+}
+
+// SignURL returns a URL (served at address) for the given method and object
+// path that expires after the given number of seconds.
+func (s URLSigner) SignURL(address, method, path, md5Hex string, expirationSeconds int64) (string, error) {
+	if len(s.Key) == 0 {
+		return "", errors.New("no signing key configured")
+	}
+	if expirationSeconds <= 0 {
+		return "", fmt.Errorf("expiration seconds must be positive, got %v", expirationSeconds)
+	}
+	expires := strconv.FormatInt(time.Now().Unix()+expirationSeconds, 10)
+
+	q := url.Values{}
+	q.Set("expires", expires)
+	q.Set("signature", s.signature(method, path, md5Hex, expires))
+	return fmt.Sprintf("%v/%v?%v", address, strings.TrimPrefix(path, "/"), q.Encode()), nil
+}
+
+// VerifyURL returns an error unless the query of a request carries an
+// unexpired signature for the given method, object path and MD5 checksum.
+func (s URLSigner) VerifyURL(method, path, md5Hex string, q url.Values) error {
+	if len(s.Key) == 0 {
+		return errors.New("no signing key configured")
+	}
+	expires := q.Get("expires")
+	expiresUnix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid expiration %q", expires)
+	}
+	if time.Now().Unix() > expiresUnix {
+		return fmt.Errorf("url expired at %v", time.Unix(expiresUnix, 0))
+	}
+	sig, err := hex.DecodeString(q.Get("signature"))
+	if err != nil {
+		return errors.New("invalid signature encoding")
+	}
+	expected, _ := hex.DecodeString(s.signature(method, path, md5Hex, expires))
+	if !hmac.Equal(sig, expected) {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
+
+func (s URLSigner) signature(method, path, md5Hex, expires string) string {
+	mac := hmac.New(sha256.New, s.Key)
+	mac.Write([]byte(strings.Join([]string{method, path, expires, strings.ToLower(md5Hex)}, "\n")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ServeSignedURL handles a request to a signed URL of the file at path (which
+// the caller must have confined to its bucket directory). PUT requests upload
+// the file and GET and HEAD requests download it.
+func ServeSignedURL(w http.ResponseWriter, r *http.Request, signer URLSigner, path string) {
+	switch r.Method {
+	case http.MethodPut:
+		// Expect "Content-Type: application/octet-stream" in a PUT request and save the body to a file.
+		if r.Header.Get("Content-Type") != "application/octet-stream" {
+			log.Print("client sent wrong content-type")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		md5B64 := r.Header.Get("Content-MD5")
+		if md5B64 == "" {
+			log.Print("client did not send content-md5")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		md5Raw, err := base64.StdEncoding.DecodeString(md5B64)
+		if err != nil {
+			log.Printf("content-md5 is not base64 encoded: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		md5Hex := hex.EncodeToString(md5Raw)
+
+		if err := signer.VerifyURL(http.MethodPut, path, md5Hex, r.URL.Query()); err != nil {
+			log.Printf("rejected upload: %v", err)
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		if err := SaveUpload(r.Body, path, md5Hex); err != nil {
+			if errors.Is(err, ErrChecksumMismatch) {
+				log.Printf("rejected upload: %v", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			log.Printf("failed to save upload: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	case http.MethodGet, http.MethodHead:
+		// HEAD requests are allowed with GET signatures.
+		if err := signer.VerifyURL(http.MethodGet, path, "", r.URL.Query()); err != nil {
+			log.Printf("rejected download: %v", err)
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		ServeFile(w, r, path)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// ErrChecksumMismatch is returned by SaveUpload when the content does not
+// match its MD5 checksum.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// SaveUpload writes the content of r to path and records its MD5 checksum
+// in an "md5.txt" file next to it. The content is written to a temporary
+// file first so that a partial upload (or one that does not match md5Hex)
+// never replaces the previous one.
+func SaveUpload(r io.Reader, path, md5Hex string) error {
+	// path should look like: "/bucket/<guid>/..."
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("mkdir (all): %v", err)
+	}
+
+	f, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	h := md5.New()
+	if _, err := io.Copy(io.MultiWriter(f, h), r); err != nil {
+		return fmt.Errorf("copy: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close: %w", err)
+	}
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != md5Hex {
+		return fmt.Errorf("%w: content-md5 is %v but the body is %v", ErrChecksumMismatch, md5Hex, sum)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("rename: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "md5.txt"), []byte(md5Hex), 0644); err != nil {
+		return fmt.Errorf("write md5 file: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package sci
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// ServerTLSConfig returns the TLS config of an SCI gRPC server. The key pair
+// and the client CA bundle are reloaded from disk when they change so that
+// rotated certificates (i.e. issued by cert-manager) are picked up without a
+// restart. When a client CA file is set, clients must present a certificate
+// that was signed by it (mTLS).
+func ServerTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	certs := newKeyPairReloader(certFile, keyFile)
+	if _, err := certs.get(); err != nil {
+		return nil, err
+	}
+	var clientCAs *reloader[*x509.CertPool]
+	if clientCAFile != "" {
+		clientCAs = newCAReloader(clientCAFile)
+		if _, err := clientCAs.get(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, err := certs.get()
+			if err != nil {
+				return nil, err
+			}
+			cfg := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*cert},
+			}
+			if clientCAs != nil {
+				pool, err := clientCAs.get()
+				if err != nil {
+					return nil, err
+				}
+				cfg.ClientCAs = pool
+				cfg.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return cfg, nil
+		},
+	}, nil
+}
+
+// ClientTLSConfig returns the TLS config that the controller uses to connect
+// to the SCI. The server certificate is verified against the CA bundle (or
+// the system roots when caFile is empty). The client key pair is presented
+// for mTLS when set. Files are reloaded when they change.
+func ClientTLSConfig(caFile, certFile, keyFile, serverName string) (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+	}
+
+	if certFile != "" || keyFile != "" {
+		certs := newKeyPairReloader(certFile, keyFile)
+		if _, err := certs.get(); err != nil {
+			return nil, err
+		}
+		cfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return certs.get()
+		}
+	}
+
+	if caFile != "" {
+		roots := newCAReloader(caFile)
+		if _, err := roots.get(); err != nil {
+			return nil, err
+		}
+		// The server certificate is verified below instead, against the
+		// latest CA bundle (RootCAs can not be reloaded).
+		cfg.InsecureSkipVerify = true
+		cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+			pool, err := roots.get()
+			if err != nil {
+				return err
+			}
+			return verifyServerCertificate(cs, pool)
+		}
+	}
+
+	return cfg, nil
+}
+
+func verifyServerCertificate(cs tls.ConnectionState, roots *x509.CertPool) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("no server certificate")
+	}
+	opts := x509.VerifyOptions{
+		DNSName:       cs.ServerName,
+		Roots:         roots,
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, cert := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := cs.PeerCertificates[0].Verify(opts)
+	return err
+}
+
+func newKeyPairReloader(certFile, keyFile string) *reloader[*tls.Certificate] {
+	return &reloader[*tls.Certificate]{
+		files: []string{certFile, keyFile},
+		load: func() (*tls.Certificate, error) {
+			cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+			if err != nil {
+				return nil, fmt.Errorf("loading key pair: %w", err)
+			}
+			return &cert, nil
+		},
+	}
+}
+
+func newCAReloader(caFile string) *reloader[*x509.CertPool] {
+	return &reloader[*x509.CertPool]{
+		files: []string{caFile},
+		load: func() (*x509.CertPool, error) {
+			data, err := os.ReadFile(caFile)
+			if err != nil {
+				return nil, fmt.Errorf("reading CA file: %w", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(data) {
+				return nil, fmt.Errorf("no certificates found in CA file: %v", caFile)
+			}
+			return pool, nil
+		},
+	}
+}
+
+// reloader caches a value that is loaded from files and loads it again when
+// the modification time of any of the files changes. Kubernetes updates
+// mounted Secrets by swapping symlinks, which also results in new
+// modification times.
+type reloader[T any] struct {
+	files []string
+	load  func() (T, error)
+
+	mtx     sync.Mutex
+	loaded  bool
+	value   T
+	modTime time.Time
+}
+
+func (r *reloader[T]) get() (T, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	var modTime time.Time
+	for _, f := range r.files {
+		info, err := os.Stat(f)
+		if err != nil {
+			if r.loaded {
+				// Keep serving the last value while files are being replaced.
+				return r.value, nil
+			}
+			var zero T
+			return zero, err
+		}
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+	if r.loaded && modTime.Equal(r.modTime) {
+		return r.value, nil
+	}
+
+	value, err := r.load()
+	if err != nil {
+		if r.loaded {
+			log.Printf("Failed to reload %v, keeping the previous version: %v", r.files, err)
+			return r.value, nil
+		}
+		return value, err
+	}
+	if r.loaded {
+		log.Printf("Reloaded %v", r.files)
+	}
+	r.loaded = true
+	r.value = value
+	r.modTime = modTime
+	return value, nil
+}
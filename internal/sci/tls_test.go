@@ -0,0 +1,140 @@
+package sci_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	hv1 "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/substratusai/substratus/internal/sci"
+)
+
+func TestTLS(t *testing.T) {
+	dir := t.TempDir()
+	file := func(name string) string { return filepath.Join(dir, name) }
+
+	ca := newTestCA(t, "sci-ca")
+	ca.write(t, file("ca.crt"))
+	ca.issue(t, "sci.test", x509.ExtKeyUsageServerAuth).write(t, file("server.crt"), file("server.key"))
+	ca.issue(t, "controller-manager", x509.ExtKeyUsageClientAuth).write(t, file("client.crt"), file("client.key"))
+
+	serverCfg, err := sci.ServerTLSConfig(file("server.crt"), file("server.key"), file("ca.crt"))
+	require.NoError(t, err)
+	gs := grpc.NewServer(grpc.Creds(credentials.NewTLS(serverCfg)))
+	hv1.RegisterHealthServer(gs, health.NewServer())
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+
+	check := func(t *testing.T, caFile, certFile, keyFile string) error {
+		cfg, err := sci.ClientTLSConfig(caFile, certFile, keyFile, "sci.test")
+		require.NoError(t, err)
+		conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(cfg)))
+		require.NoError(t, err)
+		defer conn.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err = hv1.NewHealthClient(conn).Check(ctx, &hv1.HealthCheckRequest{})
+		return err
+	}
+
+	t.Log("Connecting with a client certificate")
+	require.NoError(t, check(t, file("ca.crt"), file("client.crt"), file("client.key")))
+
+	t.Log("Connecting without a client certificate")
+	require.Error(t, check(t, file("ca.crt"), "", ""))
+
+	t.Log("Connecting with a client certificate of another CA")
+	other := newTestCA(t, "other-ca")
+	other.write(t, file("other-ca.crt"))
+	other.issue(t, "controller-manager", x509.ExtKeyUsageClientAuth).write(t, file("other-client.crt"), file("other-client.key"))
+	require.Error(t, check(t, file("ca.crt"), file("other-client.crt"), file("other-client.key")))
+
+	t.Log("Verifying the server against another CA")
+	require.Error(t, check(t, file("other-ca.crt"), file("client.crt"), file("client.key")))
+
+	t.Log("Rotating all certificates")
+	rotated := newTestCA(t, "rotated-ca")
+	rotated.write(t, file("ca.crt"))
+	rotated.issue(t, "sci.test", x509.ExtKeyUsageServerAuth).write(t, file("server.crt"), file("server.key"))
+	rotated.issue(t, "controller-manager", x509.ExtKeyUsageClientAuth).write(t, file("client.crt"), file("client.key"))
+	require.NoError(t, check(t, file("ca.crt"), file("client.crt"), file("client.key")))
+	require.Error(t, check(t, file("ca.crt"), file("other-client.crt"), file("other-client.key")))
+}
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T, name string) *testCert {
+	return createTestCert(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: name},
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}, nil)
+}
+
+func (ca *testCert) issue(t *testing.T, name string, usage x509.ExtKeyUsage) *testCert {
+	return createTestCert(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: name},
+		DNSNames:    []string{name},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{usage},
+	}, ca)
+}
+
+func createTestCert(t *testing.T, tmpl *x509.Certificate, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	tmpl.SerialNumber = serial
+	tmpl.NotBefore = time.Now().Add(-time.Hour)
+	tmpl.NotAfter = time.Now().Add(time.Hour)
+
+	parentCert, parentKey := tmpl, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parentCert, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCert{cert: cert, key: key}
+}
+
+// testModTime is moved forward with every write to make sure that rewritten
+// files are noticed (regardless of the resolution of the filesystem).
+var testModTime = time.Now()
+
+// write writes the certificate and optionally the key.
+func (c *testCert) write(t *testing.T, certFile string, keyFile ...string) {
+	testModTime = testModTime.Add(time.Minute)
+	future := testModTime
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}), 0600))
+	require.NoError(t, os.Chtimes(certFile, future, future))
+	for _, f := range keyFile {
+		der, err := x509.MarshalECPrivateKey(c.key)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(f, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0600))
+		require.NoError(t, os.Chtimes(f, future, future))
+	}
+}
@@ -15,7 +15,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
-	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 	"github.com/substratusai/substratus/internal/cli/utils"
 	"github.com/substratusai/substratus/internal/client"
 )
@@ -169,12 +169,12 @@ func createWithUploadCmd(ctx context.Context, res *client.Resource, obj client.O
 
 			var version int
 			switch list := list.(type) {
-			case *apiv1.ModelList:
+			case *apiv1beta1.ModelList:
 				version, err = nextModelVersion(list, obj.GetName())
 				if err != nil {
 					return fmt.Errorf("next model version: %w", err)
 				}
-			case *apiv1.DatasetList:
+			case *apiv1beta1.DatasetList:
 				version, err = nextDatasetVersion(list, obj.GetName())
 				if err != nil {
 					return fmt.Errorf("next dataset version: %w", err)
@@ -224,7 +224,7 @@ func waitReadyCmd(ctx context.Context, res *client.Resource, obj client.Object)
 	}
 }
 
-func nextModelVersion(list *apiv1.ModelList, name string) (int, error) {
+func nextModelVersion(list *apiv1beta1.ModelList, name string) (int, error) {
 	var highestVersion int
 	re := regexp.MustCompile(name + `-(\d+)`)
 	for _, item := range list.Items {
@@ -244,7 +244,7 @@ func nextModelVersion(list *apiv1.ModelList, name string) (int, error) {
 	return highestVersion + 1, nil
 }
 
-func nextDatasetVersion(list *apiv1.DatasetList, name string) (int, error) {
+func nextDatasetVersion(list *apiv1beta1.DatasetList, name string) (int, error) {
 	var highestVersion int
 	re := regexp.MustCompile(name + `-(\d+)`)
 	for _, item := range list.Items {
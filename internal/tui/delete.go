@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 	"github.com/substratusai/substratus/internal/client"
 )
 
@@ -27,6 +30,10 @@ type DeleteModel struct {
 	toDelete []client.Object
 	deleted  []string
 
+	// dependents of the objects to delete (by name), see client.Dependents.
+	dependents map[string][]string
+	confirmed  bool
+
 	Style lipgloss.Style
 
 	// End times
@@ -81,19 +88,40 @@ func (m DeleteModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		log.Println("Received key msg:", msg.String())
-		if msg.String() == "q" {
+		switch msg.String() {
+		case "q":
+			return m, tea.Quit
+		case "y":
+			if m.dependents == nil || m.confirmed {
+				break
+			}
+			m.confirmed = true
+			var cmds []tea.Cmd
+			for _, obj := range m.toDelete {
+				if len(m.dependents[obj.GetName()]) > 0 {
+					cmds = append(cmds, forceDeleteCmd(m.Ctx, m.resource, obj))
+				} else {
+					cmds = append(cmds, deleteCmd(m.Ctx, m.resource, obj))
+				}
+			}
+			return m, tea.Sequence(cmds...)
+		case "n":
+			if m.dependents == nil || m.confirmed {
+				break
+			}
+			m.goodbye = "Nothing deleted"
 			return m, tea.Quit
 		}
 
 	case deletionListMsg:
-		var cmds []tea.Cmd
 		m.toDelete = msg.items
 		for _, obj := range msg.items {
 			log.Printf("to-delete: %v", obj.GetName())
-			// TODO: Implement a confirmation flow.
-			cmds = append(cmds, deleteCmd(m.Ctx, m.resource, obj))
 		}
-		return m, tea.Sequence(cmds...)
+		return m, getDependentsCmd(m.Client, msg.items)
+
+	case dependentsMsg:
+		m.dependents = msg.dependents
 
 	case deletedMsg:
 		if msg.error != nil {
@@ -107,6 +135,9 @@ func (m DeleteModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.WindowSizeMsg:
 		m.Style.Width(msg.Width)
+
+	case error:
+		m.finalError = msg
 	}
 
 	return m, nil
@@ -130,6 +161,29 @@ func (m DeleteModel) View() (v string) {
 		return
 	}
 
+	if !m.confirmed && m.dependents != nil {
+		var referenced bool
+		for _, obj := range m.toDelete {
+			deps := m.dependents[obj.GetName()]
+			if len(deps) == 0 {
+				continue
+			}
+			referenced = true
+			v += objectScope(obj) + " is referenced by:\n"
+			for _, dep := range deps {
+				v += "  " + dep + "\n"
+			}
+		}
+		if referenced {
+			v += "Delete anyway? (y/n)\n"
+		} else {
+			for _, obj := range m.toDelete {
+				v += "Delete " + objectScope(obj) + "? (y/n)\n"
+			}
+		}
+		return
+	}
+
 	for _, name := range m.deleted {
 		v += checkMark.String() + " " + name + ": deleted\n"
 	}
@@ -149,6 +203,7 @@ func getDeletionTargetsCmd(ctx context.Context, res *client.Resource, obj client
 			if err != nil {
 				return fmt.Errorf("get: %w", err)
 			}
+			fetched.GetObjectKind().SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
 			return deletionListMsg{items: []client.Object{fetched.(client.Object)}}
 		} else {
 			items, err := res.List(obj.GetNamespace(), "", &metav1.ListOptions{})
@@ -160,3 +215,39 @@ func getDeletionTargetsCmd(ctx context.Context, res *client.Resource, obj client
 		}
 	}
 }
+
+type dependentsMsg struct {
+	dependents map[string][]string
+}
+
+func getDependentsCmd(c client.Interface, objs []client.Object) tea.Cmd {
+	return func() tea.Msg {
+		dependents := map[string][]string{}
+		for _, obj := range objs {
+			deps, err := client.Dependents(c, obj)
+			if err != nil {
+				return fmt.Errorf("dependents: %w", err)
+			}
+			dependents[obj.GetName()] = deps
+		}
+		return dependentsMsg{dependents: dependents}
+	}
+}
+
+// forceDeleteCmd deletes an object that is still referenced. The controller
+// would otherwise wait for the dependents to be deleted first.
+func forceDeleteCmd(ctx context.Context, res *client.Resource, obj client.Object) tea.Cmd {
+	return func() tea.Msg {
+		patch := fmt.Sprintf(`{"metadata": {"annotations": {%q: "true"}}}`, apiv1beta1.ForceDeleteAnnotation)
+		if _, err := res.Patch(obj.GetNamespace(), obj.GetName(), types.MergePatchType, []byte(patch), &metav1.PatchOptions{}); err != nil {
+			log.Printf("Error forcing deletion: %v", err)
+			return deletedMsg{name: obj.GetName(), error: err}
+		}
+		return deleteCmd(ctx, res, obj)()
+	}
+}
+
+// objectScope returns the scope of an object, i.e. "models/<name>".
+func objectScope(obj client.Object) string {
+	return strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind) + "s/" + obj.GetName()
+}
@@ -13,7 +13,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/watch"
 
-	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 	"github.com/substratusai/substratus/internal/client"
 )
 
@@ -161,7 +161,12 @@ func (m GetModel) View() (v string) {
 			} else {
 				indicator = o.spinner.View()
 			}
-			v += "" + indicator + " " + name + "\n"
+			v += "" + indicator + " " + name + artifactsView(o.object) + "\n"
+			if !o.GetStatusReady() {
+				if pv := trainingProgressView(o.object, 0); pv != "" {
+					v += lipgloss.NewStyle().PaddingLeft(2).Render(strings.TrimSuffix(pv, "\n")) + "\n"
+				}
+			}
 		}
 		v += "\n"
 	}
@@ -175,6 +180,35 @@ func (m GetModel) View() (v string) {
 	return v
 }
 
+// artifactsView returns the size of the artifacts of an object (if known).
+func artifactsView(o object) string {
+	ao, ok := o.(interface {
+		GetStatusArtifacts() apiv1beta1.ArtifactsStatus
+	})
+	if !ok {
+		return ""
+	}
+	a := ao.GetStatusArtifacts()
+	if a.FileCount == 0 {
+		return ""
+	}
+	return sizeStyle(fmt.Sprintf(" (%v, %v files)", formatBytes(a.SizeBytes), a.FileCount))
+}
+
+// formatBytes formats a size in bytes using binary units (i.e. "1.5 GiB").
+func formatBytes(b int64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := int64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
+
 type watchMsg struct {
 	watch.Event
 	resource string
@@ -230,10 +264,10 @@ func watchCmd(ctx context.Context, c client.Interface, namespace, scope string)
 func scopeToObjects(scope string) ([]client.Object, error) {
 	if scope == "" {
 		return []client.Object{
-			&apiv1.Notebook{TypeMeta: metav1.TypeMeta{APIVersion: "substratus.ai/v1", Kind: "Notebook"}},
-			&apiv1.Dataset{TypeMeta: metav1.TypeMeta{APIVersion: "substratus.ai/v1", Kind: "Dataset"}},
-			&apiv1.Model{TypeMeta: metav1.TypeMeta{APIVersion: "substratus.ai/v1", Kind: "Model"}},
-			&apiv1.Server{TypeMeta: metav1.TypeMeta{APIVersion: "substratus.ai/v1", Kind: "Server"}},
+			&apiv1beta1.Notebook{TypeMeta: metav1.TypeMeta{APIVersion: "substratus.ai/v1beta1", Kind: "Notebook"}},
+			&apiv1beta1.Dataset{TypeMeta: metav1.TypeMeta{APIVersion: "substratus.ai/v1beta1", Kind: "Dataset"}},
+			&apiv1beta1.Model{TypeMeta: metav1.TypeMeta{APIVersion: "substratus.ai/v1beta1", Kind: "Model"}},
+			&apiv1beta1.Server{TypeMeta: metav1.TypeMeta{APIVersion: "substratus.ai/v1beta1", Kind: "Server"}},
 		}, nil
 	}
 
@@ -253,14 +287,14 @@ func scopeToObject(scope string) (client.Object, error) {
 
 	var obj client.Object
 	switch res {
-	case "notebooks":
-		obj = &apiv1.Notebook{TypeMeta: metav1.TypeMeta{APIVersion: "substratus.ai/v1", Kind: "Notebook"}}
-	case "datasets":
-		obj = &apiv1.Dataset{TypeMeta: metav1.TypeMeta{APIVersion: "substratus.ai/v1", Kind: "Dataset"}}
-	case "models":
-		obj = &apiv1.Model{TypeMeta: metav1.TypeMeta{APIVersion: "substratus.ai/v1", Kind: "Model"}}
-	case "servers":
-		obj = &apiv1.Server{TypeMeta: metav1.TypeMeta{APIVersion: "substratus.ai/v1", Kind: "Server"}}
+	case "notebooks", "notebook":
+		obj = &apiv1beta1.Notebook{TypeMeta: metav1.TypeMeta{APIVersion: "substratus.ai/v1beta1", Kind: "Notebook"}}
+	case "datasets", "dataset":
+		obj = &apiv1beta1.Dataset{TypeMeta: metav1.TypeMeta{APIVersion: "substratus.ai/v1beta1", Kind: "Dataset"}}
+	case "models", "model":
+		obj = &apiv1beta1.Model{TypeMeta: metav1.TypeMeta{APIVersion: "substratus.ai/v1beta1", Kind: "Model"}}
+	case "servers", "server":
+		obj = &apiv1beta1.Server{TypeMeta: metav1.TypeMeta{APIVersion: "substratus.ai/v1beta1", Kind: "Server"}}
 	default:
 		return nil, fmt.Errorf("Invalid scope: %v", scope)
 	}
@@ -13,7 +13,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
-	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 	"github.com/substratusai/substratus/internal/client"
 )
 
@@ -250,7 +250,7 @@ func manifestToObjects(manifest []byte, substratusOnly bool) ([]client.Object, e
 
 		if substratusOnly {
 			switch obj.(type) {
-			case *apiv1.Model, *apiv1.Dataset, *apiv1.Server, *apiv1.Notebook:
+			case *apiv1beta1.Model, *apiv1beta1.Dataset, *apiv1beta1.Server, *apiv1beta1.Notebook:
 				m = append(m, obj)
 			}
 		} else {
@@ -12,7 +12,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/utils/ptr"
 
-	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 	"github.com/substratusai/substratus/internal/client"
 )
 
@@ -31,7 +31,7 @@ type NotebookModel struct {
 	K8s    *kubernetes.Clientset
 
 	// Current notebook
-	notebook *apiv1.Notebook
+	notebook *apiv1beta1.Notebook
 	resource *client.Resource
 
 	// Proceses
@@ -187,7 +187,7 @@ func (m NotebookModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, m.cleanupAndQuitCmd)
 
 	case tarballUploadedMsg:
-		m.notebook = msg.Object.(*apiv1.Notebook)
+		m.notebook = msg.Object.(*apiv1beta1.Notebook)
 
 		m.readiness.Object = m.notebook
 		m.readiness.Resource = m.resource
@@ -199,7 +199,7 @@ func (m NotebookModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		)
 
 	case objectReadyMsg:
-		m.notebook = msg.Object.(*apiv1.Notebook)
+		m.notebook = msg.Object.(*apiv1beta1.Notebook)
 		m.syncingFiles = inProgress
 		cmds = append(cmds,
 			notebookSyncFilesCmd(m.Ctx, m.Client, m.notebook.DeepCopy(), m.Path),
@@ -303,7 +303,7 @@ type notebookFileSyncMsg struct {
 	error    error
 }
 
-func notebookSyncFilesCmd(ctx context.Context, c client.Interface, nb *apiv1.Notebook, dir string) tea.Cmd {
+func notebookSyncFilesCmd(ctx context.Context, c client.Interface, nb *apiv1beta1.Notebook, dir string) tea.Cmd {
 	return func() tea.Msg {
 		if err := c.SyncFilesFromNotebook(ctx, nb, dir, LogFile, func(file string, complete bool, syncErr error) {
 			P.Send(notebookFileSyncMsg{
@@ -320,7 +320,7 @@ func notebookSyncFilesCmd(ctx context.Context, c client.Interface, nb *apiv1.Not
 	}
 }
 
-func notebookOpenInBrowser(nb *apiv1.Notebook) tea.Cmd {
+func notebookOpenInBrowser(nb *apiv1beta1.Notebook) tea.Cmd {
 	return func() tea.Msg {
 		// TODO(nstogner): Grab token from Notebook status.
 		url := "http://localhost:8888?token=default"
@@ -0,0 +1,217 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
+	"github.com/substratusai/substratus/internal/cli/utils"
+	"github.com/substratusai/substratus/internal/client"
+)
+
+// maxPullRequests is the number of times download URLs are requested before
+// giving up (URLs are requested again when they expire).
+const maxPullRequests = 3
+
+type PullModel struct {
+	// Cancellation
+	Ctx context.Context
+
+	// Config
+	Scope       string
+	Namespace   Namespace
+	Dir         string
+	Parallelism int
+
+	// Clients
+	Client client.Interface
+
+	object   client.Object
+	resource *client.Resource
+
+	requests    int
+	requesting  status
+	downloading status
+
+	files           int
+	totalBytes      int64
+	downloadedBytes int64
+	progress        progress.Model
+
+	Style lipgloss.Style
+
+	// End times
+	goodbye    string
+	finalError error
+}
+
+func (m *PullModel) New() PullModel {
+	m.Style = appStyle
+	m.progress = progress.New(progress.WithDefaultGradient())
+	return *m
+}
+
+type pullInitMsg struct{}
+
+func (m PullModel) Init() tea.Cmd {
+	return func() tea.Msg { return pullInitMsg{} }
+}
+
+func (m PullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case pullInitMsg:
+		obj, err := scopeToObject(m.Scope)
+		if err != nil {
+			m.finalError = fmt.Errorf("scope to object: %w", err)
+			return m, nil
+		}
+		if obj.GetName() == "" {
+			m.finalError = fmt.Errorf("name required: %v", m.Scope)
+			return m, nil
+		}
+		if _, ok := obj.(interface {
+			GetStatusDownload() *apiv1beta1.DownloadStatus
+		}); !ok {
+			m.finalError = fmt.Errorf("only Models and Datasets can be pulled: %v", m.Scope)
+			return m, nil
+		}
+		m.Namespace.Set(obj)
+		m.object = obj
+
+		res, err := m.Client.Resource(obj)
+		if err != nil {
+			m.finalError = fmt.Errorf("resource client: %w", err)
+			return m, nil
+		}
+		m.resource = res
+
+		m.requests++
+		m.requesting = inProgress
+		return m, requestDownloadCmd(m.Ctx, m.resource, m.object)
+
+	case downloadRequestedMsg:
+		m.requesting = completed
+		m.downloading = inProgress
+		m.files = len(msg.Files)
+		m.totalBytes = 0
+		m.downloadedBytes = 0
+		for _, f := range msg.Files {
+			m.totalBytes += f.Size
+		}
+		return m, downloadFilesCmd(m.Ctx, m.Dir, msg, m.Parallelism)
+
+	case downloadProgressMsg:
+		m.downloadedBytes += int64(msg)
+		if m.totalBytes == 0 {
+			return m, nil
+		}
+		return m, m.progress.SetPercent(float64(m.downloadedBytes) / float64(m.totalBytes))
+
+	case filesDownloadedMsg:
+		if msg.error != nil {
+			if errors.Is(msg.error, client.ErrDownloadExpired) && m.requests < maxPullRequests {
+				log.Println("Download URLs expired, requesting new ones")
+				m.requests++
+				m.requesting = inProgress
+				m.downloading = notStarted
+				return m, requestDownloadCmd(m.Ctx, m.resource, m.object)
+			}
+			m.finalError = msg.error
+			return m, nil
+		}
+		m.downloading = completed
+		m.goodbye = fmt.Sprintf("Pulled %v files (%v) into %v", m.files, formatBytes(m.totalBytes), m.Dir)
+		return m, tea.Quit
+
+	case tea.KeyMsg:
+		log.Println("Received key msg:", msg.String())
+		if msg.String() == "q" {
+			return m, tea.Quit
+		}
+
+	case tea.WindowSizeMsg:
+		m.Style.Width(msg.Width)
+
+	// FrameMsg is sent when the progress bar wants to animate itself
+	case progress.FrameMsg:
+		progressModel, cmd := m.progress.Update(msg)
+		m.progress = progressModel.(progress.Model)
+		return m, cmd
+
+	case error:
+		m.finalError = msg
+	}
+
+	return m, nil
+}
+
+// View returns a string based on data in the model. That string which will be
+// rendered to the terminal.
+func (m PullModel) View() (v string) {
+	defer func() {
+		if m.goodbye == "" {
+			v += helpStyle("Press \"q\" to quit")
+		}
+		v = m.Style.Render(v)
+	}()
+
+	if m.finalError != nil {
+		v += errorStyle.Width(m.Style.GetWidth()-m.Style.GetHorizontalMargins()-10).Render("Error: "+m.finalError.Error()) + "\n"
+		return
+	}
+
+	if m.goodbye != "" {
+		v += checkMark.String() + " " + m.goodbye + "\n"
+		return
+	}
+
+	if m.requesting == inProgress {
+		v += "Requesting download URLs...\n"
+	}
+
+	if m.downloading == inProgress {
+		m.progress.Width = m.Style.GetWidth() - m.Style.GetHorizontalMargins()
+		v += fmt.Sprintf("Downloading %v files (%v)...\n\n", m.files, formatBytes(m.totalBytes))
+		v += m.progress.View() + "\n\n"
+	}
+
+	return
+}
+
+type downloadRequestedMsg *apiv1beta1.DownloadStatus
+
+func requestDownloadCmd(ctx context.Context, res *client.Resource, obj client.Object) tea.Cmd {
+	return func() tea.Msg {
+		log.Println("Requesting download")
+		download, err := res.RequestDownload(ctx, obj, utils.NewUUID())
+		if err != nil {
+			return fmt.Errorf("requesting download: %w", err)
+		}
+		return downloadRequestedMsg(download)
+	}
+}
+
+type downloadProgressMsg int64
+
+type filesDownloadedMsg struct {
+	error error
+}
+
+func downloadFilesCmd(ctx context.Context, dir string, download *apiv1beta1.DownloadStatus, parallelism int) tea.Cmd {
+	return func() tea.Msg {
+		log.Printf("Downloading %v files into %v", len(download.Files), dir)
+		err := client.DownloadFiles(ctx, dir, download, parallelism, func(n int64) {
+			P.Send(downloadProgressMsg(n))
+		})
+		if err != nil {
+			log.Println("Download failed", err)
+		}
+		return filesDownloadedMsg{error: err}
+	}
+}
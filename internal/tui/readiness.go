@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 
+	"github.com/charmbracelet/bubbles/progress"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 	"github.com/substratusai/substratus/internal/client"
 )
 
@@ -93,6 +97,7 @@ func (m readinessModel) View() (v string) {
 				v += "\n"
 			}
 		}
+		v += trainingProgressView(m.Object, m.Style.GetWidth()-m.Style.GetHorizontalPadding())
 	} else if m.waiting == completed {
 		kind := m.Object.GetObjectKind().GroupVersionKind().Kind
 		v += fmt.Sprintf("%v (%v): Ready\n", kind, m.Object.GetName())
@@ -100,3 +105,49 @@ func (m readinessModel) View() (v string) {
 
 	return v
 }
+
+// trainingProgressView renders the training progress of a Model (if reported).
+func trainingProgressView(obj client.Object, width int) (v string) {
+	model, ok := obj.(*apiv1beta1.Model)
+	if !ok || model.Status.Progress == nil {
+		return ""
+	}
+	p := model.Status.Progress
+
+	var stats []string
+	if p.TotalSteps > 0 {
+		stats = append(stats, fmt.Sprintf("Step %v/%v", p.Step, p.TotalSteps))
+	} else {
+		stats = append(stats, fmt.Sprintf("Step %v", p.Step))
+	}
+	if p.Epoch != "" {
+		stats = append(stats, "Epoch "+p.Epoch)
+	}
+	if p.Loss != "" {
+		stats = append(stats, "Loss "+p.Loss)
+	}
+	v += strings.Join(stats, " | ") + "\n"
+
+	if p.TotalSteps > 0 {
+		bar := progress.New(progress.WithDefaultGradient())
+		if width > 0 {
+			bar.Width = width
+		}
+		v += bar.ViewAs(float64(p.Step)/float64(p.TotalSteps)) + "\n"
+	}
+
+	if len(p.Metrics) > 0 {
+		var keys []string
+		for k := range p.Metrics {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var metrics []string
+		for _, k := range keys {
+			metrics = append(metrics, k+"="+p.Metrics[k])
+		}
+		v += strings.Join(metrics, " ") + "\n"
+	}
+
+	return v
+}
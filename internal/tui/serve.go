@@ -12,7 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 
-	apiv1 "github.com/substratusai/substratus/api/v1"
+	apiv1beta1 "github.com/substratusai/substratus/api/v1beta1"
 	"github.com/substratusai/substratus/internal/client"
 )
 
@@ -31,7 +31,7 @@ type ServeModel struct {
 	K8s    *kubernetes.Clientset
 
 	// Current Server
-	server   *apiv1.Server
+	server   *apiv1beta1.Server
 	resource *client.Resource
 	readyPod *corev1.Pod
 
@@ -117,7 +117,7 @@ func (m ServeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case manifestSelectedMsg:
 		m.Namespace.Set(msg.obj)
-		m.server = msg.obj.(*apiv1.Server)
+		m.server = msg.obj.(*apiv1beta1.Server)
 		apply()
 
 	case appliedMsg:
@@ -126,7 +126,7 @@ func (m ServeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			break
 		}
 		m.applying = completed
-		m.server = msg.Object.(*apiv1.Server)
+		m.server = msg.Object.(*apiv1beta1.Server)
 
 		m.readiness.Object = m.server
 		m.readiness.Resource = m.resource
@@ -178,7 +178,7 @@ func (m ServeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, tea.Quit)
 
 	case objectReadyMsg:
-		m.server = msg.Object.(*apiv1.Server)
+		m.server = msg.Object.(*apiv1beta1.Server)
 	// TODO: What to do?
 	// cmds = append(cmds) // TODO: Port-forward to Pod.
 	// portForwardCmd(m.Ctx, m.Client, client.PodForNotebook(m.Server)),
@@ -279,7 +279,7 @@ func (m ServeModel) View() (v string) {
 	return v
 }
 
-func serverOpenInBrowser(s *apiv1.Server) tea.Cmd {
+func serverOpenInBrowser(s *apiv1beta1.Server) tea.Cmd {
 	return func() tea.Msg {
 		url := "http://localhost:8000"
 		log.Printf("Opening browser to %s\n", url)
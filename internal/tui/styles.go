@@ -24,6 +24,8 @@ var (
 			MarginBottom(1).
 			Render
 
+	sizeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#626262")).Render
+
 	errorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#e76f51"))
 
 	activeSpinnerStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#E9C46A"))